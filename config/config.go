@@ -3,6 +3,9 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -24,8 +27,9 @@ func InitDB() (*gorm.DB, error) {
 		host, port, user, password, dbname, sslmode,
 	)
 
-	// Connect to the database
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	// Connect to the database; driver errors such as unique violations are
+	// translated to gorm's sentinel errors so services can report them
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{TranslateError: true})
 	if err != nil {
 		return nil, err
 	}
@@ -33,6 +37,88 @@ func InitDB() (*gorm.DB, error) {
 	return db, nil
 }
 
+// AutoMigrateEnabled reports whether pending migrations run at startup
+func AutoMigrateEnabled() bool {
+	return getEnv("DB_AUTO_MIGRATE", "true") != "false"
+}
+
+// OutboxConfig holds settings for the outbox dispatcher
+type OutboxConfig struct {
+	WebhookURLs    []string
+	PollInterval   time.Duration
+	WebhookTimeout time.Duration
+}
+
+// LoadOutboxConfig reads outbox dispatcher settings from environment variables
+func LoadOutboxConfig() OutboxConfig {
+	var urls []string
+	for _, url := range strings.Split(getEnv("OUTBOX_WEBHOOK_URLS", ""), ",") {
+		if url = strings.TrimSpace(url); url != "" {
+			urls = append(urls, url)
+		}
+	}
+
+	return OutboxConfig{
+		WebhookURLs:    urls,
+		PollInterval:   getDurationEnv("OUTBOX_POLL_INTERVAL", 5*time.Second),
+		WebhookTimeout: getDurationEnv("OUTBOX_WEBHOOK_TIMEOUT", 10*time.Second),
+	}
+}
+
+// TrashConfig holds settings for purging soft-deleted records
+type TrashConfig struct {
+	Retention     time.Duration
+	PurgeInterval time.Duration
+}
+
+// LoadTrashConfig reads soft-delete retention settings from environment variables
+func LoadTrashConfig() TrashConfig {
+	days, err := strconv.Atoi(getEnv("TRASH_RETENTION_DAYS", "30"))
+	if err != nil || days < 0 {
+		days = 30
+	}
+
+	return TrashConfig{
+		Retention:     time.Duration(days) * 24 * time.Hour,
+		PurgeInterval: getDurationEnv("TRASH_PURGE_INTERVAL", time.Hour),
+	}
+}
+
+// IdempotencyConfig holds settings for stored Idempotency-Key responses
+type IdempotencyConfig struct {
+	TTL           time.Duration
+	PurgeInterval time.Duration
+}
+
+// LoadIdempotencyConfig reads idempotency key settings from environment variables
+func LoadIdempotencyConfig() IdempotencyConfig {
+	return IdempotencyConfig{
+		TTL:           getDurationEnv("IDEMPOTENCY_KEY_TTL", 24*time.Hour),
+		PurgeInterval: getDurationEnv("IDEMPOTENCY_PURGE_INTERVAL", time.Hour),
+	}
+}
+
+// AutoFinalizeConfig holds settings for the auto-finalize worker
+type AutoFinalizeConfig struct {
+	PollInterval time.Duration
+}
+
+// LoadAutoFinalizeConfig reads auto-finalize worker settings from environment variables
+func LoadAutoFinalizeConfig() AutoFinalizeConfig {
+	return AutoFinalizeConfig{
+		PollInterval: getDurationEnv("AUTO_FINALIZE_INTERVAL", time.Minute),
+	}
+}
+
+// getDurationEnv parses a duration such as "5s" from an environment variable
+func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
+	value, err := time.ParseDuration(getEnv(key, ""))
+	if err != nil || value <= 0 {
+		return defaultValue
+	}
+	return value
+}
+
 // getEnv retrievess an environment variable or returns a default value
 func getEnv(key, defaultValue string) string {
 	value := os.Getenv(key)
@@ -0,0 +1,76 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/krushnna/meeting-scheduler/middleware"
+	"github.com/krushnna/meeting-scheduler/repository"
+	"github.com/krushnna/meeting-scheduler/services"
+	"go.uber.org/zap"
+)
+
+// AuditController handles HTTP requests for the audit trail.
+type AuditController struct {
+	service *services.AuditService
+	logger  *zap.Logger
+}
+
+func NewAuditController(service *services.AuditService, logger *zap.Logger) *AuditController {
+	return &AuditController{
+		service: service,
+		logger:  logger.With(zap.String("controller", "audit")),
+	}
+}
+
+// GetAuditLogs returns a page of audit entries, newest first unless sorted otherwise.
+// Filters: actor, action, entity_type, entity_id, request_id, since and until (RFC 3339).
+func (c *AuditController) GetAuditLogs(ctx *gin.Context) {
+	page, ok := parsePageRequest(ctx)
+	if !ok {
+		return
+	}
+
+	filter := repository.AuditFilter{
+		Actor:      ctx.Query("actor"),
+		Action:     ctx.Query("action"),
+		EntityType: ctx.Query("entity_type"),
+		RequestID:  ctx.Query("request_id"),
+	}
+
+	if entityIDStr := ctx.Query("entity_id"); entityIDStr != "" {
+		entityID, err := strconv.ParseUint(entityIDStr, 10, 32)
+		if err != nil {
+			ctx.Error(middleware.NewProblem(http.StatusBadRequest, "Invalid entity_id value"))
+			return
+		}
+		filter.EntityID = uint(entityID)
+	}
+
+	var err error
+	if sinceStr := ctx.Query("since"); sinceStr != "" {
+		if filter.Since, err = time.Parse(time.RFC3339, sinceStr); err != nil {
+			ctx.Error(middleware.NewProblem(http.StatusBadRequest, "Invalid since value, expected RFC 3339"))
+			return
+		}
+	}
+	if untilStr := ctx.Query("until"); untilStr != "" {
+		if filter.Until, err = time.Parse(time.RFC3339, untilStr); err != nil {
+			ctx.Error(middleware.NewProblem(http.StatusBadRequest, "Invalid until value, expected RFC 3339"))
+			return
+		}
+	}
+
+	c.logger.Debug("Fetching audit logs", zap.Any("filter", filter), zap.Any("page", page))
+	entries, err := c.service.GetAuditLogs(filter, page)
+	if err != nil {
+		c.logger.Error("Failed to fetch audit logs", zap.Error(err))
+		ctx.Error(err)
+		return
+	}
+
+	c.logger.Info("Retrieved audit logs", zap.Int("count", len(entries.Items)), zap.Int64("total", entries.Total))
+	writePage(ctx, entries)
+}
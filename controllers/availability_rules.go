@@ -0,0 +1,172 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/krushnna/meeting-scheduler/middleware"
+	"github.com/krushnna/meeting-scheduler/models"
+	"github.com/krushnna/meeting-scheduler/services"
+	"go.uber.org/zap"
+)
+
+// AvailabilityRuleController handles HTTP requests for recurring availability rules.
+type AvailabilityRuleController struct {
+	service *services.AvailabilityRuleService
+	logger  *zap.Logger
+}
+
+func NewAvailabilityRuleController(service *services.AvailabilityRuleService, logger *zap.Logger) *AvailabilityRuleController {
+	return &AvailabilityRuleController{
+		service: service,
+		logger:  logger.With(zap.String("controller", "availability_rule")),
+	}
+}
+
+// parseUserID reads the user ID path parameter, reporting a 400 when it is malformed.
+func (c *AvailabilityRuleController) parseUserID(ctx *gin.Context) (uint, bool) {
+	userID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		c.logger.Error("Invalid user ID format", zap.String("user_id", ctx.Param("id")), zap.Error(err))
+		ctx.Error(middleware.NewProblem(http.StatusBadRequest, "Invalid user ID format"))
+		return 0, false
+	}
+	return uint(userID), true
+}
+
+// CreateRule adds a weekly availability rule for a user.
+func (c *AvailabilityRuleController) CreateRule(ctx *gin.Context) {
+	userID, ok := c.parseUserID(ctx)
+	if !ok {
+		return
+	}
+
+	var rule models.AvailabilityRule
+	if err := ctx.ShouldBindJSON(&rule); err != nil {
+		c.logger.Error("Failed to bind JSON", zap.Error(err))
+		ctx.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+	rule.UserID = userID
+
+	c.logger.Info("Creating availability rule", zap.Uint("user_id", userID), zap.Int("weekday", rule.Weekday))
+	if err := c.service.CreateRule(ctx.Request.Context(), &rule); err != nil {
+		c.logger.Error("Failed to create availability rule", zap.Uint("user_id", userID), zap.Error(err))
+		ctx.Error(err)
+		return
+	}
+
+	c.logger.Info("Availability rule created successfully", zap.Uint("rule_id", rule.ID))
+	ctx.JSON(http.StatusCreated, rule)
+}
+
+// GetRules lists a user's weekly availability rules.
+func (c *AvailabilityRuleController) GetRules(ctx *gin.Context) {
+	userID, ok := c.parseUserID(ctx)
+	if !ok {
+		return
+	}
+
+	rules, err := c.service.GetRules(userID)
+	if err != nil {
+		c.logger.Error("Failed to fetch availability rules", zap.Uint("user_id", userID), zap.Error(err))
+		ctx.Error(err)
+		return
+	}
+
+	c.logger.Info("Retrieved availability rules", zap.Uint("user_id", userID), zap.Int("count", len(rules)))
+	ctx.JSON(http.StatusOK, rules)
+}
+
+// DeleteRule removes one of a user's weekly availability rules.
+func (c *AvailabilityRuleController) DeleteRule(ctx *gin.Context) {
+	userID, ok := c.parseUserID(ctx)
+	if !ok {
+		return
+	}
+	ruleID, err := strconv.ParseUint(ctx.Param("ruleId"), 10, 32)
+	if err != nil {
+		c.logger.Error("Invalid rule ID format", zap.String("rule_id", ctx.Param("ruleId")), zap.Error(err))
+		ctx.Error(middleware.NewProblem(http.StatusBadRequest, "Invalid rule ID format"))
+		return
+	}
+
+	c.logger.Info("Deleting availability rule", zap.Uint64("rule_id", ruleID))
+	if err := c.service.DeleteRule(ctx.Request.Context(), userID, uint(ruleID)); err != nil {
+		c.logger.Error("Failed to delete availability rule", zap.Uint64("rule_id", ruleID), zap.Error(err))
+		ctx.Error(err)
+		return
+	}
+
+	c.logger.Info("Availability rule deleted successfully", zap.Uint64("rule_id", ruleID))
+	ctx.JSON(http.StatusOK, gin.H{"message": "Availability rule deleted successfully"})
+}
+
+// CreateException blocks recurring availability on a date for a user.
+func (c *AvailabilityRuleController) CreateException(ctx *gin.Context) {
+	userID, ok := c.parseUserID(ctx)
+	if !ok {
+		return
+	}
+
+	var exception models.AvailabilityException
+	if err := ctx.ShouldBindJSON(&exception); err != nil {
+		c.logger.Error("Failed to bind JSON", zap.Error(err))
+		ctx.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+	exception.UserID = userID
+
+	c.logger.Info("Creating availability exception", zap.Uint("user_id", userID), zap.String("date", exception.Date))
+	if err := c.service.CreateException(ctx.Request.Context(), &exception); err != nil {
+		c.logger.Error("Failed to create availability exception", zap.Uint("user_id", userID), zap.Error(err))
+		ctx.Error(err)
+		return
+	}
+
+	c.logger.Info("Availability exception created successfully", zap.Uint("exception_id", exception.ID))
+	ctx.JSON(http.StatusCreated, exception)
+}
+
+// GetExceptions lists a user's availability exceptions.
+func (c *AvailabilityRuleController) GetExceptions(ctx *gin.Context) {
+	userID, ok := c.parseUserID(ctx)
+	if !ok {
+		return
+	}
+
+	exceptions, err := c.service.GetExceptions(userID)
+	if err != nil {
+		c.logger.Error("Failed to fetch availability exceptions", zap.Uint("user_id", userID), zap.Error(err))
+		ctx.Error(err)
+		return
+	}
+
+	c.logger.Info("Retrieved availability exceptions", zap.Uint("user_id", userID), zap.Int("count", len(exceptions)))
+	ctx.JSON(http.StatusOK, exceptions)
+}
+
+// DeleteException removes one of a user's availability exceptions.
+func (c *AvailabilityRuleController) DeleteException(ctx *gin.Context) {
+	userID, ok := c.parseUserID(ctx)
+	if !ok {
+		return
+	}
+	exceptionID, err := strconv.ParseUint(ctx.Param("exceptionId"), 10, 32)
+	if err != nil {
+		c.logger.Error("Invalid exception ID format", zap.String("exception_id", ctx.Param("exceptionId")), zap.Error(err))
+		ctx.Error(middleware.NewProblem(http.StatusBadRequest, "Invalid exception ID format"))
+		return
+	}
+
+	c.logger.Info("Deleting availability exception", zap.Uint64("exception_id", exceptionID))
+	if err := c.service.DeleteException(ctx.Request.Context(), userID, uint(exceptionID)); err != nil {
+		c.logger.Error("Failed to delete availability exception", zap.Uint64("exception_id", exceptionID), zap.Error(err))
+		ctx.Error(err)
+		return
+	}
+
+	c.logger.Info("Availability exception deleted successfully", zap.Uint64("exception_id", exceptionID))
+	ctx.JSON(http.StatusOK, gin.H{"message": "Availability exception deleted successfully"})
+}
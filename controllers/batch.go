@@ -0,0 +1,59 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/krushnna/meeting-scheduler/models"
+	"github.com/krushnna/meeting-scheduler/services"
+	"go.uber.org/zap"
+)
+
+// BatchScheduleController handles HTTP requests for scheduling events together.
+type BatchScheduleController struct {
+	service *services.BatchScheduleService
+	logger  *zap.Logger
+}
+
+func NewBatchScheduleController(service *services.BatchScheduleService, logger *zap.Logger) *BatchScheduleController {
+	return &BatchScheduleController{
+		service: service,
+		logger:  logger.With(zap.String("controller", "batch_schedule")),
+	}
+}
+
+// BatchScheduleRequest lists the events to place together and the constraints
+// between them. timezone decides what same_day means; apply finalizes the result.
+type BatchScheduleRequest struct {
+	EventIDs    []uint                   `json:"event_ids" binding:"required"`
+	Constraints []models.BatchConstraint `json:"constraints" binding:"dive"`
+	Timezone    string                   `json:"timezone"`
+	Apply       bool                     `json:"apply"`
+}
+
+// ScheduleBatch returns a joint assignment of start times to the events that keeps
+// shared participants from being double-booked, finalizing it when apply is set.
+func (c *BatchScheduleController) ScheduleBatch(ctx *gin.Context) {
+	var request BatchScheduleRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		c.logger.Error("Failed to bind JSON", zap.Error(err))
+		ctx.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	c.logger.Info("Scheduling batch", zap.Uints("event_ids", request.EventIDs), zap.Int("constraints", len(request.Constraints)), zap.Bool("apply", request.Apply))
+	schedule, err := c.service.ScheduleBatch(ctx.Request.Context(), services.BatchScheduleOptions{
+		EventIDs:    request.EventIDs,
+		Constraints: request.Constraints,
+		Timezone:    request.Timezone,
+		Apply:       request.Apply,
+	})
+	if err != nil {
+		c.logger.Error("Failed to schedule batch", zap.Uints("event_ids", request.EventIDs), zap.Error(err))
+		ctx.Error(err)
+		return
+	}
+
+	c.logger.Info("Batch scheduled successfully", zap.Int("total_attendance", schedule.TotalAttendance), zap.Bool("applied", schedule.Applied))
+	ctx.JSON(http.StatusOK, schedule)
+}
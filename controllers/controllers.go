@@ -3,9 +3,12 @@ package controllers
 import (
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/krushnna/meeting-scheduler/middleware"
 	"github.com/krushnna/meeting-scheduler/models"
+	"github.com/krushnna/meeting-scheduler/repository"
 	"github.com/krushnna/meeting-scheduler/services"
 	"go.uber.org/zap"
 )
@@ -28,28 +31,19 @@ func (c *EventController) CreateEvent(ctx *gin.Context) {
 	var event models.Event
 	if err := ctx.ShouldBindJSON(&event); err != nil {
 		c.logger.Error("Failed to bind JSON", zap.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON payload: " + err.Error()})
-		return
-	}
-
-	// Validate required fields
-	if event.Title == "" {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Event title is required"})
-		return
-	}
-	if event.DurationMinutes <= 0 {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Event duration must be greater than zero"})
+		ctx.Error(err).SetType(gin.ErrorTypeBind)
 		return
 	}
 
 	c.logger.Info("Crreating new event", zap.String("title", event.Title))
-	if err := c.service.CreateEvent(&event); err != nil {
+	if err := c.service.CreateEvent(ctx.Request.Context(), &event); err != nil {
 		c.logger.Error("Failed to create event", zap.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Error creating event: " + err.Error()})
+		ctx.Error(err)
 		return
 	}
 
 	c.logger.Info("Event created successfully", zap.Uint("event_id", event.ID))
+	setETag(ctx, event.Version)
 	ctx.JSON(http.StatusCreated, event)
 }
 
@@ -58,60 +52,66 @@ func (c *EventController) GetEvent(ctx *gin.Context) {
 	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
 	if err != nil {
 		c.logger.Error("Invalid ID format", zap.String("id", ctx.Param("id")), zap.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid event ID format"})
+		ctx.Error(middleware.NewProblem(http.StatusBadRequest, "Invalid event ID format"))
 		return
 	}
 
 	c.logger.Debug("Fetching event", zap.Uint64("id", id))
 	event, err := c.service.GetEvent(uint(id))
 	if err != nil {
-		c.logger.Error("Event not found", zap.Uint64("id", id), zap.Error(err))
-		ctx.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+		c.logger.Error("Failed to fetch event", zap.Uint64("id", id), zap.Error(err))
+		ctx.Error(err)
 		return
 	}
 
+	setETag(ctx, event.Version)
 	ctx.JSON(http.StatusOK, event)
 }
 
-// GetAllEvents returns all events with pagination support.
-// Query parameters: limit (default 10) and offset (default 0)
+// GetAllEvents returns one page of events.
+// Filters: organizer_id, status, and from/to (RFC 3339) selecting events with a
+// time slot in that range. Paging: limit, cursor and sort
+// (id, created_at, title or duration_minutes, prefixed with "-" for descending).
 func (c *EventController) GetAllEvents(ctx *gin.Context) {
-	limitStr := ctx.Query("limit")
-	offsetStr := ctx.Query("offset")
-	var limit, offset int
-	var err error
+	page, ok := parsePageRequest(ctx)
+	if !ok {
+		return
+	}
 
-	if limitStr != "" {
-		limit, err = strconv.Atoi(limitStr)
-		if err != nil || limit <= 0 {
-			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit value"})
+	filter := repository.EventFilter{Status: ctx.Query("status")}
+	if organizerIDStr := ctx.Query("organizer_id"); organizerIDStr != "" {
+		organizerID, err := strconv.ParseUint(organizerIDStr, 10, 32)
+		if err != nil {
+			ctx.Error(middleware.NewProblem(http.StatusBadRequest, "Invalid organizer_id value"))
 			return
 		}
-	} else {
-		limit = 10 // default limit
+		filter.OrganizerID = uint(organizerID)
 	}
 
-	if offsetStr != "" {
-		offset, err = strconv.Atoi(offsetStr)
-		if err != nil || offset < 0 {
-			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid offset value"})
+	var err error
+	if fromStr := ctx.Query("from"); fromStr != "" {
+		if filter.From, err = time.Parse(time.RFC3339, fromStr); err != nil {
+			ctx.Error(middleware.NewProblem(http.StatusBadRequest, "Invalid from value, expected RFC 3339"))
+			return
+		}
+	}
+	if toStr := ctx.Query("to"); toStr != "" {
+		if filter.To, err = time.Parse(time.RFC3339, toStr); err != nil {
+			ctx.Error(middleware.NewProblem(http.StatusBadRequest, "Invalid to value, expected RFC 3339"))
 			return
 		}
-	} else {
-		offset = 0 // default offset
 	}
 
-	c.logger.Debug("Fetching events with pagination", zap.Int("limit", limit), zap.Int("offset", offset))
-	// Call a service method that supports pagination.
-	events, err := c.service.GetAllEventsWithPagination(limit, offset)
+	c.logger.Debug("Fetching events", zap.Any("filter", filter), zap.Any("page", page))
+	events, err := c.service.ListEvents(filter, page)
 	if err != nil {
 		c.logger.Error("Failed to fetch events", zap.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching events: " + err.Error()})
+		ctx.Error(err)
 		return
 	}
 
-	c.logger.Info("Retrieved events", zap.Int("count", len(events)))
-	ctx.JSON(http.StatusOK, events)
+	c.logger.Info("Retrieved events", zap.Int("count", len(events.Items)), zap.Int64("total", events.Total))
+	writePage(ctx, events)
 }
 
 // UpdateEvent modifies an existing event.
@@ -119,35 +119,32 @@ func (c *EventController) UpdateEvent(ctx *gin.Context) {
 	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
 	if err != nil {
 		c.logger.Error("Invalid ID format", zap.String("id", ctx.Param("id")), zap.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid event ID format"})
+		ctx.Error(middleware.NewProblem(http.StatusBadRequest, "Invalid event ID format"))
 		return
 	}
 
 	var event models.Event
 	if err := ctx.ShouldBindJSON(&event); err != nil {
 		c.logger.Error("Failed to bind JSON", zap.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON payload: " + err.Error()})
+		ctx.Error(err).SetType(gin.ErrorTypeBind)
 		return
 	}
 
-	// Validate fields again if needed.
-	if event.Title == "" {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Event title is required"})
-		return
-	}
-	if event.DurationMinutes <= 0 {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Event duration must be greater than zero"})
+	version, ok := parseIfMatch(ctx)
+	if !ok {
 		return
 	}
+	event.Version = version
 
 	c.logger.Info("Updating event", zap.Uint64("id", id))
-	if err := c.service.UpdateEvent(uint(id), &event); err != nil {
+	if err := c.service.UpdateEvent(ctx.Request.Context(), uint(id), &event); err != nil {
 		c.logger.Error("Failed to update event", zap.Uint64("id", id), zap.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Error updating event: " + err.Error()})
+		ctx.Error(err)
 		return
 	}
 
 	c.logger.Info("Event updated successfully", zap.Uint64("id", id))
+	setETag(ctx, event.Version)
 	ctx.JSON(http.StatusOK, gin.H{"message": "Event updated successfully"})
 }
 
@@ -156,14 +153,19 @@ func (c *EventController) DeleteEvent(ctx *gin.Context) {
 	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
 	if err != nil {
 		c.logger.Error("Invalid ID format", zap.String("id", ctx.Param("id")), zap.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid event ID format"})
+		ctx.Error(middleware.NewProblem(http.StatusBadRequest, "Invalid event ID format"))
+		return
+	}
+
+	version, ok := parseIfMatch(ctx)
+	if !ok {
 		return
 	}
 
 	c.logger.Info("Deleting event", zap.Uint64("id", id))
-	if err := c.service.DeleteEvent(uint(id)); err != nil {
+	if err := c.service.DeleteEvent(ctx.Request.Context(), uint(id), version); err != nil {
 		c.logger.Error("Failed to delete event", zap.Uint64("id", id), zap.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Error deleting event: " + err.Error()})
+		ctx.Error(err)
 		return
 	}
 
@@ -189,48 +191,54 @@ func (c *TimeSlotController) CreateTimeSlot(ctx *gin.Context) {
 	eventID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
 	if err != nil {
 		c.logger.Error("Invalid event ID format", zap.String("id", ctx.Param("id")), zap.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid event ID format"})
+		ctx.Error(middleware.NewProblem(http.StatusBadRequest, "Invalid event ID format"))
 		return
 	}
 
 	var timeSlot models.TimeSlot
 	if err := ctx.ShouldBindJSON(&timeSlot); err != nil {
 		c.logger.Error("Failed to bind JSON", zap.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON payload: " + err.Error()})
+		ctx.Error(err).SetType(gin.ErrorTypeBind)
 		return
 	}
 
 	timeSlot.EventID = uint(eventID)
 	c.logger.Info("Creating time slot", zap.Uint64("event_id", eventID))
-	if err := c.service.CreateTimeSlot(&timeSlot); err != nil {
+	if err := c.service.CreateTimeSlot(ctx.Request.Context(), &timeSlot); err != nil {
 		c.logger.Error("Failed to create time slot", zap.Uint64("event_id", eventID), zap.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Error creating time slot: " + err.Error()})
+		ctx.Error(err)
 		return
 	}
 
 	c.logger.Info("Time slot created successfully", zap.Uint("slot_id", timeSlot.ID), zap.Uint64("event_id", eventID))
+	setETag(ctx, timeSlot.Version)
 	ctx.JSON(http.StatusCreated, timeSlot)
 }
 
-// GetTimeSlotsByEvent retrieves all timeslots for a given event.
+// GetTimeSlotsByEvent returns one page of timeslots for a given event.
+// Paging: limit, cursor and sort (id, start_time or end_time).
 func (c *TimeSlotController) GetTimeSlotsByEvent(ctx *gin.Context) {
 	eventID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
 	if err != nil {
 		c.logger.Error("Invalid event ID format", zap.String("id", ctx.Param("id")), zap.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid event ID format"})
+		ctx.Error(middleware.NewProblem(http.StatusBadRequest, "Invalid event ID format"))
+		return
+	}
+	page, ok := parsePageRequest(ctx)
+	if !ok {
 		return
 	}
 
 	c.logger.Debug("Fetching time slots for event", zap.Uint64("event_id", eventID))
-	timeSlots, err := c.service.GetTimeSlotsByEvent(uint(eventID))
+	timeSlots, err := c.service.ListTimeSlots(uint(eventID), page)
 	if err != nil {
 		c.logger.Error("Failed to fetch time slots", zap.Uint64("event_id", eventID), zap.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching time slots: " + err.Error()})
+		ctx.Error(err)
 		return
 	}
 
-	c.logger.Info("Retrieved time slots", zap.Uint64("event_id", eventID), zap.Int("count", len(timeSlots)))
-	ctx.JSON(http.StatusOK, timeSlots)
+	c.logger.Info("Retrieved time slots", zap.Uint64("event_id", eventID), zap.Int("count", len(timeSlots.Items)))
+	writePage(ctx, timeSlots)
 }
 
 // UpdateTimeSlot updates an existing timeslot.
@@ -238,25 +246,32 @@ func (c *TimeSlotController) UpdateTimeSlot(ctx *gin.Context) {
 	slotID, err := strconv.ParseUint(ctx.Param("slotId"), 10, 32)
 	if err != nil {
 		c.logger.Error("Invalid time slot ID format", zap.String("slot_id", ctx.Param("slotId")), zap.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid time slot ID format"})
+		ctx.Error(middleware.NewProblem(http.StatusBadRequest, "Invalid time slot ID format"))
 		return
 	}
 
 	var timeSlot models.TimeSlot
 	if err := ctx.ShouldBindJSON(&timeSlot); err != nil {
 		c.logger.Error("Failed to bind JSON", zap.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON payload: " + err.Error()})
+		ctx.Error(err).SetType(gin.ErrorTypeBind)
 		return
 	}
 
+	version, ok := parseIfMatch(ctx)
+	if !ok {
+		return
+	}
+	timeSlot.Version = version
+
 	c.logger.Info("Updating time slot", zap.Uint64("slot_id", slotID))
-	if err := c.service.UpdateTimeSlot(uint(slotID), &timeSlot); err != nil {
+	if err := c.service.UpdateTimeSlot(ctx.Request.Context(), uint(slotID), &timeSlot); err != nil {
 		c.logger.Error("Failed to update time slot", zap.Uint64("slot_id", slotID), zap.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Error updating time slot: " + err.Error()})
+		ctx.Error(err)
 		return
 	}
 
 	c.logger.Info("Time slot updated successfully", zap.Uint64("slot_id", slotID))
+	setETag(ctx, timeSlot.Version)
 	ctx.JSON(http.StatusOK, gin.H{"message": "Time slot updated successfully"})
 }
 
@@ -265,14 +280,19 @@ func (c *TimeSlotController) DeleteTimeSlot(ctx *gin.Context) {
 	slotID, err := strconv.ParseUint(ctx.Param("slotId"), 10, 32)
 	if err != nil {
 		c.logger.Error("Invalid time slot ID format", zap.String("slot_id", ctx.Param("slotId")), zap.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid time slot ID format"})
+		ctx.Error(middleware.NewProblem(http.StatusBadRequest, "Invalid time slot ID format"))
+		return
+	}
+
+	version, ok := parseIfMatch(ctx)
+	if !ok {
 		return
 	}
 
 	c.logger.Info("Deleting time slot", zap.Uint64("slot_id", slotID))
-	if err := c.service.DeleteTimeSlot(uint(slotID)); err != nil {
+	if err := c.service.DeleteTimeSlot(ctx.Request.Context(), uint(slotID), version); err != nil {
 		c.logger.Error("Failed to delete time slot", zap.Uint64("slot_id", slotID), zap.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Error deleting time slot: " + err.Error()})
+		ctx.Error(err)
 		return
 	}
 
@@ -298,18 +318,19 @@ func (c *UserController) CreateUser(ctx *gin.Context) {
 	var user models.User
 	if err := ctx.ShouldBindJSON(&user); err != nil {
 		c.logger.Error("Failed to bind JSON", zap.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON payload: " + err.Error()})
+		ctx.Error(err).SetType(gin.ErrorTypeBind)
 		return
 	}
 
 	c.logger.Info("Creating user", zap.String("email", user.Email))
-	if err := c.service.CreateUser(&user); err != nil {
+	if err := c.service.CreateUser(ctx.Request.Context(), &user); err != nil {
 		c.logger.Error("Failed to create user", zap.String("email", user.Email), zap.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Error creating user: " + err.Error()})
+		ctx.Error(err)
 		return
 	}
 
 	c.logger.Info("User created successfully", zap.Uint("user_id", user.ID), zap.String("email", user.Email))
+	setETag(ctx, user.Version)
 	ctx.JSON(http.StatusCreated, user)
 }
 
@@ -318,33 +339,45 @@ func (c *UserController) GetUser(ctx *gin.Context) {
 	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
 	if err != nil {
 		c.logger.Error("Invalid ID format", zap.String("id", ctx.Param("id")), zap.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID format"})
+		ctx.Error(middleware.NewProblem(http.StatusBadRequest, "Invalid user ID format"))
 		return
 	}
 
 	c.logger.Debug("Fetching user", zap.Uint64("id", id))
 	user, err := c.service.GetUser(uint(id))
 	if err != nil {
-		c.logger.Error("User not found", zap.Uint64("id", id), zap.Error(err))
-		ctx.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		c.logger.Error("Failed to fetch user", zap.Uint64("id", id), zap.Error(err))
+		ctx.Error(err)
 		return
 	}
 
+	setETag(ctx, user.Version)
 	ctx.JSON(http.StatusOK, user)
 }
 
-// GetAllUsers retrieves all users.
+// GetAllUsers returns one page of users.
+// Filters: email (exact match) and search (part of the name or email).
+// Paging: limit, cursor and sort (id, created_at, name or email).
 func (c *UserController) GetAllUsers(ctx *gin.Context) {
-	c.logger.Debug("Fetching all users")
-	users, err := c.service.GetAllUsers()
+	page, ok := parsePageRequest(ctx)
+	if !ok {
+		return
+	}
+	filter := repository.UserFilter{
+		Email:  ctx.Query("email"),
+		Search: ctx.Query("search"),
+	}
+
+	c.logger.Debug("Fetching users", zap.Any("filter", filter), zap.Any("page", page))
+	users, err := c.service.ListUsers(filter, page)
 	if err != nil {
 		c.logger.Error("Failed to fetch users", zap.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching users: " + err.Error()})
+		ctx.Error(err)
 		return
 	}
 
-	c.logger.Info("Retrieved all users", zap.Int("count", len(users)))
-	ctx.JSON(http.StatusOK, users)
+	c.logger.Info("Retrieved users", zap.Int("count", len(users.Items)), zap.Int64("total", users.Total))
+	writePage(ctx, users)
 }
 
 // UpdateUser updates an existing user.
@@ -352,25 +385,32 @@ func (c *UserController) UpdateUser(ctx *gin.Context) {
 	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
 	if err != nil {
 		c.logger.Error("Invalid ID format", zap.String("id", ctx.Param("id")), zap.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID format"})
+		ctx.Error(middleware.NewProblem(http.StatusBadRequest, "Invalid user ID format"))
 		return
 	}
 
 	var user models.User
 	if err := ctx.ShouldBindJSON(&user); err != nil {
 		c.logger.Error("Failed to bind JSON", zap.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON payload: " + err.Error()})
+		ctx.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	version, ok := parseIfMatch(ctx)
+	if !ok {
 		return
 	}
+	user.Version = version
 
 	c.logger.Info("Updating user", zap.Uint64("id", id))
-	if err := c.service.UpdateUser(uint(id), &user); err != nil {
+	if err := c.service.UpdateUser(ctx.Request.Context(), uint(id), &user); err != nil {
 		c.logger.Error("Failed to update user", zap.Uint64("id", id), zap.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Error updating user: " + err.Error()})
+		ctx.Error(err)
 		return
 	}
 
 	c.logger.Info("User updated successfully", zap.Uint64("id", id))
+	setETag(ctx, user.Version)
 	ctx.JSON(http.StatusOK, gin.H{"message": "User updated successfully"})
 }
 
@@ -379,14 +419,19 @@ func (c *UserController) DeleteUser(ctx *gin.Context) {
 	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
 	if err != nil {
 		c.logger.Error("Invalid ID format", zap.String("id", ctx.Param("id")), zap.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID format"})
+		ctx.Error(middleware.NewProblem(http.StatusBadRequest, "Invalid user ID format"))
+		return
+	}
+
+	version, ok := parseIfMatch(ctx)
+	if !ok {
 		return
 	}
 
 	c.logger.Info("Deleting user", zap.Uint64("id", id))
-	if err := c.service.DeleteUser(uint(id)); err != nil {
+	if err := c.service.DeleteUser(ctx.Request.Context(), uint(id), version); err != nil {
 		c.logger.Error("Failed to delete user", zap.Uint64("id", id), zap.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Error deleting user: " + err.Error()})
+		ctx.Error(err)
 		return
 	}
 
@@ -412,21 +457,21 @@ func (c *AvailabilityController) CreateAvailability(ctx *gin.Context) {
 	userID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
 	if err != nil {
 		c.logger.Error("Invalid user ID format", zap.String("user_id", ctx.Param("id")), zap.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID format"})
+		ctx.Error(middleware.NewProblem(http.StatusBadRequest, "Invalid user ID format"))
 		return
 	}
 
 	eventID, err := strconv.ParseUint(ctx.Param("eventId"), 10, 32)
 	if err != nil {
 		c.logger.Error("Invalid event ID format", zap.String("event_id", ctx.Param("eventId")), zap.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid event ID format"})
+		ctx.Error(middleware.NewProblem(http.StatusBadRequest, "Invalid event ID format"))
 		return
 	}
 
 	var availability models.UserAvailability
 	if err := ctx.ShouldBindJSON(&availability); err != nil {
 		c.logger.Error("Failed to bind JSON", zap.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON payload: " + err.Error()})
+		ctx.Error(err).SetType(gin.ErrorTypeBind)
 		return
 	}
 
@@ -434,84 +479,132 @@ func (c *AvailabilityController) CreateAvailability(ctx *gin.Context) {
 	availability.EventID = uint(eventID)
 
 	c.logger.Info("Creating availability", zap.Uint64("user_id", userID), zap.Uint64("event_id", eventID))
-	if err := c.service.CreateAvailability(&availability); err != nil {
+	if err := c.service.CreateAvailability(ctx.Request.Context(), &availability); err != nil {
 		c.logger.Error("Failed to create availability", zap.Uint64("user_id", userID), zap.Uint64("event_id", eventID), zap.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Error creating availability: " + err.Error()})
+		ctx.Error(err)
 		return
 	}
 
 	c.logger.Info("Availability created successfully", zap.Uint("avail_id", availability.ID))
+	setETag(ctx, availability.Version)
 	ctx.JSON(http.StatusCreated, availability)
 }
 
-// GetUserAvailability retrieves availability records for a user in an event.
+// GetUserAvailability returns one page of availability records for a user in an event.
+// Paging: limit, cursor and sort (id, start_time or end_time).
 func (c *AvailabilityController) GetUserAvailability(ctx *gin.Context) {
 	userID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
 	if err != nil {
 		c.logger.Error("Invalid user ID format", zap.String("user_id", ctx.Param("id")), zap.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID format"})
+		ctx.Error(middleware.NewProblem(http.StatusBadRequest, "Invalid user ID format"))
 		return
 	}
 
 	eventID, err := strconv.ParseUint(ctx.Param("eventId"), 10, 32)
 	if err != nil {
 		c.logger.Error("Invalid event ID format", zap.String("event_id", ctx.Param("eventId")), zap.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid event ID format"})
+		ctx.Error(middleware.NewProblem(http.StatusBadRequest, "Invalid event ID format"))
+		return
+	}
+	page, ok := parsePageRequest(ctx)
+	if !ok {
 		return
 	}
 
 	c.logger.Debug("Fetching user availability", zap.Uint64("user_id", userID), zap.Uint64("event_id", eventID))
-	availabilities, err := c.service.GetUserAvailability(uint(userID), uint(eventID))
+	availabilities, err := c.service.ListUserAvailability(uint(userID), uint(eventID), page)
 	if err != nil {
 		c.logger.Error("Failed to fetch availability", zap.Uint64("user_id", userID), zap.Uint64("event_id", eventID), zap.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching availability: " + err.Error()})
+		ctx.Error(err)
 		return
 	}
 
-	c.logger.Info("Retrieved user availability", zap.Uint64("user_id", userID), zap.Uint64("event_id", eventID), zap.Int("count", len(availabilities)))
-	ctx.JSON(http.StatusOK, availabilities)
+	c.logger.Info("Retrieved user availability", zap.Uint64("user_id", userID), zap.Uint64("event_id", eventID), zap.Int("count", len(availabilities.Items)))
+	writePage(ctx, availabilities)
 }
 
-// UpdateAvailability updates an existing availability record.
+// UpdateAvailability updates one of a user's availability records for an event.
 func (c *AvailabilityController) UpdateAvailability(ctx *gin.Context) {
+	userID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		c.logger.Error("Invalid user ID format", zap.String("user_id", ctx.Param("id")), zap.Error(err))
+		ctx.Error(middleware.NewProblem(http.StatusBadRequest, "Invalid user ID format"))
+		return
+	}
+
+	eventID, err := strconv.ParseUint(ctx.Param("eventId"), 10, 32)
+	if err != nil {
+		c.logger.Error("Invalid event ID format", zap.String("event_id", ctx.Param("eventId")), zap.Error(err))
+		ctx.Error(middleware.NewProblem(http.StatusBadRequest, "Invalid event ID format"))
+		return
+	}
+
 	availID, err := strconv.ParseUint(ctx.Param("availId"), 10, 32)
 	if err != nil {
 		c.logger.Error("Invalid availability ID format", zap.String("avail_id", ctx.Param("availId")), zap.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid availability ID format"})
+		ctx.Error(middleware.NewProblem(http.StatusBadRequest, "Invalid availability ID format"))
 		return
 	}
 
 	var availability models.UserAvailability
 	if err := ctx.ShouldBindJSON(&availability); err != nil {
 		c.logger.Error("Failed to bind JSON", zap.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON payload: " + err.Error()})
+		ctx.Error(err).SetType(gin.ErrorTypeBind)
 		return
 	}
 
+	version, ok := parseIfMatch(ctx)
+	if !ok {
+		return
+	}
+	availability.UserID = uint(userID)
+	availability.EventID = uint(eventID)
+	availability.Version = version
+
 	c.logger.Info("Updating availability", zap.Uint64("avail_id", availID))
-	if err := c.service.UpdateAvailability(uint(availID), &availability); err != nil {
+	if err := c.service.UpdateAvailability(ctx.Request.Context(), uint(availID), &availability); err != nil {
 		c.logger.Error("Failed to update availability", zap.Uint64("avail_id", availID), zap.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Error updating availability: " + err.Error()})
+		ctx.Error(err)
 		return
 	}
 
 	c.logger.Info("Availability updated successfully", zap.Uint64("avail_id", availID))
+	setETag(ctx, availability.Version)
 	ctx.JSON(http.StatusOK, gin.H{"message": "Availability updated successfully"})
 }
 
 // DeleteAvailability deletes an availability record.
 func (c *AvailabilityController) DeleteAvailability(ctx *gin.Context) {
+	userID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		c.logger.Error("Invalid user ID format", zap.String("user_id", ctx.Param("id")), zap.Error(err))
+		ctx.Error(middleware.NewProblem(http.StatusBadRequest, "Invalid user ID format"))
+		return
+	}
+
+	eventID, err := strconv.ParseUint(ctx.Param("eventId"), 10, 32)
+	if err != nil {
+		c.logger.Error("Invalid event ID format", zap.String("event_id", ctx.Param("eventId")), zap.Error(err))
+		ctx.Error(middleware.NewProblem(http.StatusBadRequest, "Invalid event ID format"))
+		return
+	}
+
 	availID, err := strconv.ParseUint(ctx.Param("availId"), 10, 32)
 	if err != nil {
 		c.logger.Error("Invalid availability ID format", zap.String("avail_id", ctx.Param("availId")), zap.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid availability ID format"})
+		ctx.Error(middleware.NewProblem(http.StatusBadRequest, "Invalid availability ID format"))
+		return
+	}
+
+	version, ok := parseIfMatch(ctx)
+	if !ok {
 		return
 	}
 
 	c.logger.Info("Deleting availability", zap.Uint64("avail_id", availID))
-	if err := c.service.DeleteAvailability(uint(availID)); err != nil {
+	if err := c.service.DeleteAvailability(ctx.Request.Context(), uint(userID), uint(eventID), uint(availID), version); err != nil {
 		c.logger.Error("Failed to delete availability", zap.Uint64("avail_id", availID), zap.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Error deleting availability: " + err.Error()})
+		ctx.Error(err)
 		return
 	}
 
@@ -519,6 +612,101 @@ func (c *AvailabilityController) DeleteAvailability(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, gin.H{"message": "Availability deleted successfully"})
 }
 
+// ReplaceAvailability replaces all of a user's availability for an event with the
+// windows in the request body, which may be empty to clear it. Overlapping and
+// adjacent windows are merged.
+func (c *AvailabilityController) ReplaceAvailability(ctx *gin.Context) {
+	userID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		c.logger.Error("Invalid user ID format", zap.String("user_id", ctx.Param("id")), zap.Error(err))
+		ctx.Error(middleware.NewProblem(http.StatusBadRequest, "Invalid user ID format"))
+		return
+	}
+
+	eventID, err := strconv.ParseUint(ctx.Param("eventId"), 10, 32)
+	if err != nil {
+		c.logger.Error("Invalid event ID format", zap.String("event_id", ctx.Param("eventId")), zap.Error(err))
+		ctx.Error(middleware.NewProblem(http.StatusBadRequest, "Invalid event ID format"))
+		return
+	}
+
+	var windows []models.UserAvailability
+	if err := ctx.ShouldBindJSON(&windows); err != nil {
+		c.logger.Error("Failed to bind JSON", zap.Error(err))
+		ctx.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	c.logger.Info("Replacing availability", zap.Uint64("user_id", userID), zap.Uint64("event_id", eventID), zap.Int("count", len(windows)))
+	availabilities, err := c.service.ReplaceAvailability(ctx.Request.Context(), uint(userID), uint(eventID), windows)
+	if err != nil {
+		c.logger.Error("Failed to replace availability", zap.Uint64("user_id", userID), zap.Uint64("event_id", eventID), zap.Error(err))
+		ctx.Error(err)
+		return
+	}
+
+	c.logger.Info("Availability replaced successfully", zap.Uint64("user_id", userID), zap.Uint64("event_id", eventID))
+	ctx.JSON(http.StatusOK, availabilities)
+}
+
+// SubmitAvailability records a batch of availability windows for a user in an event.
+// Overlapping and adjacent windows are merged with the stored ones and the
+// normalized availability is returned.
+func (c *AvailabilityController) SubmitAvailability(ctx *gin.Context) {
+	userID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		c.logger.Error("Invalid user ID format", zap.String("user_id", ctx.Param("id")), zap.Error(err))
+		ctx.Error(middleware.NewProblem(http.StatusBadRequest, "Invalid user ID format"))
+		return
+	}
+
+	eventID, err := strconv.ParseUint(ctx.Param("eventId"), 10, 32)
+	if err != nil {
+		c.logger.Error("Invalid event ID format", zap.String("event_id", ctx.Param("eventId")), zap.Error(err))
+		ctx.Error(middleware.NewProblem(http.StatusBadRequest, "Invalid event ID format"))
+		return
+	}
+
+	var windows []models.UserAvailability
+	if err := ctx.ShouldBindJSON(&windows); err != nil {
+		c.logger.Error("Failed to bind JSON", zap.Error(err))
+		ctx.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	c.logger.Info("Submitting availability", zap.Uint64("user_id", userID), zap.Uint64("event_id", eventID), zap.Int("count", len(windows)))
+	availabilities, err := c.service.SubmitAvailability(ctx.Request.Context(), uint(userID), uint(eventID), windows)
+	if err != nil {
+		c.logger.Error("Failed to submit availability", zap.Uint64("user_id", userID), zap.Uint64("event_id", eventID), zap.Error(err))
+		ctx.Error(err)
+		return
+	}
+
+	c.logger.Info("Availability submitted successfully", zap.Uint64("user_id", userID), zap.Uint64("event_id", eventID), zap.Int("records", len(availabilities)))
+	ctx.JSON(http.StatusOK, availabilities)
+}
+
+// GetEventAvailability returns every participant's availability for an event, grouped by user.
+func (c *AvailabilityController) GetEventAvailability(ctx *gin.Context) {
+	eventID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		c.logger.Error("Invalid event ID format", zap.String("id", ctx.Param("id")), zap.Error(err))
+		ctx.Error(middleware.NewProblem(http.StatusBadRequest, "Invalid event ID format"))
+		return
+	}
+
+	c.logger.Debug("Fetching availability for event", zap.Uint64("event_id", eventID))
+	groups, err := c.service.GetEventAvailability(uint(eventID))
+	if err != nil {
+		c.logger.Error("Failed to fetch event availability", zap.Uint64("event_id", eventID), zap.Error(err))
+		ctx.Error(err)
+		return
+	}
+
+	c.logger.Info("Retrieved event availability", zap.Uint64("event_id", eventID), zap.Int("users", len(groups)))
+	ctx.JSON(http.StatusOK, groups)
+}
+
 // RecommendationController handles HTTP requests for time slot recommendations.
 type RecommendationController struct {
 	service *services.RecommendationService
@@ -532,21 +720,34 @@ func NewRecommendationController(service *services.RecommendationService, logger
 	}
 }
 
-// GetRecommendations generates and returns time slot recommendations.
+// GetRecommendations generates and returns time slot recommendations. With
+// explain=true every candidate start is explained: who can't attend and why.
 // It relies on proper JSON struct tags (with omitempty) in the models to omit null values.
 func (c *RecommendationController) GetRecommendations(ctx *gin.Context) {
 	eventID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
 	if err != nil {
 		c.logger.Error("Invalid event ID format", zap.String("event_id", ctx.Param("id")), zap.Error(err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid event ID format"})
+		ctx.Error(middleware.NewProblem(http.StatusBadRequest, "Invalid event ID format"))
 		return
 	}
+	explain := false
+	if explainStr := ctx.Query("explain"); explainStr != "" {
+		if explain, err = strconv.ParseBool(explainStr); err != nil {
+			ctx.Error(middleware.NewProblem(http.StatusBadRequest, "Invalid explain value, expected true or false"))
+			return
+		}
+	}
 
-	c.logger.Info("Generating recommendations", zap.Uint64("event_id", eventID))
-	recommendations, err := c.service.GetRecommendations(uint(eventID))
+	c.logger.Info("Generating recommendations", zap.Uint64("event_id", eventID), zap.Bool("explain", explain))
+	var recommendations []models.TimeSlotRecommendation
+	if explain {
+		recommendations, err = c.service.ExplainRecommendations(uint(eventID))
+	} else {
+		recommendations, err = c.service.GetRecommendations(uint(eventID))
+	}
 	if err != nil {
 		c.logger.Error("Failed to generate recommendations", zap.Uint64("event_id", eventID), zap.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Error generating recommendations: " + err.Error()})
+		ctx.Error(err)
 		return
 	}
 
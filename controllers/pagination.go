@@ -0,0 +1,64 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/krushnna/meeting-scheduler/middleware"
+	"github.com/krushnna/meeting-scheduler/repository"
+)
+
+// PageLinks holds navigation links for a list response.
+type PageLinks struct {
+	Next string `json:"next,omitempty"`
+}
+
+// PageResponse is the envelope returned by cursor-paginated list endpoints.
+type PageResponse[T any] struct {
+	Data       []T       `json:"data"`
+	Total      int64     `json:"total"`
+	NextCursor string    `json:"next_cursor,omitempty"`
+	Links      PageLinks `json:"links"`
+}
+
+// parsePageRequest reads the limit, cursor and sort query parameters.
+// Limit defaults to 10 and may not exceed 100.
+func parsePageRequest(ctx *gin.Context) (repository.PageRequest, bool) {
+	page := repository.PageRequest{
+		Limit:  repository.DefaultPageLimit,
+		Cursor: ctx.Query("cursor"),
+		Sort:   ctx.Query("sort"),
+	}
+
+	if limitStr := ctx.Query("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 || limit > repository.MaxPageLimit {
+			ctx.Error(middleware.NewProblem(http.StatusBadRequest, "Invalid limit value, expected 1 to 100"))
+			return page, false
+		}
+		page.Limit = limit
+	}
+	return page, true
+}
+
+// writePage renders a page in the list envelope. The next link repeats the
+// current query with the cursor of the following page.
+func writePage[T any](ctx *gin.Context, page *repository.Page[T]) {
+	response := PageResponse[T]{
+		Data:       page.Items,
+		Total:      page.Total,
+		NextCursor: page.NextCursor,
+	}
+	if response.Data == nil {
+		response.Data = []T{}
+	}
+	if page.NextCursor != "" {
+		next := *ctx.Request.URL
+		query := next.Query()
+		query.Set("cursor", page.NextCursor)
+		next.RawQuery = query.Encode()
+		response.Links.Next = next.RequestURI()
+	}
+	ctx.JSON(http.StatusOK, response)
+}
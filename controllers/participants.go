@@ -0,0 +1,180 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/krushnna/meeting-scheduler/middleware"
+	"github.com/krushnna/meeting-scheduler/models"
+	"github.com/krushnna/meeting-scheduler/services"
+	"go.uber.org/zap"
+)
+
+// ParticipantController handles HTTP requests for an event's invitees.
+type ParticipantController struct {
+	service *services.ParticipantService
+	logger  *zap.Logger
+}
+
+func NewParticipantController(service *services.ParticipantService, logger *zap.Logger) *ParticipantController {
+	return &ParticipantController{
+		service: service,
+		logger:  logger.With(zap.String("controller", "participant")),
+	}
+}
+
+// InviteParticipantRequest invites a user, optionally as a required participant.
+type InviteParticipantRequest struct {
+	UserID   uint `json:"user_id" binding:"required"`
+	Required bool `json:"required"`
+}
+
+// ParticipantUpdateRequest declines an invitation or takes a decline back, and
+// marks the participant required or optional.
+type ParticipantUpdateRequest struct {
+	Status   string `json:"status"`
+	Required *bool  `json:"required"`
+}
+
+// RSVPRequest answers a scheduled event's confirmed time.
+type RSVPRequest struct {
+	Response string `json:"response" binding:"required"`
+}
+
+// parseIDs reads the event ID and, when present, the user ID path parameters,
+// reporting a 400 when either is malformed.
+func (c *ParticipantController) parseIDs(ctx *gin.Context) (uint, uint, bool) {
+	eventID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		c.logger.Error("Invalid event ID format", zap.String("id", ctx.Param("id")), zap.Error(err))
+		ctx.Error(middleware.NewProblem(http.StatusBadRequest, "Invalid event ID format"))
+		return 0, 0, false
+	}
+	if ctx.Param("userId") == "" {
+		return uint(eventID), 0, true
+	}
+	userID, err := strconv.ParseUint(ctx.Param("userId"), 10, 32)
+	if err != nil {
+		c.logger.Error("Invalid user ID format", zap.String("user_id", ctx.Param("userId")), zap.Error(err))
+		ctx.Error(middleware.NewProblem(http.StatusBadRequest, "Invalid user ID format"))
+		return 0, 0, false
+	}
+	return uint(eventID), uint(userID), true
+}
+
+// InviteParticipant adds a user to an event's invitee list.
+func (c *ParticipantController) InviteParticipant(ctx *gin.Context) {
+	eventID, _, ok := c.parseIDs(ctx)
+	if !ok {
+		return
+	}
+
+	var request InviteParticipantRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		c.logger.Error("Failed to bind JSON", zap.Error(err))
+		ctx.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+	participant := models.EventParticipant{EventID: eventID, UserID: request.UserID, Required: request.Required}
+
+	c.logger.Info("Inviting participant", zap.Uint("event_id", eventID), zap.Uint("user_id", participant.UserID))
+	if err := c.service.InviteParticipant(ctx.Request.Context(), &participant); err != nil {
+		c.logger.Error("Failed to invite participant", zap.Uint("event_id", eventID), zap.Error(err))
+		ctx.Error(err)
+		return
+	}
+
+	c.logger.Info("Participant invited successfully", zap.Uint("participant_id", participant.ID))
+	ctx.JSON(http.StatusCreated, participant)
+}
+
+// GetParticipants lists an event's invitees and their response status.
+func (c *ParticipantController) GetParticipants(ctx *gin.Context) {
+	eventID, _, ok := c.parseIDs(ctx)
+	if !ok {
+		return
+	}
+
+	participants, err := c.service.GetParticipants(eventID)
+	if err != nil {
+		c.logger.Error("Failed to fetch participants", zap.Uint("event_id", eventID), zap.Error(err))
+		ctx.Error(err)
+		return
+	}
+
+	c.logger.Info("Retrieved participants", zap.Uint("event_id", eventID), zap.Int("count", len(participants)))
+	ctx.JSON(http.StatusOK, participants)
+}
+
+// UpdateParticipant sets an invitee's status to declined or back to invited
+// and whether they are required.
+func (c *ParticipantController) UpdateParticipant(ctx *gin.Context) {
+	eventID, userID, ok := c.parseIDs(ctx)
+	if !ok {
+		return
+	}
+
+	var request ParticipantUpdateRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		c.logger.Error("Failed to bind JSON", zap.Error(err))
+		ctx.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	c.logger.Info("Updating participant", zap.Uint("event_id", eventID), zap.Uint("user_id", userID), zap.String("status", request.Status))
+	participant, err := c.service.UpdateParticipant(ctx.Request.Context(), eventID, userID, request.Status, request.Required)
+	if err != nil {
+		c.logger.Error("Failed to update participant", zap.Uint("event_id", eventID), zap.Uint("user_id", userID), zap.Error(err))
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, participant)
+}
+
+// RespondToEvent records an invitee's RSVP (accepted, tentative or declined) to a
+// scheduled event. A required invitee declining reopens polling when the event
+// has auto_reschedule set.
+func (c *ParticipantController) RespondToEvent(ctx *gin.Context) {
+	eventID, userID, ok := c.parseIDs(ctx)
+	if !ok {
+		return
+	}
+
+	var request RSVPRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		c.logger.Error("Failed to bind JSON", zap.Error(err))
+		ctx.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	c.logger.Info("Recording RSVP", zap.Uint("event_id", eventID), zap.Uint("user_id", userID), zap.String("response", request.Response))
+	participant, err := c.service.RespondToEvent(ctx.Request.Context(), eventID, userID, request.Response)
+	if err != nil {
+		c.logger.Error("Failed to record RSVP", zap.Uint("event_id", eventID), zap.Uint("user_id", userID), zap.Error(err))
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, participant)
+}
+
+// RemoveParticipant takes a user off an event's invitee list, deleting their
+// availability and votes for the event.
+func (c *ParticipantController) RemoveParticipant(ctx *gin.Context) {
+	eventID, userID, ok := c.parseIDs(ctx)
+	if !ok {
+		return
+	}
+
+	c.logger.Info("Removing participant", zap.Uint("event_id", eventID), zap.Uint("user_id", userID))
+	if err := c.service.RemoveParticipant(ctx.Request.Context(), eventID, userID); err != nil {
+		c.logger.Error("Failed to remove participant", zap.Uint("event_id", eventID), zap.Uint("user_id", userID), zap.Error(err))
+		ctx.Error(err)
+		return
+	}
+
+	c.logger.Info("Participant removed successfully", zap.Uint("event_id", eventID), zap.Uint("user_id", userID))
+	ctx.JSON(http.StatusOK, gin.H{"message": "Participant removed successfully"})
+}
@@ -0,0 +1,123 @@
+package controllers
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/krushnna/meeting-scheduler/middleware"
+	"go.uber.org/zap"
+)
+
+// MergePatchContentType is the media type of RFC 7396 JSON merge patches.
+const MergePatchContentType = "application/merge-patch+json"
+
+// readMergePatch returns the request body if it is sent as a merge patch or plain JSON.
+func readMergePatch(ctx *gin.Context) ([]byte, bool) {
+	mediaType, _, _ := mime.ParseMediaType(ctx.GetHeader("Content-Type"))
+	if mediaType != MergePatchContentType && mediaType != "application/json" {
+		ctx.Error(middleware.NewProblem(http.StatusUnsupportedMediaType, "Content-Type must be "+MergePatchContentType))
+		return nil, false
+	}
+
+	patch, err := ctx.GetRawData()
+	if err != nil || len(patch) == 0 {
+		ctx.Error(middleware.NewProblem(http.StatusBadRequest, "Merge patch body is required"))
+		return nil, false
+	}
+	return patch, true
+}
+
+// PatchEvent applies a JSON merge patch to an event and returns the updated event.
+func (c *EventController) PatchEvent(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		c.logger.Error("Invalid ID format", zap.String("id", ctx.Param("id")), zap.Error(err))
+		ctx.Error(middleware.NewProblem(http.StatusBadRequest, "Invalid event ID format"))
+		return
+	}
+
+	version, ok := parseIfMatch(ctx)
+	if !ok {
+		return
+	}
+	patch, ok := readMergePatch(ctx)
+	if !ok {
+		return
+	}
+
+	c.logger.Info("Patching event", zap.Uint64("id", id))
+	event, err := c.service.PatchEvent(ctx.Request.Context(), uint(id), version, patch)
+	if err != nil {
+		c.logger.Error("Failed to patch event", zap.Uint64("id", id), zap.Error(err))
+		ctx.Error(err)
+		return
+	}
+
+	c.logger.Info("Event patched successfully", zap.Uint64("id", id))
+	setETag(ctx, event.Version)
+	ctx.JSON(http.StatusOK, event)
+}
+
+// PatchTimeSlot applies a JSON merge patch to a timeslot and returns the updated timeslot.
+func (c *TimeSlotController) PatchTimeSlot(ctx *gin.Context) {
+	slotID, err := strconv.ParseUint(ctx.Param("slotId"), 10, 32)
+	if err != nil {
+		c.logger.Error("Invalid time slot ID format", zap.String("slot_id", ctx.Param("slotId")), zap.Error(err))
+		ctx.Error(middleware.NewProblem(http.StatusBadRequest, "Invalid time slot ID format"))
+		return
+	}
+
+	version, ok := parseIfMatch(ctx)
+	if !ok {
+		return
+	}
+	patch, ok := readMergePatch(ctx)
+	if !ok {
+		return
+	}
+
+	c.logger.Info("Patching time slot", zap.Uint64("slot_id", slotID))
+	timeSlot, err := c.service.PatchTimeSlot(ctx.Request.Context(), uint(slotID), version, patch)
+	if err != nil {
+		c.logger.Error("Failed to patch time slot", zap.Uint64("slot_id", slotID), zap.Error(err))
+		ctx.Error(err)
+		return
+	}
+
+	c.logger.Info("Time slot patched successfully", zap.Uint64("slot_id", slotID))
+	setETag(ctx, timeSlot.Version)
+	ctx.JSON(http.StatusOK, timeSlot)
+}
+
+// PatchUser applies a JSON merge patch to a user and returns the updated user.
+func (c *UserController) PatchUser(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		c.logger.Error("Invalid ID format", zap.String("id", ctx.Param("id")), zap.Error(err))
+		ctx.Error(middleware.NewProblem(http.StatusBadRequest, "Invalid user ID format"))
+		return
+	}
+
+	version, ok := parseIfMatch(ctx)
+	if !ok {
+		return
+	}
+	patch, ok := readMergePatch(ctx)
+	if !ok {
+		return
+	}
+
+	c.logger.Info("Patching user", zap.Uint64("id", id))
+	user, err := c.service.PatchUser(ctx.Request.Context(), uint(id), version, patch)
+	if err != nil {
+		c.logger.Error("Failed to patch user", zap.Uint64("id", id), zap.Error(err))
+		ctx.Error(err)
+		return
+	}
+
+	c.logger.Info("User patched successfully", zap.Uint64("id", id))
+	setETag(ctx, user.Version)
+	ctx.JSON(http.StatusOK, user)
+}
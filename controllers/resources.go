@@ -0,0 +1,188 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/krushnna/meeting-scheduler/middleware"
+	"github.com/krushnna/meeting-scheduler/models"
+	"github.com/krushnna/meeting-scheduler/services"
+	"go.uber.org/zap"
+)
+
+// ResourceController handles HTTP requests for rooms, equipment and their bookings.
+type ResourceController struct {
+	service *services.ResourceService
+	logger  *zap.Logger
+}
+
+func NewResourceController(service *services.ResourceService, logger *zap.Logger) *ResourceController {
+	return &ResourceController{
+		service: service,
+		logger:  logger.With(zap.String("controller", "resource")),
+	}
+}
+
+// parseResourceID reads the resource ID path parameter, reporting a 400 when it is malformed.
+func (c *ResourceController) parseResourceID(ctx *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		c.logger.Error("Invalid resource ID format", zap.String("id", ctx.Param("id")), zap.Error(err))
+		ctx.Error(middleware.NewProblem(http.StatusBadRequest, "Invalid resource ID format"))
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// CreateResource creates a room or piece of equipment.
+func (c *ResourceController) CreateResource(ctx *gin.Context) {
+	var resource models.Resource
+	if err := ctx.ShouldBindJSON(&resource); err != nil {
+		c.logger.Error("Failed to bind JSON", zap.Error(err))
+		ctx.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	c.logger.Info("Creating resource", zap.String("name", resource.Name), zap.String("kind", resource.Kind))
+	if err := c.service.CreateResource(ctx.Request.Context(), &resource); err != nil {
+		c.logger.Error("Failed to create resource", zap.Error(err))
+		ctx.Error(err)
+		return
+	}
+
+	c.logger.Info("Resource created successfully", zap.Uint("resource_id", resource.ID))
+	ctx.JSON(http.StatusCreated, resource)
+}
+
+// GetResource retrieves a resource by its ID.
+func (c *ResourceController) GetResource(ctx *gin.Context) {
+	id, ok := c.parseResourceID(ctx)
+	if !ok {
+		return
+	}
+
+	resource, err := c.service.GetResource(id)
+	if err != nil {
+		c.logger.Error("Failed to fetch resource", zap.Uint("id", id), zap.Error(err))
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resource)
+}
+
+// GetAllResources returns one page of resources.
+// Paging: limit, cursor and sort (id, created_at, name or capacity, prefixed with "-" for descending).
+func (c *ResourceController) GetAllResources(ctx *gin.Context) {
+	page, ok := parsePageRequest(ctx)
+	if !ok {
+		return
+	}
+
+	resources, err := c.service.ListResources(page)
+	if err != nil {
+		c.logger.Error("Failed to fetch resources", zap.Error(err))
+		ctx.Error(err)
+		return
+	}
+
+	c.logger.Info("Retrieved resources", zap.Int("count", len(resources.Items)), zap.Int64("total", resources.Total))
+	writePage(ctx, resources)
+}
+
+// DeleteResource deletes a resource and its bookings.
+func (c *ResourceController) DeleteResource(ctx *gin.Context) {
+	id, ok := c.parseResourceID(ctx)
+	if !ok {
+		return
+	}
+
+	c.logger.Info("Deleting resource", zap.Uint("id", id))
+	if err := c.service.DeleteResource(ctx.Request.Context(), id); err != nil {
+		c.logger.Error("Failed to delete resource", zap.Uint("id", id), zap.Error(err))
+		ctx.Error(err)
+		return
+	}
+
+	c.logger.Info("Resource deleted successfully", zap.Uint("id", id))
+	ctx.JSON(http.StatusOK, gin.H{"message": "Resource deleted successfully"})
+}
+
+// CreateBooking blocks a resource for a time.
+func (c *ResourceController) CreateBooking(ctx *gin.Context) {
+	id, ok := c.parseResourceID(ctx)
+	if !ok {
+		return
+	}
+
+	var booking models.ResourceBooking
+	if err := ctx.ShouldBindJSON(&booking); err != nil {
+		c.logger.Error("Failed to bind JSON", zap.Error(err))
+		ctx.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+	booking.ResourceID = id
+
+	c.logger.Info("Creating resource booking", zap.Uint("resource_id", id), zap.Time("start_time", booking.StartTime))
+	if err := c.service.CreateBooking(ctx.Request.Context(), &booking); err != nil {
+		c.logger.Error("Failed to create resource booking", zap.Uint("resource_id", id), zap.Error(err))
+		ctx.Error(err)
+		return
+	}
+
+	c.logger.Info("Resource booking created successfully", zap.Uint("booking_id", booking.ID))
+	ctx.JSON(http.StatusCreated, booking)
+}
+
+// GetBookings lists a resource's bookings between from (default now) and to
+// (default 90 days later), including those of scheduled events.
+func (c *ResourceController) GetBookings(ctx *gin.Context) {
+	id, ok := c.parseResourceID(ctx)
+	if !ok {
+		return
+	}
+	from, ok := parseTimeQuery(ctx, "from", time.Now())
+	if !ok {
+		return
+	}
+	to, ok := parseTimeQuery(ctx, "to", from.Add(services.DefaultBookingWindow))
+	if !ok {
+		return
+	}
+
+	bookings, err := c.service.GetBookings(id, from, to)
+	if err != nil {
+		c.logger.Error("Failed to fetch resource bookings", zap.Uint("resource_id", id), zap.Error(err))
+		ctx.Error(err)
+		return
+	}
+
+	c.logger.Info("Retrieved resource bookings", zap.Uint("resource_id", id), zap.Int("count", len(bookings)))
+	ctx.JSON(http.StatusOK, bookings)
+}
+
+// DeleteBooking frees the time a booking blocked.
+func (c *ResourceController) DeleteBooking(ctx *gin.Context) {
+	id, ok := c.parseResourceID(ctx)
+	if !ok {
+		return
+	}
+	bookingID, err := strconv.ParseUint(ctx.Param("bookingId"), 10, 32)
+	if err != nil {
+		c.logger.Error("Invalid booking ID format", zap.String("booking_id", ctx.Param("bookingId")), zap.Error(err))
+		ctx.Error(middleware.NewProblem(http.StatusBadRequest, "Invalid booking ID format"))
+		return
+	}
+
+	c.logger.Info("Deleting resource booking", zap.Uint64("booking_id", bookingID))
+	if err := c.service.DeleteBooking(ctx.Request.Context(), id, uint(bookingID)); err != nil {
+		c.logger.Error("Failed to delete resource booking", zap.Uint64("booking_id", bookingID), zap.Error(err))
+		ctx.Error(err)
+		return
+	}
+
+	c.logger.Info("Resource booking deleted successfully", zap.Uint64("booking_id", bookingID))
+	ctx.JSON(http.StatusOK, gin.H{"message": "Resource booking deleted successfully"})
+}
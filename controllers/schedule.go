@@ -0,0 +1,132 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/krushnna/meeting-scheduler/middleware"
+	"github.com/krushnna/meeting-scheduler/models"
+	"github.com/krushnna/meeting-scheduler/services"
+	"go.uber.org/zap"
+)
+
+// FinalizeRequest picks the time a polling event is scheduled for. Set
+// override_quorum to finalize a time below the event's quorum.
+type FinalizeRequest struct {
+	StartTime      time.Time `json:"start_time" binding:"required"`
+	OverrideQuorum bool      `json:"override_quorum"`
+}
+
+// FinalizeEvent schedules an event at a start time inside one of its time slots.
+// It fails with 409 when the time overlaps another commitment of a participant
+// or falls short of the event's quorum without an override.
+func (c *EventController) FinalizeEvent(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		c.logger.Error("Invalid ID format", zap.String("id", ctx.Param("id")), zap.Error(err))
+		ctx.Error(middleware.NewProblem(http.StatusBadRequest, "Invalid event ID format"))
+		return
+	}
+
+	version, ok := parseIfMatch(ctx)
+	if !ok {
+		return
+	}
+
+	var request FinalizeRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		c.logger.Error("Failed to bind JSON", zap.Error(err))
+		ctx.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	c.logger.Info("Finalizing event", zap.Uint64("id", id), zap.Time("start_time", request.StartTime))
+	event, err := c.service.FinalizeEvent(ctx.Request.Context(), uint(id), version, request.StartTime, request.OverrideQuorum)
+	if err != nil {
+		c.logger.Error("Failed to finalize event", zap.Uint64("id", id), zap.Error(err))
+		ctx.Error(err)
+		return
+	}
+
+	c.logger.Info("Event finalized successfully", zap.Uint64("id", id))
+	setETag(ctx, event.Version)
+	ctx.JSON(http.StatusOK, event)
+}
+
+// RescheduleRequest reopens a scheduled event for polling. Availability is
+// cleared unless preserve_availability is set; time_slots are added to the event.
+type RescheduleRequest struct {
+	Reason               string            `json:"reason"`
+	PreserveAvailability bool              `json:"preserve_availability"`
+	TimeSlots            []models.TimeSlot `json:"time_slots" binding:"dive"`
+}
+
+// RescheduleEvent moves a scheduled event back into polling, keeping its previous
+// time in the schedule history and notifying participants.
+func (c *EventController) RescheduleEvent(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		c.logger.Error("Invalid ID format", zap.String("id", ctx.Param("id")), zap.Error(err))
+		ctx.Error(middleware.NewProblem(http.StatusBadRequest, "Invalid event ID format"))
+		return
+	}
+
+	version, ok := parseIfMatch(ctx)
+	if !ok {
+		return
+	}
+
+	var request RescheduleRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		c.logger.Error("Failed to bind JSON", zap.Error(err))
+		ctx.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	c.logger.Info("Rescheduling event", zap.Uint64("id", id), zap.Int("new_time_slots", len(request.TimeSlots)))
+	event, err := c.service.RescheduleEvent(ctx.Request.Context(), uint(id), version, services.RescheduleOptions{
+		Reason:               request.Reason,
+		PreserveAvailability: request.PreserveAvailability,
+		TimeSlots:            request.TimeSlots,
+	})
+	if err != nil {
+		c.logger.Error("Failed to reschedule event", zap.Uint64("id", id), zap.Error(err))
+		ctx.Error(err)
+		return
+	}
+
+	c.logger.Info("Event rescheduled successfully", zap.Uint64("id", id))
+	setETag(ctx, event.Version)
+	ctx.JSON(http.StatusOK, event)
+}
+
+// GetConflicts lists pairs of a user's scheduled events and series occurrences that
+// overlap, between from (default now) and to (default 90 days later).
+func (c *UserController) GetConflicts(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		c.logger.Error("Invalid user ID format", zap.String("id", ctx.Param("id")), zap.Error(err))
+		ctx.Error(middleware.NewProblem(http.StatusBadRequest, "Invalid user ID format"))
+		return
+	}
+	from, ok := parseTimeQuery(ctx, "from", time.Now())
+	if !ok {
+		return
+	}
+	to, ok := parseTimeQuery(ctx, "to", from.Add(services.DefaultConflictWindow))
+	if !ok {
+		return
+	}
+
+	conflicts, err := c.service.GetConflicts(uint(id), from, to)
+	if err != nil {
+		c.logger.Error("Failed to fetch conflicts", zap.Uint64("user_id", id), zap.Error(err))
+		ctx.Error(err)
+		return
+	}
+
+	c.logger.Info("Retrieved conflicts", zap.Uint64("user_id", id), zap.Int("count", len(conflicts)))
+	ctx.JSON(http.StatusOK, conflicts)
+}
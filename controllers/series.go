@@ -0,0 +1,265 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/krushnna/meeting-scheduler/middleware"
+	"github.com/krushnna/meeting-scheduler/models"
+	"github.com/krushnna/meeting-scheduler/services"
+	"go.uber.org/zap"
+)
+
+// SeriesController handles HTTP requests for recurring event series.
+type SeriesController struct {
+	service *services.EventSeriesService
+	logger  *zap.Logger
+}
+
+func NewSeriesController(service *services.EventSeriesService, logger *zap.Logger) *SeriesController {
+	return &SeriesController{
+		service: service,
+		logger:  logger.With(zap.String("controller", "series")),
+	}
+}
+
+// parseSeriesID reads the series ID path parameter, reporting a 400 when it is malformed.
+func (c *SeriesController) parseSeriesID(ctx *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		c.logger.Error("Invalid series ID format", zap.String("id", ctx.Param("id")), zap.Error(err))
+		ctx.Error(middleware.NewProblem(http.StatusBadRequest, "Invalid series ID format"))
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// parseTimeQuery reads an optional RFC 3339 query parameter, falling back to def.
+func parseTimeQuery(ctx *gin.Context, name string, def time.Time) (time.Time, bool) {
+	value := ctx.Query(name)
+	if value == "" {
+		return def, true
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		ctx.Error(middleware.NewProblem(http.StatusBadRequest, "Invalid "+name+" value, expected RFC 3339"))
+		return time.Time{}, false
+	}
+	return parsed, true
+}
+
+// parseCountQuery reads an optional positive integer query parameter up to max, falling back to def.
+func parseCountQuery(ctx *gin.Context, name string, def, max int) (int, bool) {
+	value := ctx.Query(name)
+	if value == "" {
+		return def, true
+	}
+	count, err := strconv.Atoi(value)
+	if err != nil || count <= 0 || count > max {
+		ctx.Error(middleware.NewProblem(http.StatusBadRequest, "Invalid "+name+" value, expected 1 to "+strconv.Itoa(max)))
+		return 0, false
+	}
+	return count, true
+}
+
+// CreateSeries creates a recurring event series from an RRULE.
+func (c *SeriesController) CreateSeries(ctx *gin.Context) {
+	var series models.EventSeries
+	if err := ctx.ShouldBindJSON(&series); err != nil {
+		c.logger.Error("Failed to bind JSON", zap.Error(err))
+		ctx.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	c.logger.Info("Creating event series", zap.String("title", series.Title), zap.String("rrule", series.RRule))
+	if err := c.service.CreateSeries(ctx.Request.Context(), &series); err != nil {
+		c.logger.Error("Failed to create event series", zap.Error(err))
+		ctx.Error(err)
+		return
+	}
+
+	c.logger.Info("Event series created successfully", zap.Uint("series_id", series.ID))
+	ctx.JSON(http.StatusCreated, series)
+}
+
+// GetSeries retrieves an event series by its ID.
+func (c *SeriesController) GetSeries(ctx *gin.Context) {
+	id, ok := c.parseSeriesID(ctx)
+	if !ok {
+		return
+	}
+
+	series, err := c.service.GetSeries(id)
+	if err != nil {
+		c.logger.Error("Failed to fetch event series", zap.Uint("id", id), zap.Error(err))
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, series)
+}
+
+// GetAllSeries returns one page of event series.
+// Paging: limit, cursor and sort (id, created_at, title or start_time, prefixed with "-" for descending).
+func (c *SeriesController) GetAllSeries(ctx *gin.Context) {
+	page, ok := parsePageRequest(ctx)
+	if !ok {
+		return
+	}
+
+	series, err := c.service.ListSeries(page)
+	if err != nil {
+		c.logger.Error("Failed to fetch event series", zap.Error(err))
+		ctx.Error(err)
+		return
+	}
+
+	c.logger.Info("Retrieved event series", zap.Int("count", len(series.Items)), zap.Int64("total", series.Total))
+	writePage(ctx, series)
+}
+
+// DeleteSeries deletes an event series and its exceptions.
+func (c *SeriesController) DeleteSeries(ctx *gin.Context) {
+	id, ok := c.parseSeriesID(ctx)
+	if !ok {
+		return
+	}
+
+	c.logger.Info("Deleting event series", zap.Uint("id", id))
+	if err := c.service.DeleteSeries(ctx.Request.Context(), id); err != nil {
+		c.logger.Error("Failed to delete event series", zap.Uint("id", id), zap.Error(err))
+		ctx.Error(err)
+		return
+	}
+
+	c.logger.Info("Event series deleted successfully", zap.Uint("id", id))
+	ctx.JSON(http.StatusOK, gin.H{"message": "Event series deleted successfully"})
+}
+
+// GetOccurrences expands a series between from (default now) and to (default
+// 90 days later), returning at most limit occurrences (default 50, max 500).
+func (c *SeriesController) GetOccurrences(ctx *gin.Context) {
+	id, ok := c.parseSeriesID(ctx)
+	if !ok {
+		return
+	}
+	from, ok := parseTimeQuery(ctx, "from", time.Now())
+	if !ok {
+		return
+	}
+	to, ok := parseTimeQuery(ctx, "to", from.Add(services.DefaultOccurrenceWindow))
+	if !ok {
+		return
+	}
+	limit, ok := parseCountQuery(ctx, "limit", services.DefaultOccurrenceLimit, services.MaxOccurrenceLimit)
+	if !ok {
+		return
+	}
+
+	occurrences, err := c.service.GetOccurrences(id, from, to, limit)
+	if err != nil {
+		c.logger.Error("Failed to expand event series", zap.Uint("id", id), zap.Error(err))
+		ctx.Error(err)
+		return
+	}
+
+	c.logger.Info("Expanded event series", zap.Uint("id", id), zap.Int("count", len(occurrences)))
+	ctx.JSON(http.StatusOK, occurrences)
+}
+
+// CreateException moves or cancels one occurrence of a series.
+func (c *SeriesController) CreateException(ctx *gin.Context) {
+	id, ok := c.parseSeriesID(ctx)
+	if !ok {
+		return
+	}
+
+	var exception models.SeriesException
+	if err := ctx.ShouldBindJSON(&exception); err != nil {
+		c.logger.Error("Failed to bind JSON", zap.Error(err))
+		ctx.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+	exception.SeriesID = id
+
+	c.logger.Info("Creating series exception", zap.Uint("series_id", id), zap.Time("original_start", exception.OriginalStart))
+	if err := c.service.CreateException(ctx.Request.Context(), &exception); err != nil {
+		c.logger.Error("Failed to create series exception", zap.Uint("series_id", id), zap.Error(err))
+		ctx.Error(err)
+		return
+	}
+
+	c.logger.Info("Series exception created successfully", zap.Uint("exception_id", exception.ID))
+	ctx.JSON(http.StatusCreated, exception)
+}
+
+// GetExceptions lists the moved and cancelled occurrences of a series.
+func (c *SeriesController) GetExceptions(ctx *gin.Context) {
+	id, ok := c.parseSeriesID(ctx)
+	if !ok {
+		return
+	}
+
+	exceptions, err := c.service.GetExceptions(id)
+	if err != nil {
+		c.logger.Error("Failed to fetch series exceptions", zap.Uint("series_id", id), zap.Error(err))
+		ctx.Error(err)
+		return
+	}
+
+	c.logger.Info("Retrieved series exceptions", zap.Uint("series_id", id), zap.Int("count", len(exceptions)))
+	ctx.JSON(http.StatusOK, exceptions)
+}
+
+// DeleteException restores an occurrence to its time under the rule.
+func (c *SeriesController) DeleteException(ctx *gin.Context) {
+	id, ok := c.parseSeriesID(ctx)
+	if !ok {
+		return
+	}
+	exceptionID, err := strconv.ParseUint(ctx.Param("exceptionId"), 10, 32)
+	if err != nil {
+		c.logger.Error("Invalid exception ID format", zap.String("exception_id", ctx.Param("exceptionId")), zap.Error(err))
+		ctx.Error(middleware.NewProblem(http.StatusBadRequest, "Invalid exception ID format"))
+		return
+	}
+
+	c.logger.Info("Deleting series exception", zap.Uint64("exception_id", exceptionID))
+	if err := c.service.DeleteException(ctx.Request.Context(), id, uint(exceptionID)); err != nil {
+		c.logger.Error("Failed to delete series exception", zap.Uint64("exception_id", exceptionID), zap.Error(err))
+		ctx.Error(err)
+		return
+	}
+
+	c.logger.Info("Series exception deleted successfully", zap.Uint64("exception_id", exceptionID))
+	ctx.JSON(http.StatusOK, gin.H{"message": "Series exception deleted successfully"})
+}
+
+// GetRecommendations ranks weekly start times for a series across its next
+// occurrences (default 4, max 52) after from (default now).
+func (c *SeriesController) GetRecommendations(ctx *gin.Context) {
+	id, ok := c.parseSeriesID(ctx)
+	if !ok {
+		return
+	}
+	from, ok := parseTimeQuery(ctx, "from", time.Now())
+	if !ok {
+		return
+	}
+	count, ok := parseCountQuery(ctx, "occurrences", services.DefaultRecommendationOccurrences, services.MaxRecommendationOccurrences)
+	if !ok {
+		return
+	}
+
+	recommendations, err := c.service.GetRecommendations(id, from, count)
+	if err != nil {
+		c.logger.Error("Failed to generate series recommendations", zap.Uint("id", id), zap.Error(err))
+		ctx.Error(err)
+		return
+	}
+
+	c.logger.Info("Generated series recommendations", zap.Uint("id", id), zap.Int("count", len(recommendations)))
+	ctx.JSON(http.StatusOK, recommendations)
+}
@@ -0,0 +1,160 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/krushnna/meeting-scheduler/middleware"
+	"github.com/krushnna/meeting-scheduler/services"
+	"go.uber.org/zap"
+)
+
+// TrashController handles admin HTTP requests for soft-deleted records.
+type TrashController struct {
+	service *services.TrashService
+	logger  *zap.Logger
+}
+
+func NewTrashController(service *services.TrashService, logger *zap.Logger) *TrashController {
+	return &TrashController{
+		service: service,
+		logger:  logger.With(zap.String("controller", "trash")),
+	}
+}
+
+// GetDeletedEvents lists a page of soft-deleted events, most recently deleted first
+// unless sorted otherwise.
+func (c *TrashController) GetDeletedEvents(ctx *gin.Context) {
+	page, ok := parsePageRequest(ctx)
+	if !ok {
+		return
+	}
+
+	events, err := c.service.GetDeletedEvents(page)
+	if err != nil {
+		c.logger.Error("Failed to fetch deleted events", zap.Error(err))
+		ctx.Error(err)
+		return
+	}
+
+	c.logger.Info("Retrieved deleted events", zap.Int("count", len(events.Items)), zap.Int64("total", events.Total))
+	writePage(ctx, events)
+}
+
+// GetDeletedUsers lists a page of soft-deleted users, most recently deleted first
+// unless sorted otherwise.
+func (c *TrashController) GetDeletedUsers(ctx *gin.Context) {
+	page, ok := parsePageRequest(ctx)
+	if !ok {
+		return
+	}
+
+	users, err := c.service.GetDeletedUsers(page)
+	if err != nil {
+		c.logger.Error("Failed to fetch deleted users", zap.Error(err))
+		ctx.Error(err)
+		return
+	}
+
+	c.logger.Info("Retrieved deleted users", zap.Int("count", len(users.Items)), zap.Int64("total", users.Total))
+	writePage(ctx, users)
+}
+
+// RestoreEvent undeletes an event along with its time slots and availability.
+func (c *TrashController) RestoreEvent(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		c.logger.Error("Invalid ID format", zap.String("id", ctx.Param("id")), zap.Error(err))
+		ctx.Error(middleware.NewProblem(http.StatusBadRequest, "Invalid event ID format"))
+		return
+	}
+
+	c.logger.Info("Restoring event", zap.Uint64("id", id))
+	event, err := c.service.RestoreEvent(ctx.Request.Context(), uint(id))
+	if err != nil {
+		c.logger.Error("Failed to restore event", zap.Uint64("id", id), zap.Error(err))
+		ctx.Error(err)
+		return
+	}
+
+	c.logger.Info("Event restored successfully", zap.Uint64("id", id))
+	ctx.JSON(http.StatusOK, event)
+}
+
+// RestoreUser undeletes a user along with their availability.
+func (c *TrashController) RestoreUser(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		c.logger.Error("Invalid ID format", zap.String("id", ctx.Param("id")), zap.Error(err))
+		ctx.Error(middleware.NewProblem(http.StatusBadRequest, "Invalid user ID format"))
+		return
+	}
+
+	c.logger.Info("Restoring user", zap.Uint64("id", id))
+	user, err := c.service.RestoreUser(ctx.Request.Context(), uint(id))
+	if err != nil {
+		c.logger.Error("Failed to restore user", zap.Uint64("id", id), zap.Error(err))
+		ctx.Error(err)
+		return
+	}
+
+	c.logger.Info("User restored successfully", zap.Uint64("id", id))
+	ctx.JSON(http.StatusOK, user)
+}
+
+// PurgeEvent permanently removes a deleted event.
+func (c *TrashController) PurgeEvent(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		c.logger.Error("Invalid ID format", zap.String("id", ctx.Param("id")), zap.Error(err))
+		ctx.Error(middleware.NewProblem(http.StatusBadRequest, "Invalid event ID format"))
+		return
+	}
+
+	c.logger.Info("Purging event", zap.Uint64("id", id))
+	err = c.service.PurgeEvent(ctx.Request.Context(), uint(id))
+	if err != nil {
+		c.logger.Error("Failed to purge event", zap.Uint64("id", id), zap.Error(err))
+		ctx.Error(err)
+		return
+	}
+
+	c.logger.Info("Event purged successfully", zap.Uint64("id", id))
+	ctx.JSON(http.StatusOK, gin.H{"message": "Event purged successfully"})
+}
+
+// PurgeUser permanently removes a deleted user.
+func (c *TrashController) PurgeUser(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		c.logger.Error("Invalid ID format", zap.String("id", ctx.Param("id")), zap.Error(err))
+		ctx.Error(middleware.NewProblem(http.StatusBadRequest, "Invalid user ID format"))
+		return
+	}
+
+	c.logger.Info("Purging user", zap.Uint64("id", id))
+	err = c.service.PurgeUser(ctx.Request.Context(), uint(id))
+	if err != nil {
+		c.logger.Error("Failed to purge user", zap.Uint64("id", id), zap.Error(err))
+		ctx.Error(err)
+		return
+	}
+
+	c.logger.Info("User purged successfully", zap.Uint64("id", id))
+	ctx.JSON(http.StatusOK, gin.H{"message": "User purged successfully"})
+}
+
+// PurgeExpired permanently removes every record deleted before the retention window.
+func (c *TrashController) PurgeExpired(ctx *gin.Context) {
+	c.logger.Info("Purging expired deleted records", zap.Duration("retention", c.service.Retention()))
+	purged, err := c.service.PurgeExpired(ctx.Request.Context())
+	if err != nil {
+		c.logger.Error("Failed to purge expired records", zap.Error(err))
+		ctx.Error(err)
+		return
+	}
+
+	c.logger.Info("Expired records purged", zap.Int64("count", purged))
+	ctx.JSON(http.StatusOK, gin.H{"purged": purged})
+}
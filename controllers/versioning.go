@@ -0,0 +1,31 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/krushnna/meeting-scheduler/middleware"
+)
+
+// setETag sets the ETag header to the resource version.
+func setETag(ctx *gin.Context, version uint) {
+	ctx.Header("ETag", `"`+strconv.FormatUint(uint64(version), 10)+`"`)
+}
+
+// parseIfMatch returns the version required by the If-Match header, or 0 when the
+// header is absent or "*". Tags that can never match a version are rejected with 412.
+func parseIfMatch(ctx *gin.Context) (uint, bool) {
+	header := strings.TrimSpace(ctx.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return 0, true
+	}
+
+	version, err := strconv.ParseUint(strings.Trim(header, `"`), 10, 32)
+	if err != nil || version == 0 || !strings.HasPrefix(header, `"`) || !strings.HasSuffix(header, `"`) {
+		ctx.Error(middleware.NewProblem(http.StatusPreconditionFailed, "If-Match must be a single ETag returned by this API"))
+		return 0, false
+	}
+	return uint(version), true
+}
@@ -0,0 +1,107 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/krushnna/meeting-scheduler/middleware"
+	"github.com/krushnna/meeting-scheduler/models"
+	"github.com/krushnna/meeting-scheduler/services"
+	"go.uber.org/zap"
+)
+
+// VoteController handles HTTP requests for votes on an event's time slots.
+type VoteController struct {
+	service *services.VoteService
+	logger  *zap.Logger
+}
+
+func NewVoteController(service *services.VoteService, logger *zap.Logger) *VoteController {
+	return &VoteController{
+		service: service,
+		logger:  logger.With(zap.String("controller", "vote")),
+	}
+}
+
+// parseUserEventIDs reads the user and event ID path parameters of a user's
+// ballot, reporting a 400 when either is malformed.
+func (c *VoteController) parseUserEventIDs(ctx *gin.Context) (uint, uint, bool) {
+	userID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		c.logger.Error("Invalid user ID format", zap.String("user_id", ctx.Param("id")), zap.Error(err))
+		ctx.Error(middleware.NewProblem(http.StatusBadRequest, "Invalid user ID format"))
+		return 0, 0, false
+	}
+	eventID, err := strconv.ParseUint(ctx.Param("eventId"), 10, 32)
+	if err != nil {
+		c.logger.Error("Invalid event ID format", zap.String("event_id", ctx.Param("eventId")), zap.Error(err))
+		ctx.Error(middleware.NewProblem(http.StatusBadRequest, "Invalid event ID format"))
+		return 0, 0, false
+	}
+	return uint(userID), uint(eventID), true
+}
+
+// SubmitVotes replaces a user's yes/maybe/no votes on an event's time slots.
+// Slots missing from the ballot lose the user's vote.
+func (c *VoteController) SubmitVotes(ctx *gin.Context) {
+	userID, eventID, ok := c.parseUserEventIDs(ctx)
+	if !ok {
+		return
+	}
+
+	var ballot []models.SlotVote
+	if err := ctx.ShouldBindJSON(&ballot); err != nil {
+		c.logger.Error("Failed to bind JSON", zap.Error(err))
+		ctx.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	c.logger.Info("Submitting votes", zap.Uint("user_id", userID), zap.Uint("event_id", eventID), zap.Int("count", len(ballot)))
+	votes, err := c.service.SubmitVotes(ctx.Request.Context(), userID, eventID, ballot)
+	if err != nil {
+		c.logger.Error("Failed to submit votes", zap.Uint("user_id", userID), zap.Uint("event_id", eventID), zap.Error(err))
+		ctx.Error(err)
+		return
+	}
+
+	c.logger.Info("Votes submitted successfully", zap.Uint("user_id", userID), zap.Uint("event_id", eventID))
+	ctx.JSON(http.StatusOK, votes)
+}
+
+// GetUserVotes returns a user's votes on an event's time slots.
+func (c *VoteController) GetUserVotes(ctx *gin.Context) {
+	userID, eventID, ok := c.parseUserEventIDs(ctx)
+	if !ok {
+		return
+	}
+
+	votes, err := c.service.GetUserVotes(userID, eventID)
+	if err != nil {
+		c.logger.Error("Failed to fetch votes", zap.Uint("user_id", userID), zap.Uint("event_id", eventID), zap.Error(err))
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, votes)
+}
+
+// GetResults returns the per-slot breakdown of an event's votes.
+func (c *VoteController) GetResults(ctx *gin.Context) {
+	eventID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		c.logger.Error("Invalid event ID format", zap.String("id", ctx.Param("id")), zap.Error(err))
+		ctx.Error(middleware.NewProblem(http.StatusBadRequest, "Invalid event ID format"))
+		return
+	}
+
+	results, err := c.service.GetResults(uint(eventID))
+	if err != nil {
+		c.logger.Error("Failed to fetch vote results", zap.Uint64("event_id", eventID), zap.Error(err))
+		ctx.Error(err)
+		return
+	}
+
+	c.logger.Info("Retrieved vote results", zap.Uint64("event_id", eventID), zap.Int("slots", len(results)))
+	ctx.JSON(http.StatusOK, results)
+}
@@ -0,0 +1,25 @@
+package initializers
+
+import (
+	"context"
+
+	"github.com/krushnna/meeting-scheduler/config"
+	"github.com/krushnna/meeting-scheduler/repository"
+	"github.com/krushnna/meeting-scheduler/services"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// StartAutoFinalizer launches the goroutine that finalizes events whose response
+// deadline has passed or whose required participants have all responded.
+func StartAutoFinalizer(ctx context.Context, db *gorm.DB, logger *zap.Logger) {
+	cfg := config.LoadAutoFinalizeConfig()
+
+	eventRepo := repository.NewEventRepository(db)
+	transactor := repository.NewTransactor(db)
+	recommendations := services.NewRecommendationService(transactor)
+	finalizer := services.NewAutoFinalizer(eventRepo, recommendations, transactor, cfg.PollInterval, logger)
+	go finalizer.Run(ctx)
+
+	logger.Info("Auto-finalizer started", zap.Duration("interval", cfg.PollInterval))
+}
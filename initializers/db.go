@@ -4,27 +4,42 @@ import (
 	"log"
 
 	"github.com/krushnna/meeting-scheduler/config"
-	"github.com/krushnna/meeting-scheduler/models"
+	"github.com/krushnna/meeting-scheduler/migrations"
 	"gorm.io/gorm"
 )
 
-// InitDB initializes the database connection and performs migrations.
+// InitDB initializes the database connection and applies pending migrations.
+// Set DB_AUTO_MIGRATE=false to leave schema changes to the migrate subcommand.
 func InitDB() *gorm.DB {
+	db := ConnectDB()
+
+	if config.AutoMigrateEnabled() {
+		if _, err := RunMigrations(db); err != nil {
+			log.Fatalf("Migration failed: %v", err)
+		}
+	}
+
+	return db
+}
+
+// ConnectDB opens the database connection without touching the schema.
+func ConnectDB() *gorm.DB {
 	db, err := config.InitDB()
 	if err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
+	return db
+}
 
-	// Auto migrate your models
-	err = db.AutoMigrate(
-		&models.Event{},
-		&models.TimeSlot{},
-		&models.User{},
-		&models.UserAvailability{},
-	)
+// RunMigrations applies every pending versioned migration.
+func RunMigrations(db *gorm.DB) ([]migrations.Migration, error) {
+	migrator, err := migrations.New(db)
 	if err != nil {
-		log.Fatalf("AutoMigrate failed: %v", err)
+		return nil, err
 	}
-
-	return db
+	applied, err := migrator.Up()
+	for _, m := range applied {
+		log.Printf("Applied migration %04d_%s", m.Version, m.Name)
+	}
+	return applied, err
 }
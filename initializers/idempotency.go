@@ -0,0 +1,40 @@
+package initializers
+
+import (
+	"context"
+	"time"
+
+	"github.com/krushnna/meeting-scheduler/config"
+	"github.com/krushnna/meeting-scheduler/repository"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// StartIdempotencyPurger launches the goroutine that removes stored
+// Idempotency-Key responses once they are older than IDEMPOTENCY_KEY_TTL.
+func StartIdempotencyPurger(ctx context.Context, db *gorm.DB, logger *zap.Logger) {
+	cfg := config.LoadIdempotencyConfig()
+	repo := repository.NewIdempotencyRepository(db)
+	logger = logger.With(zap.String("component", "idempotency"))
+
+	go func() {
+		ticker := time.NewTicker(cfg.PurgeInterval)
+		defer ticker.Stop()
+
+		for {
+			purged, err := repo.DeleteExpired(time.Now())
+			if err != nil {
+				logger.Error("Failed to purge expired idempotency keys", zap.Error(err))
+			} else if purged > 0 {
+				logger.Info("Purged expired idempotency keys", zap.Int64("count", purged))
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	logger.Info("Idempotency key purger started", zap.Duration("ttl", cfg.TTL), zap.Duration("interval", cfg.PurgeInterval))
+}
@@ -0,0 +1,27 @@
+package initializers
+
+import (
+	"context"
+
+	"github.com/krushnna/meeting-scheduler/config"
+	"github.com/krushnna/meeting-scheduler/repository"
+	"github.com/krushnna/meeting-scheduler/services"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// StartOutboxDispatcher launches the goroutine that delivers outbox events
+// to the log and to any webhooks configured in OUTBOX_WEBHOOK_URLS.
+func StartOutboxDispatcher(ctx context.Context, db *gorm.DB, logger *zap.Logger) {
+	cfg := config.LoadOutboxConfig()
+
+	notifiers := []services.Notifier{services.NewLogNotifier(logger)}
+	for _, url := range cfg.WebhookURLs {
+		notifiers = append(notifiers, services.NewWebhookNotifier(url, cfg.WebhookTimeout))
+	}
+
+	dispatcher := services.NewOutboxDispatcher(repository.NewOutboxRepository(db), notifiers, cfg.PollInterval, logger)
+	go dispatcher.Run(ctx)
+
+	logger.Info("Outbox dispatcher started", zap.Int("webhooks", len(cfg.WebhookURLs)), zap.Duration("interval", cfg.PollInterval))
+}
@@ -0,0 +1,43 @@
+package initializers
+
+import (
+	"context"
+	"time"
+
+	"github.com/krushnna/meeting-scheduler/config"
+	"github.com/krushnna/meeting-scheduler/repository"
+	"github.com/krushnna/meeting-scheduler/services"
+	"github.com/krushnna/meeting-scheduler/utils"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// StartTrashPurger launches the goroutine that permanently removes records
+// soft-deleted longer ago than TRASH_RETENTION_DAYS.
+func StartTrashPurger(ctx context.Context, db *gorm.DB, logger *zap.Logger) {
+	cfg := config.LoadTrashConfig()
+	service := services.NewTrashService(repository.NewTrashRepository(db), repository.NewTransactor(db), cfg.Retention)
+	logger = logger.With(zap.String("component", "trash"))
+
+	go func() {
+		ticker := time.NewTicker(cfg.PurgeInterval)
+		defer ticker.Stop()
+		purgeCtx := utils.WithRequestMeta(ctx, utils.RequestMeta{Actor: utils.SystemActor})
+
+		for {
+			purged, err := service.PurgeExpired(purgeCtx)
+			if err != nil {
+				logger.Error("Failed to purge deleted records", zap.Error(err))
+			} else if purged > 0 {
+				logger.Info("Purged deleted records", zap.Int64("count", purged))
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	logger.Info("Trash purger started", zap.Duration("retention", cfg.Retention), zap.Duration("interval", cfg.PurgeInterval))
+}
@@ -1,11 +1,16 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/krushnna/meeting-scheduler/initializers"
+	"github.com/krushnna/meeting-scheduler/migrations"
 	"github.com/krushnna/meeting-scheduler/routers"
 	"github.com/krushnna/meeting-scheduler/utils"
 )
@@ -21,9 +26,24 @@ func main() {
 
 	logger := utils.GetLogger()
 
-	// Initialize the databases and auto-migrate models
+	// Schema management subcommand: meeting-scheduler migrate status|up|down [n]
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		runMigrate(os.Args[2:])
+		return
+	}
+
+	// Initialize the databases and apply pending migrations
 	db := initializers.InitDB()
 
+	// Background workers: outbox delivery, auto-finalizing events and purging of
+	// expired records
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	initializers.StartOutboxDispatcher(ctx, db, logger)
+	initializers.StartTrashPurger(ctx, db, logger)
+	initializers.StartIdempotencyPurger(ctx, db, logger)
+	initializers.StartAutoFinalizer(ctx, db, logger)
+
 	// Set up the router
 	router := routers.SetupRouter(db, logger)
 
@@ -38,3 +58,55 @@ func main() {
 		log.Fatalf("Failed to start server:- %v", err)
 	}
 }
+
+// runMigrate implements the migrate subcommand.
+func runMigrate(args []string) {
+	if len(args) == 0 {
+		log.Fatal("Usage: meeting-scheduler migrate status|up|down [steps]")
+	}
+
+	db := initializers.ConnectDB()
+	migrator, err := migrations.New(db)
+	if err != nil {
+		log.Fatalf("Failed to load migrations: %v", err)
+	}
+
+	switch args[0] {
+	case "status":
+		statuses, err := migrator.Status()
+		if err != nil {
+			log.Fatalf("Failed to read migration status: %v", err)
+		}
+		for _, s := range statuses {
+			applied := "pending"
+			if s.AppliedAt != nil {
+				applied = "applied " + s.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Printf("%04d_%s\t%s\n", s.Version, s.Name, applied)
+		}
+	case "up":
+		applied, err := migrator.Up()
+		for _, m := range applied {
+			fmt.Printf("Applied %04d_%s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			log.Fatalf("Migration failed: %v", err)
+		}
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			if steps, err = strconv.Atoi(args[1]); err != nil || steps <= 0 {
+				log.Fatalf("Invalid number of steps: %s", args[1])
+			}
+		}
+		reverted, err := migrator.Down(steps)
+		for _, m := range reverted {
+			fmt.Printf("Reverted %04d_%s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			log.Fatalf("Rollback failed: %v", err)
+		}
+	default:
+		log.Fatalf("Unknown migrate command %q", args[0])
+	}
+}
@@ -0,0 +1,155 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/krushnna/meeting-scheduler/repository"
+	"github.com/krushnna/meeting-scheduler/services"
+	"go.uber.org/zap"
+)
+
+// ProblemContentType is the media type of RFC 7807 error responses
+const ProblemContentType = "application/problem+json"
+
+// FieldError describes one invalid field of a request
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Problem is an RFC 7807 problem details response. It implements error, so
+// handlers can report HTTP-level failures such as a malformed ID with ctx.Error.
+type Problem struct {
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Detail    string       `json:"detail,omitempty"`
+	Instance  string       `json:"instance,omitempty"`
+	RequestID string       `json:"request_id,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
+}
+
+// NewProblem returns a problem with the standard title for status
+func NewProblem(status int, detail string) *Problem {
+	return &Problem{Type: "about:blank", Title: http.StatusText(status), Status: status, Detail: detail}
+}
+
+func (p *Problem) Error() string {
+	return p.Detail
+}
+
+// ErrorHandler renders the last error a handler attached with ctx.Error as
+// problem+json. Domain errors from the services map to 404, 409, 412 and
+// 422; anything unrecognised is logged and reported as a 500 without details.
+func ErrorHandler(logger *zap.Logger) gin.HandlerFunc {
+	logger = logger.With(zap.String("middleware", "errors"))
+	useJSONFieldNames()
+
+	return func(ctx *gin.Context) {
+		ctx.Next()
+		writeProblem(ctx, logger)
+	}
+}
+
+// NoRoute reports unknown paths as a problem
+func NoRoute(ctx *gin.Context) {
+	ctx.Error(NewProblem(http.StatusNotFound, "No route matches "+ctx.Request.Method+" "+ctx.Request.URL.Path))
+}
+
+// writeProblem renders pending errors unless a response has already been written
+func writeProblem(ctx *gin.Context, logger *zap.Logger) {
+	if len(ctx.Errors) == 0 || ctx.Writer.Written() {
+		return
+	}
+
+	last := ctx.Errors.Last()
+	problem := problemFor(last)
+	if problem.Status >= http.StatusInternalServerError {
+		logger.Error("Request failed", zap.String("method", ctx.Request.Method), zap.String("path", ctx.Request.URL.Path), zap.Error(last.Err))
+	}
+	problem.Instance = ctx.Request.URL.Path
+	problem.RequestID = ctx.GetString("request_id")
+
+	body, err := json.Marshal(problem)
+	if err != nil {
+		ctx.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+	ctx.Data(problem.Status, ProblemContentType, body)
+}
+
+func problemFor(ginErr *gin.Error) *Problem {
+	err := ginErr.Err
+
+	var problem *Problem
+	var fieldErrs validator.ValidationErrors
+	var validationErr *services.ValidationError
+	var notFoundErr *services.NotFoundError
+	var conflictErr *services.ConflictError
+
+	switch {
+	case errors.As(err, &problem):
+		copied := *problem
+		return &copied
+	case errors.As(err, &fieldErrs):
+		problem = NewProblem(http.StatusUnprocessableEntity, "The request has invalid fields")
+		for _, fieldErr := range fieldErrs {
+			problem.Errors = append(problem.Errors, FieldError{Field: fieldErr.Field(), Message: fieldMessage(fieldErr)})
+		}
+		return problem
+	case errors.As(err, &validationErr):
+		problem = NewProblem(http.StatusUnprocessableEntity, validationErr.Message)
+		if validationErr.Field != "" {
+			problem.Errors = []FieldError{{Field: validationErr.Field, Message: validationErr.Message}}
+		}
+		return problem
+	case errors.As(err, &notFoundErr):
+		return NewProblem(http.StatusNotFound, notFoundErr.Error())
+	case errors.As(err, &conflictErr):
+		return NewProblem(http.StatusConflict, conflictErr.Error())
+	case errors.Is(err, repository.ErrStaleVersion):
+		return NewProblem(http.StatusPreconditionFailed, err.Error())
+	case errors.Is(err, repository.ErrInvalidPage):
+		return NewProblem(http.StatusBadRequest, err.Error())
+	case ginErr.IsType(gin.ErrorTypeBind):
+		return NewProblem(http.StatusBadRequest, "Invalid JSON payload: "+err.Error())
+	default:
+		return NewProblem(http.StatusInternalServerError, "An unexpected error occurred")
+	}
+}
+
+// fieldMessage describes a failed binding rule in words
+func fieldMessage(fieldErr validator.FieldError) string {
+	switch fieldErr.Tag() {
+	case "required":
+		return fieldErr.Field() + " is required"
+	case "email":
+		return fieldErr.Field() + " must be a valid email address"
+	case "min":
+		return fieldErr.Field() + " must be at least " + fieldErr.Param()
+	default:
+		return fieldErr.Field() + " is invalid"
+	}
+}
+
+// useJSONFieldNames makes binding errors name fields as they appear in JSON
+func useJSONFieldNames() {
+	engine, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+	engine.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+}
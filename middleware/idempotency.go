@@ -0,0 +1,155 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/krushnna/meeting-scheduler/models"
+	"github.com/krushnna/meeting-scheduler/repository"
+	"github.com/krushnna/meeting-scheduler/utils"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// Headers used for idempotent requests
+const (
+	IdempotencyKeyHeader     = "Idempotency-Key"
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+	maxIdempotencyKeyLength  = 255
+)
+
+// responseRecorder copies everything written to the client so it can be stored
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// Idempotency makes requests carrying an Idempotency-Key header safe to retry.
+// The first response for a key (per caller) is stored for ttl and replayed
+// verbatim to retries; reusing a key for a different request returns 422, and a
+// retry that arrives while the first request is still running returns 409.
+// Server errors are not stored, so the request can be retried.
+func Idempotency(repo repository.IdempotencyRepository, ttl time.Duration, logger *zap.Logger) gin.HandlerFunc {
+	logger = logger.With(zap.String("middleware", "idempotency"))
+
+	return func(ctx *gin.Context) {
+		key := ctx.GetHeader(IdempotencyKeyHeader)
+		if key == "" {
+			ctx.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			ctx.Error(NewProblem(http.StatusBadRequest, "Idempotency-Key must be at most 255 characters"))
+			ctx.Abort()
+			return
+		}
+
+		body, err := io.ReadAll(ctx.Request.Body)
+		if err != nil {
+			ctx.Error(NewProblem(http.StatusBadRequest, "Error reading request body: "+err.Error()))
+			ctx.Abort()
+			return
+		}
+		ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		actor := utils.RequestMetaFrom(ctx.Request.Context()).Actor
+		hash := requestHash(ctx.Request.Method, ctx.Request.URL.Path, body)
+		now := time.Now()
+
+		record := &models.IdempotencyKey{
+			Actor:       actor,
+			Key:         key,
+			RequestHash: hash,
+			ExpiresAt:   now.Add(ttl),
+		}
+		reserved, err := repo.Reserve(record, now)
+		if err != nil {
+			logger.Error("Failed to reserve idempotency key", zap.String("key", key), zap.Error(err))
+			ctx.Error(err)
+			ctx.Abort()
+			return
+		}
+		if !reserved {
+			replay(ctx, repo, logger, actor, key, hash, now)
+			return
+		}
+
+		completed := false
+		defer func() {
+			if !completed {
+				if err := repo.Release(record.ID); err != nil {
+					logger.Error("Failed to release idempotency key", zap.String("key", key), zap.Error(err))
+				}
+			}
+		}()
+
+		recorder := &responseRecorder{ResponseWriter: ctx.Writer}
+		ctx.Writer = recorder
+		ctx.Next()
+		writeProblem(ctx, logger)
+
+		status := recorder.Status()
+		if status >= http.StatusInternalServerError {
+			return
+		}
+		if err := repo.Complete(record.ID, status, recorder.Header().Get("Content-Type"), recorder.body.String()); err != nil {
+			logger.Error("Failed to store idempotent response", zap.String("key", key), zap.Error(err))
+			return
+		}
+		completed = true
+	}
+}
+
+// replay answers a request whose key is already taken
+func replay(ctx *gin.Context, repo repository.IdempotencyRepository, logger *zap.Logger, actor, key, hash string, now time.Time) {
+	stored, err := repo.Find(actor, key, now)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// The other request failed and released the key in the meantime.
+		ctx.Error(NewProblem(http.StatusConflict, "The original request with this Idempotency-Key failed, please retry"))
+		ctx.Abort()
+		return
+	}
+	if err != nil {
+		logger.Error("Failed to load idempotency key", zap.String("key", key), zap.Error(err))
+		ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+
+	switch {
+	case stored.RequestHash != hash:
+		ctx.Error(NewProblem(http.StatusUnprocessableEntity, "Idempotency-Key was already used for a different request"))
+		ctx.Abort()
+	case stored.StatusCode == 0:
+		ctx.Error(NewProblem(http.StatusConflict, "A request with this Idempotency-Key is still being processed"))
+		ctx.Abort()
+	default:
+		ctx.Header(IdempotentReplayedHeader, "true")
+		ctx.Data(stored.StatusCode, stored.ContentType, []byte(stored.ResponseBody))
+		ctx.Abort()
+	}
+}
+
+// requestHash fingerprints the method, path and body of a request
+func requestHash(method, path string, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(method + " " + path + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/gin-gonic/gin"
+	"github.com/krushnna/meeting-scheduler/utils"
+)
+
+// Header names used to correlate requests and identify callers
+const (
+	RequestIDHeader = "X-Request-ID"
+	ActorHeader     = "X-User-ID"
+)
+
+// RequestContext assigns every request an ID (reusing an incoming X-Request-ID)
+// and records it with the caller from X-User-ID on the request context, so
+// services can attribute the changes they make.
+func RequestContext() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		requestID := ctx.GetHeader(RequestIDHeader)
+		if requestID == "" {
+			requestID = newRequestID()
+		}
+		ctx.Header(RequestIDHeader, requestID)
+		ctx.Set("request_id", requestID)
+
+		meta := utils.RequestMeta{RequestID: requestID, Actor: ctx.GetHeader(ActorHeader)}
+		ctx.Request = ctx.Request.WithContext(utils.WithRequestMeta(ctx.Request.Context(), meta))
+		ctx.Next()
+	}
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS user_availabilities;
+DROP TABLE IF EXISTS users;
+DROP TABLE IF EXISTS time_slots;
+DROP TABLE IF EXISTS events;
@@ -0,0 +1,51 @@
+-- Core scheduling tables. IF NOT EXISTS lets databases previously managed by
+-- gorm AutoMigrate adopt versioned migrations without changes.
+CREATE TABLE IF NOT EXISTS events (
+    id               BIGSERIAL PRIMARY KEY,
+    created_at       TIMESTAMPTZ,
+    updated_at       TIMESTAMPTZ,
+    deleted_at       TIMESTAMPTZ,
+    title            TEXT,
+    description      TEXT,
+    organizer_id     BIGINT,
+    duration_minutes BIGINT
+);
+CREATE INDEX IF NOT EXISTS idx_events_deleted_at ON events (deleted_at);
+
+CREATE TABLE IF NOT EXISTS time_slots (
+    id         BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ,
+    event_id   BIGINT,
+    start_time TIMESTAMPTZ,
+    end_time   TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_time_slots_deleted_at ON time_slots (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_time_slots_event_id ON time_slots (event_id);
+
+CREATE TABLE IF NOT EXISTS users (
+    id         BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ,
+    name       TEXT,
+    email      TEXT,
+    timezone   TEXT
+);
+CREATE INDEX IF NOT EXISTS idx_users_deleted_at ON users (deleted_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email ON users (email);
+
+CREATE TABLE IF NOT EXISTS user_availabilities (
+    id         BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ,
+    user_id    BIGINT,
+    event_id   BIGINT,
+    start_time TIMESTAMPTZ,
+    end_time   TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_user_availabilities_deleted_at ON user_availabilities (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_user_availabilities_user_id ON user_availabilities (user_id);
+CREATE INDEX IF NOT EXISTS idx_user_availabilities_event_id ON user_availabilities (event_id);
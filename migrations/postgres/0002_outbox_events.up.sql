@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id              BIGSERIAL PRIMARY KEY,
+    created_at      TIMESTAMPTZ,
+    event_type      TEXT NOT NULL,
+    aggregate_type  TEXT NOT NULL,
+    aggregate_id    BIGINT,
+    payload         TEXT,
+    status          TEXT NOT NULL DEFAULT 'pending',
+    attempts        BIGINT,
+    last_error      TEXT,
+    next_attempt_at TIMESTAMPTZ,
+    locked_until    TIMESTAMPTZ,
+    dispatched_at   TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_outbox_aggregate ON outbox_events (aggregate_type, aggregate_id);
+CREATE INDEX IF NOT EXISTS idx_outbox_pending ON outbox_events (status, next_attempt_at);
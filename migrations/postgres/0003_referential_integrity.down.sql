@@ -0,0 +1,14 @@
+ALTER TABLE user_availabilities
+    DROP CONSTRAINT IF EXISTS chk_user_availabilities_range,
+    DROP CONSTRAINT IF EXISTS fk_user_availabilities_event,
+    DROP CONSTRAINT IF EXISTS fk_user_availabilities_user,
+    ALTER COLUMN event_id DROP NOT NULL,
+    ALTER COLUMN user_id DROP NOT NULL;
+
+ALTER TABLE time_slots
+    DROP CONSTRAINT IF EXISTS chk_time_slots_range,
+    DROP CONSTRAINT IF EXISTS fk_time_slots_event,
+    ALTER COLUMN event_id DROP NOT NULL;
+
+ALTER TABLE events
+    DROP CONSTRAINT IF EXISTS chk_events_duration;
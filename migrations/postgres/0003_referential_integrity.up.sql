@@ -0,0 +1,34 @@
+-- Children of soft-deleted parents are soft-deleted with the parent's timestamp,
+-- matching what the services now do on delete.
+UPDATE time_slots ts SET deleted_at = e.deleted_at
+FROM events e
+WHERE ts.event_id = e.id AND e.deleted_at IS NOT NULL AND ts.deleted_at IS NULL;
+
+UPDATE user_availabilities ua SET deleted_at = e.deleted_at
+FROM events e
+WHERE ua.event_id = e.id AND e.deleted_at IS NOT NULL AND ua.deleted_at IS NULL;
+
+UPDATE user_availabilities ua SET deleted_at = u.deleted_at
+FROM users u
+WHERE ua.user_id = u.id AND u.deleted_at IS NOT NULL AND ua.deleted_at IS NULL;
+
+-- Rows whose parent no longer exists at all cannot satisfy the foreign keys.
+DELETE FROM time_slots WHERE event_id IS NULL OR event_id NOT IN (SELECT id FROM events);
+DELETE FROM user_availabilities
+WHERE event_id IS NULL OR event_id NOT IN (SELECT id FROM events)
+   OR user_id IS NULL OR user_id NOT IN (SELECT id FROM users);
+
+ALTER TABLE events
+    ADD CONSTRAINT chk_events_duration CHECK (duration_minutes > 0);
+
+ALTER TABLE time_slots
+    ALTER COLUMN event_id SET NOT NULL,
+    ADD CONSTRAINT fk_time_slots_event FOREIGN KEY (event_id) REFERENCES events (id) ON DELETE CASCADE,
+    ADD CONSTRAINT chk_time_slots_range CHECK (start_time < end_time);
+
+ALTER TABLE user_availabilities
+    ALTER COLUMN user_id SET NOT NULL,
+    ALTER COLUMN event_id SET NOT NULL,
+    ADD CONSTRAINT fk_user_availabilities_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    ADD CONSTRAINT fk_user_availabilities_event FOREIGN KEY (event_id) REFERENCES events (id) ON DELETE CASCADE,
+    ADD CONSTRAINT chk_user_availabilities_range CHECK (start_time < end_time);
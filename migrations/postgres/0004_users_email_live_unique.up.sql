@@ -0,0 +1,3 @@
+-- Soft-deleted users no longer block re-registering their email address.
+DROP INDEX IF EXISTS idx_users_email;
+CREATE UNIQUE INDEX idx_users_email ON users (email) WHERE deleted_at IS NULL;
@@ -0,0 +1,3 @@
+ALTER TABLE user_availabilities DROP COLUMN source;
+DROP TABLE availability_exceptions;
+DROP TABLE availability_rules;
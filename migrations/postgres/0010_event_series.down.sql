@@ -0,0 +1,2 @@
+DROP TABLE series_exceptions;
+DROP TABLE event_series;
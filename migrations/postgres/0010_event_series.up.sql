@@ -0,0 +1,31 @@
+CREATE TABLE event_series (
+    id               BIGSERIAL PRIMARY KEY,
+    created_at       TIMESTAMPTZ,
+    updated_at       TIMESTAMPTZ,
+    deleted_at       TIMESTAMPTZ,
+    title            TEXT NOT NULL,
+    description      TEXT,
+    organizer_id     BIGINT NOT NULL,
+    duration_minutes INTEGER NOT NULL CHECK (duration_minutes > 0),
+    start_time       TIMESTAMPTZ NOT NULL,
+    timezone         TEXT NOT NULL,
+    rrule            TEXT NOT NULL,
+    participant_ids  TEXT
+);
+CREATE INDEX idx_event_series_deleted_at ON event_series (deleted_at);
+
+-- Moved or cancelled occurrences, keyed by their start under the rule.
+CREATE TABLE series_exceptions (
+    id             BIGSERIAL PRIMARY KEY,
+    created_at     TIMESTAMPTZ,
+    updated_at     TIMESTAMPTZ,
+    deleted_at     TIMESTAMPTZ,
+    series_id      BIGINT NOT NULL REFERENCES event_series (id) ON DELETE CASCADE,
+    original_start TIMESTAMPTZ NOT NULL,
+    cancelled      BOOLEAN NOT NULL DEFAULT FALSE,
+    start_time     TIMESTAMPTZ,
+    CONSTRAINT chk_series_exceptions_change CHECK (cancelled OR start_time IS NOT NULL)
+);
+CREATE INDEX idx_series_exceptions_deleted_at ON series_exceptions (deleted_at);
+CREATE INDEX idx_series_exceptions_series_id ON series_exceptions (series_id);
+CREATE UNIQUE INDEX idx_series_exceptions_occurrence ON series_exceptions (series_id, original_start) WHERE deleted_at IS NULL;
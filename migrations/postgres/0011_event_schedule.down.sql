@@ -0,0 +1,3 @@
+DROP INDEX idx_events_scheduled;
+ALTER TABLE events DROP COLUMN scheduled_end;
+ALTER TABLE events DROP COLUMN scheduled_start;
@@ -0,0 +1,20 @@
+CREATE TABLE event_participants (
+    id         BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ,
+    event_id   BIGINT NOT NULL REFERENCES events (id) ON DELETE CASCADE,
+    user_id    BIGINT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    status     TEXT NOT NULL DEFAULT 'invited' CHECK (status IN ('invited', 'responded', 'declined'))
+);
+CREATE INDEX idx_event_participants_deleted_at ON event_participants (deleted_at);
+CREATE INDEX idx_event_participants_event_id ON event_participants (event_id);
+CREATE INDEX idx_event_participants_user_id ON event_participants (user_id);
+CREATE UNIQUE INDEX idx_event_participants_event_user ON event_participants (event_id, user_id) WHERE deleted_at IS NULL;
+
+-- Everyone who already submitted availability has responded to the event.
+INSERT INTO event_participants (created_at, updated_at, event_id, user_id, status)
+SELECT MIN(created_at), MIN(created_at), event_id, user_id, 'responded'
+FROM user_availabilities
+WHERE deleted_at IS NULL
+GROUP BY event_id, user_id;
@@ -0,0 +1,4 @@
+ALTER TABLE events DROP COLUMN auto_reschedule;
+ALTER TABLE event_participants DROP COLUMN rsvp_at;
+ALTER TABLE event_participants DROP COLUMN rsvp;
+ALTER TABLE event_participants DROP COLUMN required;
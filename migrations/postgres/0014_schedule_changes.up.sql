@@ -0,0 +1,10 @@
+-- Times an event was scheduled for before it was reopened for polling.
+CREATE TABLE schedule_changes (
+    id              BIGSERIAL PRIMARY KEY,
+    created_at      TIMESTAMPTZ,
+    event_id        BIGINT NOT NULL REFERENCES events (id) ON DELETE CASCADE,
+    scheduled_start TIMESTAMPTZ NOT NULL,
+    scheduled_end   TIMESTAMPTZ NOT NULL,
+    reason          TEXT
+);
+CREATE INDEX idx_schedule_changes_event_id ON schedule_changes (event_id);
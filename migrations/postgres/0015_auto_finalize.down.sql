@@ -0,0 +1,6 @@
+DROP INDEX idx_events_response_deadline;
+ALTER TABLE events DROP COLUMN auto_finalize_decided_at;
+ALTER TABLE events DROP COLUMN auto_finalize_rationale;
+ALTER TABLE events DROP COLUMN auto_finalize_outcome;
+ALTER TABLE events DROP COLUMN auto_finalize;
+ALTER TABLE events DROP COLUMN response_deadline;
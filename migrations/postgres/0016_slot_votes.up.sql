@@ -0,0 +1,19 @@
+-- Yes/maybe/no votes on an event's time slots.
+CREATE TABLE slot_votes (
+    id           BIGSERIAL PRIMARY KEY,
+    created_at   TIMESTAMPTZ,
+    updated_at   TIMESTAMPTZ,
+    deleted_at   TIMESTAMPTZ,
+    event_id     BIGINT NOT NULL REFERENCES events (id) ON DELETE CASCADE,
+    time_slot_id BIGINT NOT NULL REFERENCES time_slots (id) ON DELETE CASCADE,
+    user_id      BIGINT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    vote         TEXT NOT NULL CHECK (vote IN ('yes', 'maybe', 'no'))
+);
+CREATE INDEX idx_slot_votes_deleted_at ON slot_votes (deleted_at);
+CREATE INDEX idx_slot_votes_event_id ON slot_votes (event_id);
+CREATE INDEX idx_slot_votes_time_slot_id ON slot_votes (time_slot_id);
+CREATE INDEX idx_slot_votes_user_id ON slot_votes (user_id);
+CREATE UNIQUE INDEX idx_slot_votes_slot_user ON slot_votes (time_slot_id, user_id) WHERE deleted_at IS NULL;
+
+ALTER TABLE events ADD COLUMN recommendation_strategy TEXT NOT NULL DEFAULT 'availability'
+    CHECK (recommendation_strategy IN ('availability', 'votes'));
@@ -0,0 +1,31 @@
+-- Rooms and equipment, the bookings blocking them and events' resource needs.
+CREATE TABLE resources (
+    id         BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ,
+    name       TEXT NOT NULL,
+    kind       TEXT NOT NULL DEFAULT 'room' CHECK (kind IN ('room', 'equipment')),
+    capacity   INTEGER NOT NULL DEFAULT 0 CHECK (capacity >= 0)
+);
+CREATE INDEX idx_resources_deleted_at ON resources (deleted_at);
+
+CREATE TABLE resource_bookings (
+    id          BIGSERIAL PRIMARY KEY,
+    created_at  TIMESTAMPTZ,
+    updated_at  TIMESTAMPTZ,
+    deleted_at  TIMESTAMPTZ,
+    resource_id BIGINT NOT NULL REFERENCES resources (id) ON DELETE CASCADE,
+    event_id    BIGINT REFERENCES events (id) ON DELETE CASCADE,
+    start_time  TIMESTAMPTZ NOT NULL,
+    end_time    TIMESTAMPTZ NOT NULL,
+    note        TEXT,
+    CHECK (start_time < end_time)
+);
+CREATE INDEX idx_resource_bookings_deleted_at ON resource_bookings (deleted_at);
+CREATE INDEX idx_resource_bookings_resource_id ON resource_bookings (resource_id);
+CREATE INDEX idx_resource_bookings_event_id ON resource_bookings (event_id);
+
+ALTER TABLE events ADD COLUMN resource_id BIGINT REFERENCES resources (id) ON DELETE SET NULL;
+ALTER TABLE events ADD COLUMN min_capacity INTEGER NOT NULL DEFAULT 0 CHECK (min_capacity >= 0);
+ALTER TABLE events ADD COLUMN reserved_resource_id BIGINT REFERENCES resources (id) ON DELETE SET NULL;
@@ -0,0 +1,4 @@
+-- Per-user buffers around meetings and a cap on meeting time per day.
+ALTER TABLE users ADD COLUMN buffer_before_minutes INTEGER NOT NULL DEFAULT 0 CHECK (buffer_before_minutes >= 0);
+ALTER TABLE users ADD COLUMN buffer_after_minutes INTEGER NOT NULL DEFAULT 0 CHECK (buffer_after_minutes >= 0);
+ALTER TABLE users ADD COLUMN max_daily_meeting_minutes INTEGER NOT NULL DEFAULT 0 CHECK (max_daily_meeting_minutes >= 0);
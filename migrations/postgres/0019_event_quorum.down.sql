@@ -0,0 +1,3 @@
+ALTER TABLE events DROP COLUMN quorum_all_required;
+ALTER TABLE events DROP COLUMN quorum_percentage;
+ALTER TABLE events DROP COLUMN quorum;
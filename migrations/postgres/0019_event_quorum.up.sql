@@ -0,0 +1,4 @@
+-- How many invitees an event needs to go ahead.
+ALTER TABLE events ADD COLUMN quorum INTEGER NOT NULL DEFAULT 0 CHECK (quorum >= 0);
+ALTER TABLE events ADD COLUMN quorum_percentage INTEGER NOT NULL DEFAULT 0 CHECK (quorum_percentage BETWEEN 0 AND 100);
+ALTER TABLE events ADD COLUMN quorum_all_required BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,8 @@
+ALTER TABLE event_series ADD COLUMN participant_ids TEXT;
+UPDATE event_series SET participant_ids = (
+    SELECT json_agg(user_id ORDER BY user_id)::TEXT
+    FROM series_participants
+    WHERE series_id = event_series.id
+)
+WHERE EXISTS (SELECT 1 FROM series_participants WHERE series_id = event_series.id);
+DROP TABLE series_participants;
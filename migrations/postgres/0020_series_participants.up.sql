@@ -0,0 +1,14 @@
+-- Series participants move out of the JSON column so they can be queried by user.
+CREATE TABLE series_participants (
+    series_id BIGINT NOT NULL REFERENCES event_series (id) ON DELETE CASCADE,
+    user_id   BIGINT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    PRIMARY KEY (series_id, user_id)
+);
+CREATE INDEX idx_series_participants_user_id ON series_participants (user_id);
+
+INSERT INTO series_participants (series_id, user_id)
+SELECT DISTINCT event_series.id, participant.value::BIGINT
+FROM event_series, json_array_elements_text(event_series.participant_ids::json) AS participant (value)
+WHERE event_series.participant_ids IS NOT NULL AND event_series.participant_ids NOT IN ('', 'null');
+
+ALTER TABLE event_series DROP COLUMN participant_ids;
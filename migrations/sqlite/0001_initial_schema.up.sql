@@ -0,0 +1,49 @@
+CREATE TABLE IF NOT EXISTS events (
+    id               INTEGER PRIMARY KEY AUTOINCREMENT,
+    created_at       DATETIME,
+    updated_at       DATETIME,
+    deleted_at       DATETIME,
+    title            TEXT,
+    description      TEXT,
+    organizer_id     INTEGER,
+    duration_minutes INTEGER
+);
+CREATE INDEX IF NOT EXISTS idx_events_deleted_at ON events (deleted_at);
+
+CREATE TABLE IF NOT EXISTS time_slots (
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    created_at DATETIME,
+    updated_at DATETIME,
+    deleted_at DATETIME,
+    event_id   INTEGER,
+    start_time DATETIME,
+    end_time   DATETIME
+);
+CREATE INDEX IF NOT EXISTS idx_time_slots_deleted_at ON time_slots (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_time_slots_event_id ON time_slots (event_id);
+
+CREATE TABLE IF NOT EXISTS users (
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    created_at DATETIME,
+    updated_at DATETIME,
+    deleted_at DATETIME,
+    name       TEXT,
+    email      TEXT,
+    timezone   TEXT
+);
+CREATE INDEX IF NOT EXISTS idx_users_deleted_at ON users (deleted_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email ON users (email);
+
+CREATE TABLE IF NOT EXISTS user_availabilities (
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    created_at DATETIME,
+    updated_at DATETIME,
+    deleted_at DATETIME,
+    user_id    INTEGER,
+    event_id   INTEGER,
+    start_time DATETIME,
+    end_time   DATETIME
+);
+CREATE INDEX IF NOT EXISTS idx_user_availabilities_deleted_at ON user_availabilities (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_user_availabilities_user_id ON user_availabilities (user_id);
+CREATE INDEX IF NOT EXISTS idx_user_availabilities_event_id ON user_availabilities (event_id);
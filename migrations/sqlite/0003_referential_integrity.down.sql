@@ -0,0 +1,50 @@
+-- Children are rebuilt without foreign keys first, so dropping events cannot cascade.
+CREATE TABLE user_availabilities_old (
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    created_at DATETIME,
+    updated_at DATETIME,
+    deleted_at DATETIME,
+    user_id    INTEGER,
+    event_id   INTEGER,
+    start_time DATETIME,
+    end_time   DATETIME
+);
+INSERT INTO user_availabilities_old (id, created_at, updated_at, deleted_at, user_id, event_id, start_time, end_time)
+SELECT id, created_at, updated_at, deleted_at, user_id, event_id, start_time, end_time FROM user_availabilities;
+DROP TABLE user_availabilities;
+ALTER TABLE user_availabilities_old RENAME TO user_availabilities;
+CREATE INDEX idx_user_availabilities_deleted_at ON user_availabilities (deleted_at);
+CREATE INDEX idx_user_availabilities_user_id ON user_availabilities (user_id);
+CREATE INDEX idx_user_availabilities_event_id ON user_availabilities (event_id);
+
+CREATE TABLE time_slots_old (
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    created_at DATETIME,
+    updated_at DATETIME,
+    deleted_at DATETIME,
+    event_id   INTEGER,
+    start_time DATETIME,
+    end_time   DATETIME
+);
+INSERT INTO time_slots_old (id, created_at, updated_at, deleted_at, event_id, start_time, end_time)
+SELECT id, created_at, updated_at, deleted_at, event_id, start_time, end_time FROM time_slots;
+DROP TABLE time_slots;
+ALTER TABLE time_slots_old RENAME TO time_slots;
+CREATE INDEX idx_time_slots_deleted_at ON time_slots (deleted_at);
+CREATE INDEX idx_time_slots_event_id ON time_slots (event_id);
+
+CREATE TABLE events_old (
+    id               INTEGER PRIMARY KEY AUTOINCREMENT,
+    created_at       DATETIME,
+    updated_at       DATETIME,
+    deleted_at       DATETIME,
+    title            TEXT,
+    description      TEXT,
+    organizer_id     INTEGER,
+    duration_minutes INTEGER
+);
+INSERT INTO events_old (id, created_at, updated_at, deleted_at, title, description, organizer_id, duration_minutes)
+SELECT id, created_at, updated_at, deleted_at, title, description, organizer_id, duration_minutes FROM events;
+DROP TABLE events;
+ALTER TABLE events_old RENAME TO events;
+CREATE INDEX idx_events_deleted_at ON events (deleted_at);
@@ -0,0 +1,72 @@
+-- SQLite cannot add constraints to existing tables, so each table is rebuilt.
+-- Foreign keys are only enforced on connections opened with _foreign_keys=on.
+UPDATE time_slots SET deleted_at = (SELECT e.deleted_at FROM events e WHERE e.id = time_slots.event_id)
+WHERE deleted_at IS NULL AND event_id IN (SELECT id FROM events WHERE deleted_at IS NOT NULL);
+
+UPDATE user_availabilities SET deleted_at = (SELECT e.deleted_at FROM events e WHERE e.id = user_availabilities.event_id)
+WHERE deleted_at IS NULL AND event_id IN (SELECT id FROM events WHERE deleted_at IS NOT NULL);
+
+UPDATE user_availabilities SET deleted_at = (SELECT u.deleted_at FROM users u WHERE u.id = user_availabilities.user_id)
+WHERE deleted_at IS NULL AND user_id IN (SELECT id FROM users WHERE deleted_at IS NOT NULL);
+
+DELETE FROM time_slots WHERE event_id IS NULL OR event_id NOT IN (SELECT id FROM events);
+DELETE FROM user_availabilities
+WHERE event_id IS NULL OR event_id NOT IN (SELECT id FROM events)
+   OR user_id IS NULL OR user_id NOT IN (SELECT id FROM users);
+
+-- events is rebuilt first, while nothing references it yet
+CREATE TABLE events_new (
+    id               INTEGER PRIMARY KEY AUTOINCREMENT,
+    created_at       DATETIME,
+    updated_at       DATETIME,
+    deleted_at       DATETIME,
+    title            TEXT,
+    description      TEXT,
+    organizer_id     INTEGER,
+    duration_minutes INTEGER,
+    CONSTRAINT chk_events_duration CHECK (duration_minutes > 0)
+);
+INSERT INTO events_new (id, created_at, updated_at, deleted_at, title, description, organizer_id, duration_minutes)
+SELECT id, created_at, updated_at, deleted_at, title, description, organizer_id, duration_minutes FROM events;
+DROP TABLE events;
+ALTER TABLE events_new RENAME TO events;
+CREATE INDEX idx_events_deleted_at ON events (deleted_at);
+
+CREATE TABLE time_slots_new (
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    created_at DATETIME,
+    updated_at DATETIME,
+    deleted_at DATETIME,
+    event_id   INTEGER NOT NULL,
+    start_time DATETIME,
+    end_time   DATETIME,
+    CONSTRAINT fk_time_slots_event FOREIGN KEY (event_id) REFERENCES events (id) ON DELETE CASCADE,
+    CONSTRAINT chk_time_slots_range CHECK (start_time < end_time)
+);
+INSERT INTO time_slots_new (id, created_at, updated_at, deleted_at, event_id, start_time, end_time)
+SELECT id, created_at, updated_at, deleted_at, event_id, start_time, end_time FROM time_slots;
+DROP TABLE time_slots;
+ALTER TABLE time_slots_new RENAME TO time_slots;
+CREATE INDEX idx_time_slots_deleted_at ON time_slots (deleted_at);
+CREATE INDEX idx_time_slots_event_id ON time_slots (event_id);
+
+CREATE TABLE user_availabilities_new (
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    created_at DATETIME,
+    updated_at DATETIME,
+    deleted_at DATETIME,
+    user_id    INTEGER NOT NULL,
+    event_id   INTEGER NOT NULL,
+    start_time DATETIME,
+    end_time   DATETIME,
+    CONSTRAINT fk_user_availabilities_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    CONSTRAINT fk_user_availabilities_event FOREIGN KEY (event_id) REFERENCES events (id) ON DELETE CASCADE,
+    CONSTRAINT chk_user_availabilities_range CHECK (start_time < end_time)
+);
+INSERT INTO user_availabilities_new (id, created_at, updated_at, deleted_at, user_id, event_id, start_time, end_time)
+SELECT id, created_at, updated_at, deleted_at, user_id, event_id, start_time, end_time FROM user_availabilities;
+DROP TABLE user_availabilities;
+ALTER TABLE user_availabilities_new RENAME TO user_availabilities;
+CREATE INDEX idx_user_availabilities_deleted_at ON user_availabilities (deleted_at);
+CREATE INDEX idx_user_availabilities_user_id ON user_availabilities (user_id);
+CREATE INDEX idx_user_availabilities_event_id ON user_availabilities (event_id);
@@ -0,0 +1,4 @@
+ALTER TABLE user_availabilities DROP COLUMN version;
+ALTER TABLE users DROP COLUMN version;
+ALTER TABLE time_slots DROP COLUMN version;
+ALTER TABLE events DROP COLUMN version;
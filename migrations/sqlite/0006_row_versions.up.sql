@@ -0,0 +1,5 @@
+-- Row versions for optimistic concurrency control; every update bumps the version.
+ALTER TABLE events ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE time_slots ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE users ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE user_availabilities ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    id              INTEGER PRIMARY KEY AUTOINCREMENT,
+    created_at      DATETIME,
+    actor           TEXT NOT NULL,
+    idempotency_key TEXT NOT NULL,
+    request_hash    TEXT NOT NULL,
+    status_code     INTEGER,
+    content_type    TEXT,
+    response_body   TEXT,
+    expires_at      DATETIME
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_idempotency_keys_actor_key ON idempotency_keys (actor, idempotency_key);
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
@@ -0,0 +1,3 @@
+ALTER TABLE events ADD COLUMN status TEXT NOT NULL DEFAULT 'polling'
+    CHECK (status IN ('polling', 'scheduled', 'cancelled'));
+CREATE INDEX IF NOT EXISTS idx_events_status ON events (status);
@@ -0,0 +1,29 @@
+CREATE TABLE availability_rules (
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    created_at DATETIME,
+    updated_at DATETIME,
+    deleted_at DATETIME,
+    user_id    INTEGER NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    weekday    INTEGER NOT NULL CHECK (weekday BETWEEN 0 AND 6),
+    start_time TEXT NOT NULL,
+    end_time   TEXT NOT NULL,
+    CONSTRAINT chk_availability_rules_range CHECK (start_time < end_time)
+);
+CREATE INDEX idx_availability_rules_deleted_at ON availability_rules (deleted_at);
+CREATE INDEX idx_availability_rules_user_id ON availability_rules (user_id);
+
+CREATE TABLE availability_exceptions (
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    created_at DATETIME,
+    updated_at DATETIME,
+    deleted_at DATETIME,
+    user_id    INTEGER NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    date       TEXT NOT NULL,
+    start_time TEXT,
+    end_time   TEXT
+);
+CREATE INDEX idx_availability_exceptions_deleted_at ON availability_exceptions (deleted_at);
+CREATE INDEX idx_availability_exceptions_user_id ON availability_exceptions (user_id);
+
+-- Availability is either entered by hand or materialized from the rules above.
+ALTER TABLE user_availabilities ADD COLUMN source TEXT NOT NULL DEFAULT 'manual' CHECK (source IN ('manual', 'rule'));
@@ -0,0 +1,4 @@
+-- The time a finalized event was scheduled for.
+ALTER TABLE events ADD COLUMN scheduled_start DATETIME;
+ALTER TABLE events ADD COLUMN scheduled_end DATETIME;
+CREATE INDEX idx_events_scheduled ON events (scheduled_start, scheduled_end);
@@ -0,0 +1,8 @@
+-- RSVPs to a scheduled event's confirmed time.
+ALTER TABLE event_participants ADD COLUMN required NUMERIC NOT NULL DEFAULT 0;
+ALTER TABLE event_participants ADD COLUMN rsvp TEXT NOT NULL DEFAULT 'pending'
+    CHECK (rsvp IN ('pending', 'accepted', 'tentative', 'declined'));
+ALTER TABLE event_participants ADD COLUMN rsvp_at DATETIME;
+
+-- Reopen a scheduled event for polling when a required participant declines it.
+ALTER TABLE events ADD COLUMN auto_reschedule NUMERIC NOT NULL DEFAULT 0;
@@ -0,0 +1 @@
+DROP TABLE schedule_changes;
@@ -0,0 +1,10 @@
+-- Times an event was scheduled for before it was reopened for polling.
+CREATE TABLE schedule_changes (
+    id              INTEGER PRIMARY KEY AUTOINCREMENT,
+    created_at      DATETIME,
+    event_id        INTEGER NOT NULL REFERENCES events (id) ON DELETE CASCADE,
+    scheduled_start DATETIME NOT NULL,
+    scheduled_end   DATETIME NOT NULL,
+    reason          TEXT
+);
+CREATE INDEX idx_schedule_changes_event_id ON schedule_changes (event_id);
@@ -0,0 +1,8 @@
+-- Response deadline and automatic finalization policy, with the worker's decision.
+ALTER TABLE events ADD COLUMN response_deadline DATETIME;
+ALTER TABLE events ADD COLUMN auto_finalize TEXT NOT NULL DEFAULT 'off'
+    CHECK (auto_finalize IN ('off', 'deadline', 'responded'));
+ALTER TABLE events ADD COLUMN auto_finalize_outcome TEXT;
+ALTER TABLE events ADD COLUMN auto_finalize_rationale TEXT;
+ALTER TABLE events ADD COLUMN auto_finalize_decided_at DATETIME;
+CREATE INDEX idx_events_response_deadline ON events (response_deadline);
@@ -0,0 +1,2 @@
+ALTER TABLE events DROP COLUMN recommendation_strategy;
+DROP TABLE slot_votes;
@@ -0,0 +1,5 @@
+ALTER TABLE events DROP COLUMN reserved_resource_id;
+ALTER TABLE events DROP COLUMN min_capacity;
+ALTER TABLE events DROP COLUMN resource_id;
+DROP TABLE resource_bookings;
+DROP TABLE resources;
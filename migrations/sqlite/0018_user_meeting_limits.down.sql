@@ -0,0 +1,3 @@
+ALTER TABLE users DROP COLUMN max_daily_meeting_minutes;
+ALTER TABLE users DROP COLUMN buffer_after_minutes;
+ALTER TABLE users DROP COLUMN buffer_before_minutes;
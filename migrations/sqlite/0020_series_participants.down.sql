@@ -0,0 +1,7 @@
+ALTER TABLE event_series ADD COLUMN participant_ids TEXT;
+UPDATE event_series SET participant_ids = (
+    SELECT json_group_array(user_id)
+    FROM (SELECT user_id FROM series_participants WHERE series_id = event_series.id ORDER BY user_id)
+)
+WHERE EXISTS (SELECT 1 FROM series_participants WHERE series_id = event_series.id);
+DROP TABLE series_participants;
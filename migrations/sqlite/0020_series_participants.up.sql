@@ -0,0 +1,14 @@
+-- Series participants move out of the JSON column so they can be queried by user.
+CREATE TABLE series_participants (
+    series_id INTEGER NOT NULL REFERENCES event_series (id) ON DELETE CASCADE,
+    user_id   INTEGER NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    PRIMARY KEY (series_id, user_id)
+);
+CREATE INDEX idx_series_participants_user_id ON series_participants (user_id);
+
+INSERT INTO series_participants (series_id, user_id)
+SELECT DISTINCT event_series.id, participant.value
+FROM event_series, json_each(event_series.participant_ids) AS participant
+WHERE json_valid(event_series.participant_ids);
+
+ALTER TABLE event_series DROP COLUMN participant_ids;
@@ -6,47 +6,492 @@ import (
 	"gorm.io/gorm"
 )
 
-// Event represents a meeting or event
+// Event statuses
+const (
+	EventStatusPolling   = "polling"
+	EventStatusScheduled = "scheduled"
+	EventStatusCancelled = "cancelled"
+)
+
+// Auto-finalize policies. With AutoFinalizeAtDeadline the top recommendation is
+// picked once the response deadline passes; AutoFinalizeWhenResponded picks it as
+// soon as every required participant has responded, or at the deadline.
+const (
+	AutoFinalizeOff           = "off"
+	AutoFinalizeAtDeadline    = "deadline"
+	AutoFinalizeWhenResponded = "responded"
+)
+
+// Auto-finalize outcomes
+const (
+	AutoFinalizeOutcomeFinalized = "finalized"
+	AutoFinalizeOutcomeNoOption  = "no_viable_option"
+)
+
+// Recommendation strategies. Availability matches free-form availability windows
+// against start times; votes tallies participants' yes/maybe/no votes per slot.
+const (
+	StrategyAvailability = "availability"
+	StrategyVotes        = "votes"
+)
+
+// Event represents a meeting or event. ScheduledStart and ScheduledEnd are set
+// when the event is finalized. With AutoReschedule, a required participant
+// declining the scheduled time puts the event back into polling. Participants
+// and ScheduleHistory are only filled in on event detail responses.
+//
+// When AutoFinalize is set, a background worker finalizes the event and records
+// the outcome and its rationale. An event with an outcome is not considered
+// again until its deadline or policy changes.
+//
+// An event needs a resource when it names one in ResourceID or asks for any room
+// of at least MinCapacity seats; finalizing books one and sets ReservedResourceID.
+//
+// Quorum is the least number of invitees who must be able to attend and
+// QuorumPercentage the least share of the invitees who have not declined; only
+// one of them can be set. With QuorumAllRequired every required participant
+// must be able to attend as well. Finalizing below quorum needs an override.
 type Event struct {
 	gorm.Model
-	Title           string     `json:"title" binding:"required"`
-	Description     string     `json:"description,omitempty"`
-	OrganizerId     uint       `json:"organizer_id" binding:"required"`
-	DurationMinutes int        `json:"duration_minutes" binding:"required,min=1"`
-	TimeSlots       []TimeSlot `json:"time_slots,omitempty" gorm:"foreignKey:EventID"`
+	Title                  string             `json:"title" binding:"required"`
+	Description            string             `json:"description,omitempty"`
+	OrganizerId            uint               `json:"organizer_id" binding:"required"`
+	DurationMinutes        int                `json:"duration_minutes" binding:"required,min=1"`
+	Status                 string             `json:"status" gorm:"not null;default:polling;index"`
+	ScheduledStart         *time.Time         `json:"scheduled_start,omitempty"`
+	ScheduledEnd           *time.Time         `json:"scheduled_end,omitempty"`
+	AutoReschedule         bool               `json:"auto_reschedule" gorm:"not null;default:false"`
+	ResponseDeadline       *time.Time         `json:"response_deadline,omitempty"`
+	AutoFinalize           string             `json:"auto_finalize" gorm:"not null;default:off"`
+	AutoFinalizeOutcome    string             `json:"auto_finalize_outcome,omitempty"`
+	AutoFinalizeRationale  string             `json:"auto_finalize_rationale,omitempty"`
+	AutoFinalizeDecidedAt  *time.Time         `json:"auto_finalize_decided_at,omitempty"`
+	RecommendationStrategy string             `json:"recommendation_strategy" gorm:"not null;default:availability"`
+	ResourceID             *uint              `json:"resource_id,omitempty"`
+	MinCapacity            int                `json:"min_capacity,omitempty" gorm:"not null;default:0"`
+	ReservedResourceID     *uint              `json:"reserved_resource_id,omitempty"`
+	Quorum                 int                `json:"quorum,omitempty" gorm:"not null;default:0"`
+	QuorumPercentage       int                `json:"quorum_percentage,omitempty" gorm:"not null;default:0"`
+	QuorumAllRequired      bool               `json:"quorum_all_required,omitempty" gorm:"not null;default:false"`
+	TimeSlots              []TimeSlot         `json:"time_slots,omitempty" gorm:"foreignKey:EventID;constraint:OnDelete:CASCADE"`
+	Participants           []EventParticipant `json:"participants,omitempty" gorm:"-"`
+	ScheduleHistory        []ScheduleChange   `json:"schedule_history,omitempty" gorm:"-"`
+	Version                uint               `json:"version" gorm:"not null;default:1"`
+}
+
+// Resource kinds
+const (
+	ResourceKindRoom      = "room"
+	ResourceKindEquipment = "equipment"
+)
+
+// Resource is a room or a piece of equipment an event can need. Capacity is the
+// number of seats a room has.
+type Resource struct {
+	gorm.Model
+	Name     string `json:"name" binding:"required"`
+	Kind     string `json:"kind" gorm:"not null;default:room"`
+	Capacity int    `json:"capacity" gorm:"not null;default:0"`
+}
+
+// ResourceBooking blocks a resource for a time. Finalizing an event books its
+// resource with the event's ID; other bookings block it for outside reasons.
+type ResourceBooking struct {
+	gorm.Model
+	ResourceID uint      `json:"resource_id" gorm:"index;not null"`
+	EventID    *uint     `json:"event_id,omitempty" gorm:"index"`
+	StartTime  time.Time `json:"start_time" binding:"required"`
+	EndTime    time.Time `json:"end_time" binding:"required"`
+	Note       string    `json:"note,omitempty"`
+}
+
+// ScheduleChange records a time an event was scheduled for before it went back
+// into polling, and why
+type ScheduleChange struct {
+	ID             uint      `json:"id" gorm:"primaryKey"`
+	CreatedAt      time.Time `json:"created_at"`
+	EventID        uint      `json:"event_id" gorm:"index;not null"`
+	ScheduledStart time.Time `json:"scheduled_start" gorm:"not null"`
+	ScheduledEnd   time.Time `json:"scheduled_end" gorm:"not null"`
+	Reason         string    `json:"reason,omitempty"`
 }
 
 // TimeSlot represents a potential time for an event
 type TimeSlot struct {
 	gorm.Model
-	EventID   uint      `json:"event_id" gorm:"index;constraint:OnDelete:CASCADE"`
+	EventID   uint      `json:"event_id" gorm:"index;not null"`
 	StartTime time.Time `json:"start_time" binding:"required"`
 	EndTime   time.Time `json:"end_time" binding:"required"`
+	Version   uint      `json:"version" gorm:"not null;default:1"`
 }
 
 // User represents a user of the system
 type User struct {
 	gorm.Model
 	Name     string `json:"name" binding:"required"`
-	Email    string `json:"email" binding:"required,email" gorm:"uniqueIndex"`
+	Email    string `json:"email" binding:"required,email" gorm:"uniqueIndex:idx_users_email,where:deleted_at IS NULL"`
 	Timezone string `json:"timezone" binding:"required"`
+	// BufferBeforeMinutes and BufferAfterMinutes are kept free of other meetings
+	// before and after a meeting the recommender proposes to the user
+	BufferBeforeMinutes int `json:"buffer_before_minutes" gorm:"not null;default:0"`
+	BufferAfterMinutes  int `json:"buffer_after_minutes" gorm:"not null;default:0"`
+	// MaxDailyMeetingMinutes caps the user's meeting time per day in their
+	// timezone; 0 means no cap
+	MaxDailyMeetingMinutes int  `json:"max_daily_meeting_minutes" gorm:"not null;default:0"`
+	Version                uint `json:"version" gorm:"not null;default:1"`
 }
 
+// Availability sources
+const (
+	AvailabilitySourceManual = "manual"
+	AvailabilitySourceRule   = "rule"
+)
+
 // UserAvailability represents a user's availability for an event
 type UserAvailability struct {
 	gorm.Model
-	UserID    uint      `json:"user_id" gorm:"index"`
-	EventID   uint      `json:"event_id" gorm:"index"`
+	UserID    uint      `json:"user_id" gorm:"index;not null"`
+	EventID   uint      `json:"event_id" gorm:"index;not null"`
 	StartTime time.Time `json:"start_time" binding:"required"`
 	EndTime   time.Time `json:"end_time" binding:"required"`
+	// Source is manual for availability entered by the user and rule for
+	// availability materialized from their recurring rules
+	Source  string `json:"source" gorm:"not null;default:manual"`
+	Version uint   `json:"version" gorm:"not null;default:1"`
 }
 
-// TimeSlotRecommendation represents a recommended time slot with participant info
+// Participant statuses. An invited participant becomes responded once they
+// submit availability, and declined when they turn the invitation down.
+const (
+	ParticipantStatusInvited   = "invited"
+	ParticipantStatusResponded = "responded"
+	ParticipantStatusDeclined  = "declined"
+)
+
+// Votes on a proposed time slot
+const (
+	VoteYes   = "yes"
+	VoteMaybe = "maybe"
+	VoteNo    = "no"
+)
+
+// SlotVote is a participant's vote on one of an event's time slots
+type SlotVote struct {
+	gorm.Model
+	EventID    uint   `json:"event_id" gorm:"index;not null"`
+	TimeSlotID uint   `json:"time_slot_id" binding:"required" gorm:"index;not null"`
+	UserID     uint   `json:"user_id" gorm:"index;not null"`
+	Vote       string `json:"vote" binding:"required"`
+	User       *User  `json:"user,omitempty"`
+}
+
+// SlotVoteResult breaks down the votes on one time slot. Score counts a yes as
+// one and a maybe as half.
+type SlotVoteResult struct {
+	TimeSlot TimeSlot `json:"time_slot"`
+	Yes      []User   `json:"yes"`
+	Maybe    []User   `json:"maybe"`
+	No       []User   `json:"no"`
+	Score    float64  `json:"score"`
+}
+
+// VoteTally counts the votes on a time slot
+type VoteTally struct {
+	Yes   int `json:"yes"`
+	Maybe int `json:"maybe"`
+	No    int `json:"no"`
+}
+
+// RSVP responses to a scheduled event's confirmed time
+const (
+	RSVPPending   = "pending"
+	RSVPAccepted  = "accepted"
+	RSVPTentative = "tentative"
+	RSVPDeclined  = "declined"
+)
+
+// EventParticipant is a user invited to an event. RSVP is their answer to the
+// time the event was finalized for and starts over as pending on every finalize.
+type EventParticipant struct {
+	gorm.Model
+	EventID  uint       `json:"event_id" gorm:"index;not null"`
+	UserID   uint       `json:"user_id" binding:"required" gorm:"index;not null"`
+	Status   string     `json:"status" gorm:"not null;default:invited"`
+	Required bool       `json:"required" gorm:"not null;default:false"`
+	RSVP     string     `json:"rsvp" gorm:"column:rsvp;not null;default:pending"`
+	RSVPAt   *time.Time `json:"rsvp_at,omitempty" gorm:"column:rsvp_at"`
+	User     *User      `json:"user,omitempty"`
+}
+
+// AvailabilityRule is a weekly recurring availability window. Times are
+// "HH:MM" in the user's timezone; an end of "24:00" means midnight.
+type AvailabilityRule struct {
+	gorm.Model
+	UserID    uint   `json:"user_id" gorm:"index;not null"`
+	Weekday   int    `json:"weekday"`
+	StartTime string `json:"start_time" binding:"required"`
+	EndTime   string `json:"end_time" binding:"required"`
+}
+
+// AvailabilityException removes recurring availability on one local date,
+// either the whole day or only between StartTime and EndTime
+type AvailabilityException struct {
+	gorm.Model
+	UserID    uint   `json:"user_id" gorm:"index;not null"`
+	Date      string `json:"date" binding:"required"`
+	StartTime string `json:"start_time,omitempty"`
+	EndTime   string `json:"end_time,omitempty"`
+}
+
+// EventSeries is a recurring event. Its occurrences are not stored: they are
+// expanded on demand from an RFC 5545 RRULE, starting at StartTime and keeping
+// its wall-clock time in Timezone.
+type EventSeries struct {
+	gorm.Model
+	Title           string    `json:"title" binding:"required"`
+	Description     string    `json:"description,omitempty"`
+	OrganizerId     uint      `json:"organizer_id" binding:"required"`
+	DurationMinutes int       `json:"duration_minutes" binding:"required,min=1"`
+	StartTime       time.Time `json:"start_time" binding:"required"`
+	Timezone        string    `json:"timezone" binding:"required"`
+	RRule           string    `json:"rrule" binding:"required" gorm:"column:rrule;not null"`
+	ParticipantIDs  []uint    `json:"participant_ids,omitempty" gorm:"-"`
+}
+
+// SeriesParticipant links a user to an event series they take part in
+type SeriesParticipant struct {
+	SeriesID uint `gorm:"primaryKey"`
+	UserID   uint `gorm:"primaryKey;index"`
+}
+
+// Occurrence statuses
+const (
+	OccurrenceStatusScheduled = "scheduled"
+	OccurrenceStatusMoved     = "moved"
+	OccurrenceStatusCancelled = "cancelled"
+)
+
+// SeriesException moves or cancels one occurrence of a series, identified by
+// the start it has under the rule
+type SeriesException struct {
+	gorm.Model
+	SeriesID      uint       `json:"series_id" gorm:"index;not null"`
+	OriginalStart time.Time  `json:"original_start" binding:"required"`
+	Cancelled     bool       `json:"cancelled"`
+	StartTime     *time.Time `json:"start_time,omitempty"`
+}
+
+// Occurrence is one expanded instance of an event series
+type Occurrence struct {
+	SeriesID      uint      `json:"series_id"`
+	OriginalStart time.Time `json:"original_start"`
+	StartTime     time.Time `json:"start_time"`
+	EndTime       time.Time `json:"end_time"`
+	Status        string    `json:"status"`
+	ExceptionID   uint      `json:"exception_id,omitempty"`
+}
+
+// UserAvailabilityGroup is one user's availability for an event
+type UserAvailabilityGroup struct {
+	User         User               `json:"user"`
+	Availability []UserAvailability `json:"availability"`
+}
+
+// Commitment is a scheduled event or series occurrence that takes up a user's time
+type Commitment struct {
+	EventID   uint      `json:"event_id,omitempty"`
+	SeriesID  uint      `json:"series_id,omitempty"`
+	Title     string    `json:"title"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+}
+
+// ParticipantConflict is a participant's commitment that overlaps a proposed time
+type ParticipantConflict struct {
+	UserID     uint       `json:"user_id"`
+	Commitment Commitment `json:"commitment"`
+}
+
+// CommitmentConflict is a pair of one user's commitments that overlap
+type CommitmentConflict struct {
+	First  Commitment `json:"first"`
+	Second Commitment `json:"second"`
+}
+
+// TimeSlotRecommendation represents a recommended time slot with participant info.
+// MatchingPercentage is over every invitee who has not declined, so invitees who
+// have not responded count against it; they are listed in NonResponders.
+// Conflicts lists participants' other commitments overlapping the slot; a
+// participant does not match start times their commitments overlap. Under the
+// votes strategy, matching users voted yes or maybe and Votes holds the tally.
+// For events that need a resource, only starts with a suitable resource free are
+// offered and Resources lists those free at the first start option. QuorumMet
+// tells whether the start options meet the event's quorum; starts meeting it are
+// preferred within a slot and slots meeting it are ranked first. Explanations is
+// only filled in when asked for.
 type TimeSlotRecommendation struct {
-	TimeSlot           TimeSlot    `json:"time_slot"`
-	MatchingUsers      []User      `json:"matching_users,omitempty"`
-	NonMatchingUsers   []User      `json:"non_matching_users,omitempty"`
-	MatchingPercentage float64     `json:"matching_percentage"`
-	EventDuration      int         `json:"event_duration"`
-	StartOptions       []time.Time `json:"start_options,omitempty"`
+	TimeSlot           TimeSlot              `json:"time_slot"`
+	MatchingUsers      []User                `json:"matching_users,omitempty"`
+	NonMatchingUsers   []User                `json:"non_matching_users,omitempty"`
+	NonResponders      []User                `json:"non_responders,omitempty"`
+	Votes              *VoteTally            `json:"votes,omitempty"`
+	Resources          []Resource            `json:"resources,omitempty"`
+	MatchingPercentage float64               `json:"matching_percentage"`
+	EventDuration      int                   `json:"event_duration"`
+	StartOptions       []time.Time           `json:"start_options,omitempty"`
+	Conflicts          []ParticipantConflict `json:"conflicts,omitempty"`
+	QuorumMet          bool                  `json:"quorum_met"`
+	Explanations       []StartExplanation    `json:"explanations,omitempty"`
+}
+
+// Reasons an invitee can't attend a start option
+const (
+	ExclusionNotResponded        = "not_responded"
+	ExclusionNoAvailability      = "no_availability"
+	ExclusionPartialOverlap      = "partial_overlap"
+	ExclusionOutsideWorkingHours = "outside_working_hours"
+	ExclusionConflict            = "conflict"
+	ExclusionBuffer              = "buffer"
+	ExclusionDailyCap            = "daily_cap"
+	ExclusionVotedNo             = "voted_no"
+	ExclusionNoVote              = "no_vote"
+)
+
+// ExclusionReason is one reason an invitee can't attend. OverlapMinutes is how
+// much of the meeting their best availability window covers for partial_overlap;
+// Commitment is the clashing event or occurrence for conflict and buffer.
+type ExclusionReason struct {
+	Reason         string      `json:"reason"`
+	Detail         string      `json:"detail"`
+	OverlapMinutes int         `json:"overlap_minutes,omitempty"`
+	Commitment     *Commitment `json:"commitment,omitempty"`
+}
+
+// ExcludedUser is an invitee who can't attend a start option and why
+type ExcludedUser struct {
+	User    User              `json:"user"`
+	Reasons []ExclusionReason `json:"reasons"`
+}
+
+// StartExplanation lists who can't attend a candidate start, whether an
+// invitee's commitment rules it out and, for events that need one, whether no
+// suitable resource is free
+type StartExplanation struct {
+	StartTime  time.Time      `json:"start_time"`
+	EndTime    time.Time      `json:"end_time"`
+	Attendees  int            `json:"attendees"`
+	QuorumMet  bool           `json:"quorum_met"`
+	NoResource bool           `json:"no_resource,omitempty"`
+	Conflicted bool           `json:"conflicted,omitempty"`
+	Excluded   []ExcludedUser `json:"excluded"`
+}
+
+// Constraints between two events scheduled in one batch
+const (
+	ConstraintOrder   = "order"
+	ConstraintMinGap  = "min_gap"
+	ConstraintSameDay = "same_day"
+)
+
+// BatchConstraint relates two events of a batch. order has First end at least
+// MinGapMinutes before Second starts, min_gap keeps them that far apart in either
+// order and same_day puts them on the same calendar day.
+type BatchConstraint struct {
+	Type          string `json:"type" binding:"required"`
+	First         uint   `json:"first" binding:"required"`
+	Second        uint   `json:"second" binding:"required"`
+	MinGapMinutes int    `json:"min_gap_minutes"`
+}
+
+// BatchAssignment is the time picked for one event of a batch
+type BatchAssignment struct {
+	EventID    uint      `json:"event_id"`
+	StartTime  time.Time `json:"start_time"`
+	EndTime    time.Time `json:"end_time"`
+	Attendees  []User    `json:"attendees"`
+	Invitees   int       `json:"invitees"`
+	ResourceID *uint     `json:"resource_id,omitempty"`
+}
+
+// BatchSchedule is a joint assignment of times to several events. Events holds
+// the finalized events when the assignment was applied.
+type BatchSchedule struct {
+	Assignments     []BatchAssignment `json:"assignments"`
+	TotalAttendance int               `json:"total_attendance"`
+	Applied         bool              `json:"applied"`
+	Events          []Event           `json:"events,omitempty"`
+}
+
+// OccurrenceMatch is who can attend one occurrence at a recommended time
+type OccurrenceMatch struct {
+	StartTime          time.Time `json:"start_time"`
+	EndTime            time.Time `json:"end_time"`
+	MatchingUserIDs    []uint    `json:"matching_user_ids"`
+	NonMatchingUserIDs []uint    `json:"non_matching_user_ids"`
+}
+
+// SeriesTimeRecommendation scores one local start time applied to each of the
+// next occurrences of a series
+type SeriesTimeRecommendation struct {
+	StartTime                string            `json:"start_time"`
+	Timezone                 string            `json:"timezone"`
+	MatchingPercentage       float64           `json:"matching_percentage"`
+	FullyAttendedOccurrences int               `json:"fully_attended_occurrences"`
+	Occurrences              []OccurrenceMatch `json:"occurrences"`
+}
+
+// Outbox statuses
+const (
+	OutboxStatusPending    = "pending"
+	OutboxStatusDispatched = "dispatched"
+	OutboxStatusFailed     = "failed"
+)
+
+// OutboxEvent is a domain event written in the same transaction as the change
+// that produced it, and later delivered to integrations by the dispatcher
+type OutboxEvent struct {
+	ID            uint       `json:"id" gorm:"primaryKey"`
+	CreatedAt     time.Time  `json:"created_at"`
+	EventType     string     `json:"event_type" gorm:"not null"`
+	AggregateType string     `json:"aggregate_type" gorm:"not null;index:idx_outbox_aggregate"`
+	AggregateID   uint       `json:"aggregate_id" gorm:"index:idx_outbox_aggregate"`
+	Payload       string     `json:"payload" gorm:"type:text"`
+	Status        string     `json:"status" gorm:"not null;default:pending;index:idx_outbox_pending"`
+	Attempts      int        `json:"attempts"`
+	LastError     string     `json:"last_error,omitempty"`
+	NextAttemptAt time.Time  `json:"next_attempt_at" gorm:"index:idx_outbox_pending"`
+	LockedUntil   *time.Time `json:"-"`
+	DispatchedAt  *time.Time `json:"dispatched_at,omitempty"`
+}
+
+// FieldChange is the before and after value of one field in an audit entry
+type FieldChange struct {
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+// AuditLog is an append-only record of a single mutation
+type AuditLog struct {
+	ID         uint                   `json:"id" gorm:"primaryKey"`
+	CreatedAt  time.Time              `json:"created_at" gorm:"index"`
+	Actor      string                 `json:"actor" gorm:"index"`
+	Action     string                 `json:"action"`
+	EntityType string                 `json:"entity_type" gorm:"index:idx_audit_logs_entity"`
+	EntityID   uint                   `json:"entity_id" gorm:"index:idx_audit_logs_entity"`
+	Changes    map[string]FieldChange `json:"changes,omitempty" gorm:"serializer:json;type:text"`
+	RequestID  string                 `json:"request_id,omitempty" gorm:"index"`
+}
+
+// IdempotencyKey stores the first response to a request sent with an
+// Idempotency-Key header so retries of that request can be answered verbatim
+type IdempotencyKey struct {
+	ID           uint      `json:"id" gorm:"primaryKey"`
+	CreatedAt    time.Time `json:"created_at"`
+	Actor        string    `json:"actor" gorm:"not null;uniqueIndex:idx_idempotency_keys_actor_key"`
+	Key          string    `json:"key" gorm:"column:idempotency_key;not null;uniqueIndex:idx_idempotency_keys_actor_key"`
+	RequestHash  string    `json:"request_hash" gorm:"not null"`
+	StatusCode   int       `json:"status_code"` // 0 while the first request is still being handled
+	ContentType  string    `json:"content_type"`
+	ResponseBody string    `json:"response_body" gorm:"type:text"`
+	ExpiresAt    time.Time `json:"expires_at" gorm:"index"`
 }
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/krushnna/meeting-scheduler/models"
+	"gorm.io/gorm"
+)
+
+// AuditFilter narrows an audit log query; zero values are ignored
+type AuditFilter struct {
+	Actor      string
+	Action     string
+	EntityType string
+	EntityID   uint
+	RequestID  string
+	Since      time.Time
+	Until      time.Time
+}
+
+// AuditRepository interface defines methods for AuditLog operations.
+// The audit log is append-only, so there is no update or delete.
+type AuditRepository interface {
+	Create(entry *models.AuditLog) error
+	FindPage(filter AuditFilter, page PageRequest) (*Page[models.AuditLog], error)
+}
+
+// AuditRepositoryImpl implements AuditRepository
+type AuditRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewAuditRepository(db *gorm.DB) AuditRepository {
+	return &AuditRepositoryImpl{db: db}
+}
+
+func (r *AuditRepositoryImpl) Create(entry *models.AuditLog) error {
+	return r.db.Create(entry).Error
+}
+
+var auditSortKeys = map[string]sortKey[models.AuditLog]{
+	"id":         {column: "id", value: func(a models.AuditLog) interface{} { return a.ID }},
+	"created_at": {column: "created_at", value: func(a models.AuditLog) interface{} { return a.CreatedAt }, isTime: true},
+}
+
+// FindPage returns one page of matching entries, newest first unless page sorts otherwise
+func (r *AuditRepositoryImpl) FindPage(filter AuditFilter, page PageRequest) (*Page[models.AuditLog], error) {
+	query := r.db.Model(&models.AuditLog{})
+	if filter.Actor != "" {
+		query = query.Where("actor = ?", filter.Actor)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.EntityType != "" {
+		query = query.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID != 0 {
+		query = query.Where("entity_id = ?", filter.EntityID)
+	}
+	if filter.RequestID != "" {
+		query = query.Where("request_id = ?", filter.RequestID)
+	}
+	if !filter.Since.IsZero() {
+		query = query.Where("created_at >= ?", filter.Since)
+	}
+	if !filter.Until.IsZero() {
+		query = query.Where("created_at < ?", filter.Until)
+	}
+	if page.Sort == "" {
+		page.Sort = "-id"
+	}
+	return findPage(query, page, auditSortKeys, func(a models.AuditLog) uint { return a.ID })
+}
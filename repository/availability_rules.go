@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"github.com/krushnna/meeting-scheduler/models"
+	"gorm.io/gorm"
+)
+
+// AvailabilityRuleRepository interface defines methods for recurring availability rules and their exceptions
+type AvailabilityRuleRepository interface {
+	CreateRule(rule *models.AvailabilityRule) error
+	FindRuleByID(id uint) (*models.AvailabilityRule, error)
+	FindRulesByUser(userID uint) ([]models.AvailabilityRule, error)
+	DeleteRule(id uint) error
+	CreateException(exception *models.AvailabilityException) error
+	FindExceptionByID(id uint) (*models.AvailabilityException, error)
+	FindExceptionsByUser(userID uint) ([]models.AvailabilityException, error)
+	DeleteException(id uint) error
+}
+
+// AvailabilityRuleRepositoryImpl implements AvailabilityRuleRepository
+type AvailabilityRuleRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewAvailabilityRuleRepository(db *gorm.DB) AvailabilityRuleRepository {
+	return &AvailabilityRuleRepositoryImpl{db: db}
+}
+
+func (r *AvailabilityRuleRepositoryImpl) CreateRule(rule *models.AvailabilityRule) error {
+	return r.db.Create(rule).Error
+}
+
+func (r *AvailabilityRuleRepositoryImpl) FindRuleByID(id uint) (*models.AvailabilityRule, error) {
+	var rule models.AvailabilityRule
+	result := r.db.First(&rule, id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &rule, nil
+}
+
+// FindRulesByUser returns the user's rules ordered by weekday and start time
+func (r *AvailabilityRuleRepositoryImpl) FindRulesByUser(userID uint) ([]models.AvailabilityRule, error) {
+	var rules []models.AvailabilityRule
+	result := r.db.Where("user_id = ?", userID).Order("weekday, start_time").Find(&rules)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return rules, nil
+}
+
+func (r *AvailabilityRuleRepositoryImpl) DeleteRule(id uint) error {
+	return r.db.Delete(&models.AvailabilityRule{}, id).Error
+}
+
+func (r *AvailabilityRuleRepositoryImpl) CreateException(exception *models.AvailabilityException) error {
+	return r.db.Create(exception).Error
+}
+
+func (r *AvailabilityRuleRepositoryImpl) FindExceptionByID(id uint) (*models.AvailabilityException, error) {
+	var exception models.AvailabilityException
+	result := r.db.First(&exception, id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &exception, nil
+}
+
+// FindExceptionsByUser returns the user's exceptions ordered by date
+func (r *AvailabilityRuleRepositoryImpl) FindExceptionsByUser(userID uint) ([]models.AvailabilityException, error) {
+	var exceptions []models.AvailabilityException
+	result := r.db.Where("user_id = ?", userID).Order("date, start_time").Find(&exceptions)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return exceptions, nil
+}
+
+func (r *AvailabilityRuleRepositoryImpl) DeleteException(id uint) error {
+	return r.db.Delete(&models.AvailabilityException{}, id).Error
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/krushnna/meeting-scheduler/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IdempotencyRepository interface defines methods for IdempotencyKey operations
+type IdempotencyRepository interface {
+	Find(actor, key string, now time.Time) (*models.IdempotencyKey, error)
+	Reserve(record *models.IdempotencyKey, now time.Time) (bool, error)
+	Complete(id uint, statusCode int, contentType, body string) error
+	Release(id uint) error
+	DeleteExpired(now time.Time) (int64, error)
+}
+
+// IdempotencyRepositoryImpl implements IdempotencyRepository
+type IdempotencyRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewIdempotencyRepository(db *gorm.DB) IdempotencyRepository {
+	return &IdempotencyRepositoryImpl{db: db}
+}
+
+// Find returns the unexpired record for the actor's key
+func (r *IdempotencyRepositoryImpl) Find(actor, key string, now time.Time) (*models.IdempotencyKey, error) {
+	var record models.IdempotencyKey
+	result := r.db.Where("actor = ? AND idempotency_key = ? AND expires_at > ?", actor, key, now).First(&record)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &record, nil
+}
+
+// Reserve inserts the record unless a live one already holds the same key,
+// reporting whether the caller now owns the key. An expired record is replaced.
+func (r *IdempotencyRepositoryImpl) Reserve(record *models.IdempotencyKey, now time.Time) (bool, error) {
+	err := r.db.Where("actor = ? AND idempotency_key = ? AND expires_at <= ?", record.Actor, record.Key, now).
+		Delete(&models.IdempotencyKey{}).Error
+	if err != nil {
+		return false, err
+	}
+
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(record)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// Complete stores the response for a reserved key
+func (r *IdempotencyRepositoryImpl) Complete(id uint, statusCode int, contentType, body string) error {
+	return r.db.Model(&models.IdempotencyKey{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status_code":   statusCode,
+		"content_type":  contentType,
+		"response_body": body,
+	}).Error
+}
+
+// Release drops a reserved key so the request can be retried
+func (r *IdempotencyRepositoryImpl) Release(id uint) error {
+	return r.db.Delete(&models.IdempotencyKey{}, id).Error
+}
+
+// DeleteExpired removes every expired key and returns how many were removed
+func (r *IdempotencyRepositoryImpl) DeleteExpired(now time.Time) (int64, error) {
+	result := r.db.Where("expires_at <= ?", now).Delete(&models.IdempotencyKey{})
+	return result.RowsAffected, result.Error
+}
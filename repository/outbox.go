@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/krushnna/meeting-scheduler/models"
+	"gorm.io/gorm"
+)
+
+// OutboxRepository interface defines methods for OutboxEvent operations
+type OutboxRepository interface {
+	Create(event *models.OutboxEvent) error
+	ClaimPending(now time.Time, lease time.Duration, limit int) ([]models.OutboxEvent, error)
+	MarkDispatched(id uint, at time.Time) error
+	MarkRetry(id uint, attempts int, lastError string, nextAttemptAt time.Time) error
+	MarkFailed(id uint, attempts int, lastError string) error
+}
+
+// OutboxRepositoryImpl implements OutboxRepository
+type OutboxRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewOutboxRepository(db *gorm.DB) OutboxRepository {
+	return &OutboxRepositoryImpl{db: db}
+}
+
+func (r *OutboxRepositoryImpl) Create(event *models.OutboxEvent) error {
+	return r.db.Create(event).Error
+}
+
+// ClaimPending leases up to limit due events to the caller. Each row is claimed
+// with a conditional update, so concurrent dispatchers never hold the same row.
+func (r *OutboxRepositoryImpl) ClaimPending(now time.Time, lease time.Duration, limit int) ([]models.OutboxEvent, error) {
+	var candidates []models.OutboxEvent
+	result := r.db.
+		Where("status = ? AND next_attempt_at <= ?", models.OutboxStatusPending, now).
+		Where("locked_until IS NULL OR locked_until < ?", now).
+		Order("id").
+		Limit(limit).
+		Find(&candidates)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	lockedUntil := now.Add(lease)
+	claimed := make([]models.OutboxEvent, 0, len(candidates))
+	for _, event := range candidates {
+		result := r.db.Model(&models.OutboxEvent{}).
+			Where("id = ? AND status = ?", event.ID, models.OutboxStatusPending).
+			Where("locked_until IS NULL OR locked_until < ?", now).
+			Update("locked_until", lockedUntil)
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		if result.RowsAffected == 1 {
+			event.LockedUntil = &lockedUntil
+			claimed = append(claimed, event)
+		}
+	}
+	return claimed, nil
+}
+
+func (r *OutboxRepositoryImpl) MarkDispatched(id uint, at time.Time) error {
+	return r.db.Model(&models.OutboxEvent{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":        models.OutboxStatusDispatched,
+		"dispatched_at": at,
+		"locked_until":  nil,
+	}).Error
+}
+
+func (r *OutboxRepositoryImpl) MarkRetry(id uint, attempts int, lastError string, nextAttemptAt time.Time) error {
+	return r.db.Model(&models.OutboxEvent{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"attempts":        attempts,
+		"last_error":      lastError,
+		"next_attempt_at": nextAttemptAt,
+		"locked_until":    nil,
+	}).Error
+}
+
+func (r *OutboxRepositoryImpl) MarkFailed(id uint, attempts int, lastError string) error {
+	return r.db.Model(&models.OutboxEvent{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":       models.OutboxStatusFailed,
+		"attempts":     attempts,
+		"last_error":   lastError,
+		"locked_until": nil,
+	}).Error
+}
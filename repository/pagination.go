@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Page size limits for cursor pagination
+const (
+	DefaultPageLimit = 10
+	MaxPageLimit     = 100
+)
+
+// ErrInvalidPage is returned for an unknown sort field or a malformed cursor
+var ErrInvalidPage = errors.New("invalid page request")
+
+// PageRequest selects one page of a list. Sort names a field, prefixed with
+// "-" for descending order; Cursor is the NextCursor of the previous page.
+type PageRequest struct {
+	Limit  int
+	Cursor string
+	Sort   string
+}
+
+// Page is one page of a list together with the total number of matches
+type Page[T any] struct {
+	Items      []T
+	Total      int64
+	NextCursor string
+}
+
+// sortKey is a field a list can be ordered by
+type sortKey[T any] struct {
+	column string
+	value  func(T) interface{}
+	isTime bool
+}
+
+// cursor marks the position after the last item of a page. It records the sort
+// it was issued for, so it cannot be replayed against a different order.
+type cursor struct {
+	Sort  string      `json:"s"`
+	Value interface{} `json:"v"`
+	ID    uint        `json:"id"`
+}
+
+// findPage runs query one page at a time using keyset pagination: rows are
+// ordered by the sort column with the ID as tie-breaker, and each page starts
+// strictly after the position encoded in the cursor
+func findPage[T any](query *gorm.DB, page PageRequest, keys map[string]sortKey[T], id func(T) uint) (*Page[T], error) {
+	sort := page.Sort
+	if sort == "" {
+		sort = "id"
+	}
+	name := strings.TrimPrefix(sort, "-")
+	desc := name != sort
+	key, ok := keys[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: cannot sort by %q", ErrInvalidPage, name)
+	}
+
+	limit := page.Limit
+	if limit <= 0 {
+		limit = DefaultPageLimit
+	}
+	if limit > MaxPageLimit {
+		limit = MaxPageLimit
+	}
+
+	result := &Page[T]{}
+	if err := query.Session(&gorm.Session{}).Model(new(T)).Count(&result.Total).Error; err != nil {
+		return nil, err
+	}
+
+	direction, comparison := "ASC", ">"
+	if desc {
+		direction, comparison = "DESC", "<"
+	}
+	rows := query.Session(&gorm.Session{})
+	if page.Cursor != "" {
+		after, err := decodeCursor(page.Cursor, sort, key.isTime)
+		if err != nil {
+			return nil, err
+		}
+		if key.column == "id" {
+			rows = rows.Where(fmt.Sprintf("id %s ?", comparison), after.ID)
+		} else {
+			rows = rows.Where(fmt.Sprintf("%[1]s %[2]s ? OR (%[1]s = ? AND id %[2]s ?)", key.column, comparison), after.Value, after.Value, after.ID)
+		}
+	}
+	if key.column != "id" {
+		rows = rows.Order(key.column + " " + direction)
+	}
+	rows = rows.Order("id " + direction)
+
+	if err := rows.Limit(limit + 1).Find(&result.Items).Error; err != nil {
+		return nil, err
+	}
+	if len(result.Items) > limit {
+		result.Items = result.Items[:limit]
+		last := result.Items[limit-1]
+		next, err := encodeCursor(cursor{Sort: sort, Value: key.value(last), ID: id(last)})
+		if err != nil {
+			return nil, err
+		}
+		result.NextCursor = next
+	}
+	return result, nil
+}
+
+func encodeCursor(c cursor) (string, error) {
+	body, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(body), nil
+}
+
+func decodeCursor(encoded, sort string, isTime bool) (*cursor, error) {
+	body, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidPage)
+	}
+	var c cursor
+	if err := json.Unmarshal(body, &c); err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidPage)
+	}
+	if c.Sort != sort {
+		return nil, fmt.Errorf("%w: cursor was issued for sort %q", ErrInvalidPage, c.Sort)
+	}
+	if isTime {
+		value, ok := c.Value.(string)
+		if !ok {
+			return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidPage)
+		}
+		if c.Value, err = time.Parse(time.RFC3339Nano, value); err != nil {
+			return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidPage)
+		}
+	}
+	return &c, nil
+}
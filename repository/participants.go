@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/krushnna/meeting-scheduler/models"
+	"gorm.io/gorm"
+)
+
+// EventParticipantRepository interface defines methods for EventParticipant operations
+type EventParticipantRepository interface {
+	Create(participant *models.EventParticipant) error
+	FindByEventAndUser(eventID, userID uint) (*models.EventParticipant, error)
+	FindByEvent(eventID uint) ([]models.EventParticipant, error)
+	FindEventIDsByUser(userID uint) ([]uint, error)
+	FindByUser(userID uint) ([]models.EventParticipant, error)
+	UpdateStatus(id uint, status string) error
+	UpdateRequired(id uint, required bool) error
+	UpdateRSVP(id uint, response string, at time.Time) error
+	ResetRSVPs(eventID uint) error
+	Delete(id uint) error
+	DeleteByEventID(eventID uint) error
+	DeleteByUserID(userID uint) error
+}
+
+// EventParticipantRepositoryImpl implements EventParticipantRepository
+type EventParticipantRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewEventParticipantRepository(db *gorm.DB) EventParticipantRepository {
+	return &EventParticipantRepositoryImpl{db: db}
+}
+
+func (r *EventParticipantRepositoryImpl) Create(participant *models.EventParticipant) error {
+	return r.db.Omit("User").Create(participant).Error
+}
+
+func (r *EventParticipantRepositoryImpl) FindByEventAndUser(eventID, userID uint) (*models.EventParticipant, error) {
+	var participant models.EventParticipant
+	result := r.db.Where("event_id = ? AND user_id = ?", eventID, userID).First(&participant)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &participant, nil
+}
+
+// FindByEvent returns the event's participants with their users, ordered by user.
+// Participants whose user has been deleted are left out.
+func (r *EventParticipantRepositoryImpl) FindByEvent(eventID uint) ([]models.EventParticipant, error) {
+	var participants []models.EventParticipant
+	result := r.db.
+		Joins("JOIN users ON users.id = event_participants.user_id AND users.deleted_at IS NULL").
+		Preload("User").
+		Where("event_participants.event_id = ?", eventID).
+		Order("event_participants.user_id").
+		Find(&participants)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return participants, nil
+}
+
+// FindEventIDsByUser returns the live events the user is invited to and hasn't declined
+func (r *EventParticipantRepositoryImpl) FindEventIDsByUser(userID uint) ([]uint, error) {
+	var eventIDs []uint
+	result := r.db.Model(&models.EventParticipant{}).
+		Joins("JOIN events ON events.id = event_participants.event_id AND events.deleted_at IS NULL").
+		Where("event_participants.user_id = ? AND event_participants.status <> ?", userID, models.ParticipantStatusDeclined).
+		Order("event_participants.event_id").
+		Pluck("event_participants.event_id", &eventIDs)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return eventIDs, nil
+}
+
+// FindByUser returns the user's invitations across all events
+func (r *EventParticipantRepositoryImpl) FindByUser(userID uint) ([]models.EventParticipant, error) {
+	var participants []models.EventParticipant
+	result := r.db.Where("user_id = ?", userID).Order("event_id").Find(&participants)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return participants, nil
+}
+
+func (r *EventParticipantRepositoryImpl) UpdateStatus(id uint, status string) error {
+	return r.db.Model(&models.EventParticipant{}).Where("id = ?", id).Update("status", status).Error
+}
+
+func (r *EventParticipantRepositoryImpl) UpdateRequired(id uint, required bool) error {
+	return r.db.Model(&models.EventParticipant{}).Where("id = ?", id).Update("required", required).Error
+}
+
+func (r *EventParticipantRepositoryImpl) UpdateRSVP(id uint, response string, at time.Time) error {
+	return r.db.Model(&models.EventParticipant{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"rsvp": response, "rsvp_at": at}).Error
+}
+
+// ResetRSVPs sets every participant of the event back to pending
+func (r *EventParticipantRepositoryImpl) ResetRSVPs(eventID uint) error {
+	return r.db.Model(&models.EventParticipant{}).Where("event_id = ?", eventID).
+		Updates(map[string]interface{}{"rsvp": models.RSVPPending, "rsvp_at": nil}).Error
+}
+
+func (r *EventParticipantRepositoryImpl) Delete(id uint) error {
+	return r.db.Delete(&models.EventParticipant{}, id).Error
+}
+
+// DeleteByEventID soft-deletes an event's participants, stamped with the event's deletion time
+func (r *EventParticipantRepositoryImpl) DeleteByEventID(eventID uint) error {
+	return r.db.Model(&models.EventParticipant{}).
+		Where("event_id = ?", eventID).
+		Update("deleted_at", r.db.Unscoped().Model(&models.Event{}).Select("deleted_at").Where("id = ?", eventID)).Error
+}
+
+// DeleteByUserID soft-deletes a user's invitations, stamped with the user's deletion time
+func (r *EventParticipantRepositoryImpl) DeleteByUserID(userID uint) error {
+	return r.db.Model(&models.EventParticipant{}).
+		Where("user_id = ?", userID).
+		Update("deleted_at", r.db.Unscoped().Model(&models.User{}).Select("deleted_at").Where("id = ?", userID)).Error
+}
@@ -1,18 +1,43 @@
 package repository
 
 import (
+	"errors"
+	"strings"
+	"time"
+
 	"github.com/krushnna/meeting-scheduler/models"
 	"gorm.io/gorm"
 )
 
+// ErrStaleVersion is returned when a record was changed since the caller read it
+var ErrStaleVersion = errors.New("record has been modified by another request")
+
+// checkVersion turns a versioned write that matched no rows into ErrStaleVersion
+func checkVersion(result *gorm.DB) error {
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrStaleVersion
+	}
+	return nil
+}
+
 // EventRepository interface defines methods for Event operations
 type EventRepository interface {
 	Create(event *models.Event) error
 	FindByID(id uint) (*models.Event, error)
 	FindAll() ([]models.Event, error)
-	FindAllWithPagination(limit, offset int) ([]models.Event, error)
+	FindPage(filter EventFilter, page PageRequest) (*Page[models.Event], error)
+	FindScheduledByUser(userID uint, from, to time.Time) ([]models.Event, error)
 	Update(id uint, event *models.Event) error
-	Delete(id uint) error
+	Save(event *models.Event) error
+	Delete(id, version uint) error
+	FindAutoFinalizeDue(now time.Time) ([]models.Event, error)
+	ClearAutoFinalizeOutcome(id uint) error
+	ClearSchedule(id uint) error
+	CreateScheduleChange(change *models.ScheduleChange) error
+	FindScheduleChanges(eventID uint) ([]models.ScheduleChange, error)
 }
 
 // EventRepositoryImpl implements EventRepository
@@ -20,20 +45,12 @@ type EventRepositoryImpl struct {
 	db *gorm.DB
 }
 
-func (r *EventRepositoryImpl) FindAllWithPagination(limit, offset int) ([]models.Event, error) {
-	var events []models.Event
-	result := r.db.Limit(limit).Offset(offset).Find(&events)
-	if result.Error != nil {
-		return nil, result.Error
-	}
-	return events, nil
-}
-
 func NewEventRepository(db *gorm.DB) EventRepository {
 	return &EventRepositoryImpl{db: db}
 }
 
 func (r *EventRepositoryImpl) Create(event *models.Event) error {
+	event.Version = 1
 	return r.db.Create(event).Error
 }
 
@@ -46,6 +63,62 @@ func (r *EventRepositoryImpl) FindByID(id uint) (*models.Event, error) {
 	return &event, nil
 }
 
+// EventFilter narrows an event list; zero fields are ignored
+type EventFilter struct {
+	OrganizerID uint
+	Status      string
+	// From and To select events with a time slot overlapping the range
+	From time.Time
+	To   time.Time
+}
+
+var eventSortKeys = map[string]sortKey[models.Event]{
+	"id":               {column: "id", value: func(e models.Event) interface{} { return e.ID }},
+	"created_at":       {column: "created_at", value: func(e models.Event) interface{} { return e.CreatedAt }, isTime: true},
+	"title":            {column: "title", value: func(e models.Event) interface{} { return e.Title }},
+	"duration_minutes": {column: "duration_minutes", value: func(e models.Event) interface{} { return e.DurationMinutes }},
+}
+
+func (r *EventRepositoryImpl) FindPage(filter EventFilter, page PageRequest) (*Page[models.Event], error) {
+	query := r.db.Model(&models.Event{})
+	if filter.OrganizerID != 0 {
+		query = query.Where("organizer_id = ?", filter.OrganizerID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if !filter.From.IsZero() || !filter.To.IsZero() {
+		slots := r.db.Model(&models.TimeSlot{}).Select("1").Where("time_slots.event_id = events.id")
+		if !filter.From.IsZero() {
+			slots = slots.Where("time_slots.end_time > ?", filter.From)
+		}
+		if !filter.To.IsZero() {
+			slots = slots.Where("time_slots.start_time < ?", filter.To)
+		}
+		query = query.Where("EXISTS (?)", slots)
+	}
+	return findPage(query, page, eventSortKeys, func(e models.Event) uint { return e.ID })
+}
+
+// FindScheduledByUser returns the scheduled events the user takes part in whose
+// scheduled time overlaps [from, to), in start order. Events the user declined,
+// as an invitation or as an RSVP, are left out.
+func (r *EventRepositoryImpl) FindScheduledByUser(userID uint, from, to time.Time) ([]models.Event, error) {
+	participant := r.db.Model(&models.EventParticipant{}).Select("1").
+		Where("event_participants.event_id = events.id AND event_participants.user_id = ?", userID).
+		Where("event_participants.status <> ? AND event_participants.rsvp <> ?", models.ParticipantStatusDeclined, models.RSVPDeclined)
+	var events []models.Event
+	result := r.db.
+		Where("status = ? AND scheduled_start < ? AND scheduled_end > ?", models.EventStatusScheduled, to, from).
+		Where("EXISTS (?)", participant).
+		Order("scheduled_start").
+		Find(&events)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return events, nil
+}
+
 func (r *EventRepositoryImpl) FindAll() ([]models.Event, error) {
 	var events []models.Event
 	result := r.db.Find(&events)
@@ -55,12 +128,74 @@ func (r *EventRepositoryImpl) FindAll() ([]models.Event, error) {
 	return events, nil
 }
 
+// Update writes the non-zero fields of event if the stored version still equals
+// event.Version, and bumps the version
 func (r *EventRepositoryImpl) Update(id uint, event *models.Event) error {
-	return r.db.Model(&models.Event{}).Where("id = ?", id).Updates(event).Error
+	version := event.Version
+	event.Version = version + 1
+	return checkVersion(r.db.Model(&models.Event{}).Where("id = ? AND version = ?", id, version).Updates(event))
+}
+
+// Save writes every column of event, including zero values that Update skips,
+// under the same version check as Update
+func (r *EventRepositoryImpl) Save(event *models.Event) error {
+	version := event.Version
+	event.Version = version + 1
+	return checkVersion(r.db.Model(event).Where("version = ?", version).Select("*").Omit("ID", "CreatedAt", "DeletedAt").Updates(event))
+}
+
+// Delete soft-deletes the record if its stored version still equals version
+func (r *EventRepositoryImpl) Delete(id, version uint) error {
+	return checkVersion(r.db.Where("version = ?", version).Delete(&models.Event{}, id))
+}
+
+// FindAutoFinalizeDue returns the polling events with an auto-finalize policy and
+// no recorded outcome whose deadline has passed, or whose policy waits for
+// responses, in ID order
+func (r *EventRepositoryImpl) FindAutoFinalizeDue(now time.Time) ([]models.Event, error) {
+	var events []models.Event
+	result := r.db.
+		Where("status = ? AND auto_finalize <> ?", models.EventStatusPolling, models.AutoFinalizeOff).
+		Where("auto_finalize_outcome IS NULL OR auto_finalize_outcome = ''").
+		Where("auto_finalize = ? OR (response_deadline IS NOT NULL AND response_deadline <= ?)", models.AutoFinalizeWhenResponded, now).
+		Order("id").
+		Find(&events)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return events, nil
+}
+
+// ClearAutoFinalizeOutcome forgets the worker's decision so the event is considered again
+func (r *EventRepositoryImpl) ClearAutoFinalizeOutcome(id uint) error {
+	return r.db.Model(&models.Event{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"auto_finalize_outcome":    nil,
+		"auto_finalize_rationale":  nil,
+		"auto_finalize_decided_at": nil,
+	}).Error
+}
+
+// ClearSchedule forgets the event's scheduled time and reserved resource
+func (r *EventRepositoryImpl) ClearSchedule(id uint) error {
+	return r.db.Model(&models.Event{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"scheduled_start":      nil,
+		"scheduled_end":        nil,
+		"reserved_resource_id": nil,
+	}).Error
+}
+
+func (r *EventRepositoryImpl) CreateScheduleChange(change *models.ScheduleChange) error {
+	return r.db.Create(change).Error
 }
 
-func (r *EventRepositoryImpl) Delete(id uint) error {
-	return r.db.Delete(&models.Event{}, id).Error
+// FindScheduleChanges returns the times the event was previously scheduled for, oldest first
+func (r *EventRepositoryImpl) FindScheduleChanges(eventID uint) ([]models.ScheduleChange, error) {
+	var changes []models.ScheduleChange
+	result := r.db.Where("event_id = ?", eventID).Order("id").Find(&changes)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return changes, nil
 }
 
 // TimeSlotRepository interface defines methods for TimeSlot operations
@@ -68,8 +203,11 @@ type TimeSlotRepository interface {
 	Create(timeSlot *models.TimeSlot) error
 	FindByID(id uint) (*models.TimeSlot, error)
 	FindByEventID(eventID uint) ([]models.TimeSlot, error)
+	FindPageByEventID(eventID uint, page PageRequest) (*Page[models.TimeSlot], error)
 	Update(id uint, timeSlot *models.TimeSlot) error
-	Delete(id uint) error
+	Save(timeSlot *models.TimeSlot) error
+	Delete(id, version uint) error
+	DeleteByEventID(eventID uint) error
 }
 
 // TimeSlotRepositoryImpl implements TimeSlotRepository
@@ -82,6 +220,7 @@ func NewTimeSlotRepository(db *gorm.DB) TimeSlotRepository {
 }
 
 func (r *TimeSlotRepositoryImpl) Create(timeSlot *models.TimeSlot) error {
+	timeSlot.Version = 1
 	return r.db.Create(timeSlot).Error
 }
 
@@ -103,21 +242,56 @@ func (r *TimeSlotRepositoryImpl) FindByEventID(eventID uint) ([]models.TimeSlot,
 	return timeSlots, nil
 }
 
+var timeSlotSortKeys = map[string]sortKey[models.TimeSlot]{
+	"id":         {column: "id", value: func(t models.TimeSlot) interface{} { return t.ID }},
+	"start_time": {column: "start_time", value: func(t models.TimeSlot) interface{} { return t.StartTime }, isTime: true},
+	"end_time":   {column: "end_time", value: func(t models.TimeSlot) interface{} { return t.EndTime }, isTime: true},
+}
+
+func (r *TimeSlotRepositoryImpl) FindPageByEventID(eventID uint, page PageRequest) (*Page[models.TimeSlot], error) {
+	query := r.db.Model(&models.TimeSlot{}).Where("event_id = ?", eventID)
+	return findPage(query, page, timeSlotSortKeys, func(t models.TimeSlot) uint { return t.ID })
+}
+
+// Update writes the non-zero fields of timeSlot if the stored version still equals
+// timeSlot.Version, and bumps the version
 func (r *TimeSlotRepositoryImpl) Update(id uint, timeSlot *models.TimeSlot) error {
-	return r.db.Model(&models.TimeSlot{}).Where("id = ?", id).Updates(timeSlot).Error
+	version := timeSlot.Version
+	timeSlot.Version = version + 1
+	return checkVersion(r.db.Model(&models.TimeSlot{}).Where("id = ? AND version = ?", id, version).Updates(timeSlot))
 }
 
-func (r *TimeSlotRepositoryImpl) Delete(id uint) error {
-	return r.db.Delete(&models.TimeSlot{}, id).Error
+// Save writes every column of timeSlot, including zero values that Update skips,
+// under the same version check as Update
+func (r *TimeSlotRepositoryImpl) Save(timeSlot *models.TimeSlot) error {
+	version := timeSlot.Version
+	timeSlot.Version = version + 1
+	return checkVersion(r.db.Model(timeSlot).Where("version = ?", version).Select("*").Omit("ID", "CreatedAt", "DeletedAt").Updates(timeSlot))
+}
+
+// Delete soft-deletes the record if its stored version still equals version
+func (r *TimeSlotRepositoryImpl) Delete(id, version uint) error {
+	return checkVersion(r.db.Where("version = ?", version).Delete(&models.TimeSlot{}, id))
+}
+
+// DeleteByEventID soft-deletes an event's time slots, stamping them with the
+// event's own deletion time so they can be restored together
+func (r *TimeSlotRepositoryImpl) DeleteByEventID(eventID uint) error {
+	return r.db.Model(&models.TimeSlot{}).
+		Where("event_id = ?", eventID).
+		Update("deleted_at", r.db.Unscoped().Model(&models.Event{}).Select("deleted_at").Where("id = ?", eventID)).Error
 }
 
 // UserRepository interface defines methods for User operations
 type UserRepository interface {
 	Create(user *models.User) error
 	FindByID(id uint) (*models.User, error)
+	FindByEmail(email string) (*models.User, error)
 	FindAll() ([]models.User, error)
+	FindPage(filter UserFilter, page PageRequest) (*Page[models.User], error)
 	Update(id uint, user *models.User) error
-	Delete(id uint) error
+	Save(user *models.User) error
+	Delete(id, version uint) error
 }
 
 // UserRepositoryImpl implements UserRepository
@@ -130,6 +304,7 @@ func NewUserRepository(db *gorm.DB) UserRepository {
 }
 
 func (r *UserRepositoryImpl) Create(user *models.User) error {
+	user.Version = 1
 	return r.db.Create(user).Error
 }
 
@@ -142,6 +317,15 @@ func (r *UserRepositoryImpl) FindByID(id uint) (*models.User, error) {
 	return &user, nil
 }
 
+func (r *UserRepositoryImpl) FindByEmail(email string) (*models.User, error) {
+	var user models.User
+	result := r.db.Where("email = ?", email).First(&user)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &user, nil
+}
+
 func (r *UserRepositoryImpl) FindAll() ([]models.User, error) {
 	var users []models.User
 	result := r.db.Find(&users)
@@ -151,12 +335,52 @@ func (r *UserRepositoryImpl) FindAll() ([]models.User, error) {
 	return users, nil
 }
 
+// UserFilter narrows a user list; zero fields are ignored
+type UserFilter struct {
+	// Email matches exactly, ignoring case
+	Email string
+	// Search matches part of the name or email, ignoring case
+	Search string
+}
+
+var userSortKeys = map[string]sortKey[models.User]{
+	"id":         {column: "id", value: func(u models.User) interface{} { return u.ID }},
+	"created_at": {column: "created_at", value: func(u models.User) interface{} { return u.CreatedAt }, isTime: true},
+	"name":       {column: "name", value: func(u models.User) interface{} { return u.Name }},
+	"email":      {column: "email", value: func(u models.User) interface{} { return u.Email }},
+}
+
+func (r *UserRepositoryImpl) FindPage(filter UserFilter, page PageRequest) (*Page[models.User], error) {
+	query := r.db.Model(&models.User{})
+	if filter.Email != "" {
+		query = query.Where("LOWER(email) = ?", strings.ToLower(filter.Email))
+	}
+	if filter.Search != "" {
+		pattern := "%" + strings.ToLower(filter.Search) + "%"
+		query = query.Where("LOWER(name) LIKE ? OR LOWER(email) LIKE ?", pattern, pattern)
+	}
+	return findPage(query, page, userSortKeys, func(u models.User) uint { return u.ID })
+}
+
+// Update writes the non-zero fields of user if the stored version still equals
+// user.Version, and bumps the version
 func (r *UserRepositoryImpl) Update(id uint, user *models.User) error {
-	return r.db.Model(&models.User{}).Where("id = ?", id).Updates(user).Error
+	version := user.Version
+	user.Version = version + 1
+	return checkVersion(r.db.Model(&models.User{}).Where("id = ? AND version = ?", id, version).Updates(user))
+}
+
+// Save writes every column of user, including zero values that Update skips,
+// under the same version check as Update
+func (r *UserRepositoryImpl) Save(user *models.User) error {
+	version := user.Version
+	user.Version = version + 1
+	return checkVersion(r.db.Model(user).Where("version = ?", version).Select("*").Omit("ID", "CreatedAt", "DeletedAt").Updates(user))
 }
 
-func (r *UserRepositoryImpl) Delete(id uint) error {
-	return r.db.Delete(&models.User{}, id).Error
+// Delete soft-deletes the record if its stored version still equals version
+func (r *UserRepositoryImpl) Delete(id, version uint) error {
+	return checkVersion(r.db.Where("version = ?", version).Delete(&models.User{}, id))
 }
 
 // UserAvailabilityRepository interface defines methods for UserAvailability operations
@@ -164,11 +388,16 @@ type UserAvailabilityRepository interface {
 	Create(availability *models.UserAvailability) error
 	FindByID(id uint) (*models.UserAvailability, error)
 	FindByUserAndEvent(userID, eventID uint) ([]models.UserAvailability, error)
+	FindPageByUserAndEvent(userID, eventID uint, page PageRequest) (*Page[models.UserAvailability], error)
 	FindAllUsersByEvent(eventID uint) ([]models.User, error)
 	Update(id uint, availability *models.UserAvailability) error
-	Delete(id uint) error
+	Delete(id, version uint) error
 	// New method: fetch all availabilities for an event in one query
 	FindByEvent(eventID uint) ([]models.UserAvailability, error)
+	FindByUser(userID uint) ([]models.UserAvailability, error)
+	DeleteByEventID(eventID uint) error
+	DeleteByUserID(userID uint) error
+	FindEventIDsBySource(userID uint, source string) ([]uint, error)
 }
 
 // UserAvailabilityRepositoryImpl implements UserAvailabilityRepository
@@ -181,6 +410,10 @@ func NewUserAvailabilityRepository(db *gorm.DB) UserAvailabilityRepository {
 }
 
 func (r *UserAvailabilityRepositoryImpl) Create(availability *models.UserAvailability) error {
+	availability.Version = 1
+	if availability.Source == "" {
+		availability.Source = models.AvailabilitySourceManual
+	}
 	return r.db.Create(availability).Error
 }
 
@@ -202,10 +435,21 @@ func (r *UserAvailabilityRepositoryImpl) FindByUserAndEvent(userID, eventID uint
 	return availabilities, nil
 }
 
+var availabilitySortKeys = map[string]sortKey[models.UserAvailability]{
+	"id":         {column: "id", value: func(a models.UserAvailability) interface{} { return a.ID }},
+	"start_time": {column: "start_time", value: func(a models.UserAvailability) interface{} { return a.StartTime }, isTime: true},
+	"end_time":   {column: "end_time", value: func(a models.UserAvailability) interface{} { return a.EndTime }, isTime: true},
+}
+
+func (r *UserAvailabilityRepositoryImpl) FindPageByUserAndEvent(userID, eventID uint, page PageRequest) (*Page[models.UserAvailability], error) {
+	query := r.db.Model(&models.UserAvailability{}).Where("user_id = ? AND event_id = ?", userID, eventID)
+	return findPage(query, page, availabilitySortKeys, func(a models.UserAvailability) uint { return a.ID })
+}
+
 func (r *UserAvailabilityRepositoryImpl) FindAllUsersByEvent(eventID uint) ([]models.User, error) {
 	var users []models.User
 	result := r.db.
-		Joins("JOIN user_availabilities ON users.id = user_availabilities.user_id").
+		Joins("JOIN user_availabilities ON users.id = user_availabilities.user_id AND user_availabilities.deleted_at IS NULL").
 		Where("user_availabilities.event_id = ?", eventID).
 		Group("users.id").
 		Find(&users)
@@ -215,19 +459,62 @@ func (r *UserAvailabilityRepositoryImpl) FindAllUsersByEvent(eventID uint) ([]mo
 	return users, nil
 }
 
+// Update writes the non-zero fields of availability if the stored version still equals
+// availability.Version, and bumps the version
 func (r *UserAvailabilityRepositoryImpl) Update(id uint, availability *models.UserAvailability) error {
-	return r.db.Model(&models.UserAvailability{}).Where("id = ?", id).Updates(availability).Error
+	version := availability.Version
+	availability.Version = version + 1
+	return checkVersion(r.db.Model(&models.UserAvailability{}).Where("id = ? AND version = ?", id, version).Updates(availability))
 }
 
-func (r *UserAvailabilityRepositoryImpl) Delete(id uint) error {
-	return r.db.Delete(&models.UserAvailability{}, id).Error
+// Delete soft-deletes the record if its stored version still equals version
+func (r *UserAvailabilityRepositoryImpl) Delete(id, version uint) error {
+	return checkVersion(r.db.Where("version = ?", version).Delete(&models.UserAvailability{}, id))
 }
 
 func (r *UserAvailabilityRepositoryImpl) FindByEvent(eventID uint) ([]models.UserAvailability, error) {
 	var availabilities []models.UserAvailability
-	result := r.db.Where("event_id = ?", eventID).Find(&availabilities)
+	result := r.db.Where("event_id = ?", eventID).Order("user_id, start_time").Find(&availabilities)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return availabilities, nil
+}
+
+// FindByUser returns the user's availability across all events
+func (r *UserAvailabilityRepositoryImpl) FindByUser(userID uint) ([]models.UserAvailability, error) {
+	var availabilities []models.UserAvailability
+	result := r.db.Where("user_id = ?", userID).Order("event_id, start_time").Find(&availabilities)
 	if result.Error != nil {
 		return nil, result.Error
 	}
 	return availabilities, nil
 }
+
+// DeleteByEventID soft-deletes an event's availability, stamped with the event's deletion time
+func (r *UserAvailabilityRepositoryImpl) DeleteByEventID(eventID uint) error {
+	return r.db.Model(&models.UserAvailability{}).
+		Where("event_id = ?", eventID).
+		Update("deleted_at", r.db.Unscoped().Model(&models.Event{}).Select("deleted_at").Where("id = ?", eventID)).Error
+}
+
+// DeleteByUserID soft-deletes a user's availability, stamped with the user's deletion time
+func (r *UserAvailabilityRepositoryImpl) DeleteByUserID(userID uint) error {
+	return r.db.Model(&models.UserAvailability{}).
+		Where("user_id = ?", userID).
+		Update("deleted_at", r.db.Unscoped().Model(&models.User{}).Select("deleted_at").Where("id = ?", userID)).Error
+}
+
+// FindEventIDsBySource returns the events the user has availability from source for
+func (r *UserAvailabilityRepositoryImpl) FindEventIDsBySource(userID uint, source string) ([]uint, error) {
+	var eventIDs []uint
+	result := r.db.Model(&models.UserAvailability{}).
+		Where("user_id = ? AND source = ?", userID, source).
+		Distinct().
+		Order("event_id").
+		Pluck("event_id", &eventIDs)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return eventIDs, nil
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/krushnna/meeting-scheduler/models"
+	"gorm.io/gorm"
+)
+
+// ResourceRepository interface defines methods for rooms, equipment and their bookings
+type ResourceRepository interface {
+	Create(resource *models.Resource) error
+	FindByID(id uint) (*models.Resource, error)
+	FindPage(page PageRequest) (*Page[models.Resource], error)
+	FindSuitable(resourceID *uint, minCapacity int) ([]models.Resource, error)
+	Delete(id uint) error
+	CreateBooking(booking *models.ResourceBooking) error
+	FindBookingByID(id uint) (*models.ResourceBooking, error)
+	FindActiveBookings(resourceIDs []uint, from, to time.Time) ([]models.ResourceBooking, error)
+	DeleteBooking(id uint) error
+	DeleteBookingsByResource(resourceID uint) error
+	DeleteBookingsByEvent(eventID uint) error
+}
+
+// ResourceRepositoryImpl implements ResourceRepository
+type ResourceRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewResourceRepository(db *gorm.DB) ResourceRepository {
+	return &ResourceRepositoryImpl{db: db}
+}
+
+func (r *ResourceRepositoryImpl) Create(resource *models.Resource) error {
+	return r.db.Create(resource).Error
+}
+
+func (r *ResourceRepositoryImpl) FindByID(id uint) (*models.Resource, error) {
+	var resource models.Resource
+	result := r.db.First(&resource, id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &resource, nil
+}
+
+var resourceSortKeys = map[string]sortKey[models.Resource]{
+	"id":         {column: "id", value: func(r models.Resource) interface{} { return r.ID }},
+	"created_at": {column: "created_at", value: func(r models.Resource) interface{} { return r.CreatedAt }, isTime: true},
+	"name":       {column: "name", value: func(r models.Resource) interface{} { return r.Name }},
+	"capacity":   {column: "capacity", value: func(r models.Resource) interface{} { return r.Capacity }},
+}
+
+func (r *ResourceRepositoryImpl) FindPage(page PageRequest) (*Page[models.Resource], error) {
+	return findPage(r.db.Model(&models.Resource{}), page, resourceSortKeys, func(r models.Resource) uint { return r.ID })
+}
+
+// FindSuitable returns the resources an event can be held with, smallest first:
+// the named resource when resourceID is set, otherwise every room seating at
+// least minCapacity. A named resource must also seat minCapacity.
+func (r *ResourceRepositoryImpl) FindSuitable(resourceID *uint, minCapacity int) ([]models.Resource, error) {
+	var resources []models.Resource
+	query := r.db.Where("capacity >= ?", minCapacity)
+	if resourceID != nil {
+		query = query.Where("id = ?", *resourceID)
+	} else {
+		query = query.Where("kind = ?", models.ResourceKindRoom)
+	}
+	result := query.Order("capacity, id").Find(&resources)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return resources, nil
+}
+
+func (r *ResourceRepositoryImpl) Delete(id uint) error {
+	return r.db.Delete(&models.Resource{}, id).Error
+}
+
+func (r *ResourceRepositoryImpl) CreateBooking(booking *models.ResourceBooking) error {
+	return r.db.Create(booking).Error
+}
+
+func (r *ResourceRepositoryImpl) FindBookingByID(id uint) (*models.ResourceBooking, error) {
+	var booking models.ResourceBooking
+	result := r.db.First(&booking, id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &booking, nil
+}
+
+// FindActiveBookings returns the bookings of the resources that overlap [from, to),
+// ordered by start. An event's booking only counts while the event is live and
+// scheduled.
+func (r *ResourceRepositoryImpl) FindActiveBookings(resourceIDs []uint, from, to time.Time) ([]models.ResourceBooking, error) {
+	var bookings []models.ResourceBooking
+	if len(resourceIDs) == 0 {
+		return bookings, nil
+	}
+	result := r.db.
+		Where("resource_id IN ? AND start_time < ? AND end_time > ?", resourceIDs, to, from).
+		Where("event_id IS NULL OR EXISTS (SELECT 1 FROM events WHERE events.id = resource_bookings.event_id AND events.deleted_at IS NULL AND events.status = ?)", models.EventStatusScheduled).
+		Order("start_time, id").
+		Find(&bookings)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return bookings, nil
+}
+
+func (r *ResourceRepositoryImpl) DeleteBooking(id uint) error {
+	return r.db.Delete(&models.ResourceBooking{}, id).Error
+}
+
+func (r *ResourceRepositoryImpl) DeleteBookingsByResource(resourceID uint) error {
+	return r.db.Where("resource_id = ?", resourceID).Delete(&models.ResourceBooking{}).Error
+}
+
+func (r *ResourceRepositoryImpl) DeleteBookingsByEvent(eventID uint) error {
+	return r.db.Where("event_id = ?", eventID).Delete(&models.ResourceBooking{}).Error
+}
@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"github.com/krushnna/meeting-scheduler/models"
+	"gorm.io/gorm"
+)
+
+// EventSeriesRepository interface defines methods for recurring event series and their exceptions
+type EventSeriesRepository interface {
+	Create(series *models.EventSeries) error
+	FindByID(id uint) (*models.EventSeries, error)
+	FindPage(page PageRequest) (*Page[models.EventSeries], error)
+	FindByParticipant(userID uint) ([]models.EventSeries, error)
+	Delete(id uint) error
+	CreateException(exception *models.SeriesException) error
+	FindExceptionByID(id uint) (*models.SeriesException, error)
+	FindExceptionsBySeries(seriesID uint) ([]models.SeriesException, error)
+	DeleteException(id uint) error
+	DeleteExceptionsBySeries(seriesID uint) error
+}
+
+// EventSeriesRepositoryImpl implements EventSeriesRepository
+type EventSeriesRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewEventSeriesRepository(db *gorm.DB) EventSeriesRepository {
+	return &EventSeriesRepositoryImpl{db: db}
+}
+
+// Create stores the series and one series_participants row per participant
+func (r *EventSeriesRepositoryImpl) Create(series *models.EventSeries) error {
+	if err := r.db.Create(series).Error; err != nil {
+		return err
+	}
+	if len(series.ParticipantIDs) == 0 {
+		return nil
+	}
+	participants := make([]models.SeriesParticipant, 0, len(series.ParticipantIDs))
+	for _, userID := range series.ParticipantIDs {
+		participants = append(participants, models.SeriesParticipant{SeriesID: series.ID, UserID: userID})
+	}
+	return r.db.Create(&participants).Error
+}
+
+func (r *EventSeriesRepositoryImpl) FindByID(id uint) (*models.EventSeries, error) {
+	var series models.EventSeries
+	result := r.db.First(&series, id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	list := []models.EventSeries{series}
+	if err := r.loadParticipants(list); err != nil {
+		return nil, err
+	}
+	return &list[0], nil
+}
+
+// loadParticipants fills in ParticipantIDs for the given series with one query
+func (r *EventSeriesRepositoryImpl) loadParticipants(series []models.EventSeries) error {
+	if len(series) == 0 {
+		return nil
+	}
+	ids := make([]uint, 0, len(series))
+	for _, s := range series {
+		ids = append(ids, s.ID)
+	}
+	var participants []models.SeriesParticipant
+	if err := r.db.Where("series_id IN ?", ids).Order("series_id, user_id").Find(&participants).Error; err != nil {
+		return err
+	}
+	bySeries := make(map[uint][]uint, len(series))
+	for _, participant := range participants {
+		bySeries[participant.SeriesID] = append(bySeries[participant.SeriesID], participant.UserID)
+	}
+	for i := range series {
+		series[i].ParticipantIDs = bySeries[series[i].ID]
+	}
+	return nil
+}
+
+var seriesSortKeys = map[string]sortKey[models.EventSeries]{
+	"id":         {column: "id", value: func(s models.EventSeries) interface{} { return s.ID }},
+	"created_at": {column: "created_at", value: func(s models.EventSeries) interface{} { return s.CreatedAt }, isTime: true},
+	"title":      {column: "title", value: func(s models.EventSeries) interface{} { return s.Title }},
+	"start_time": {column: "start_time", value: func(s models.EventSeries) interface{} { return s.StartTime }, isTime: true},
+}
+
+func (r *EventSeriesRepositoryImpl) FindPage(page PageRequest) (*Page[models.EventSeries], error) {
+	result, err := findPage(r.db.Model(&models.EventSeries{}), page, seriesSortKeys, func(s models.EventSeries) uint { return s.ID })
+	if err != nil {
+		return nil, err
+	}
+	if err := r.loadParticipants(result.Items); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// FindByParticipant returns the series the user takes part in, ordered by ID
+func (r *EventSeriesRepositoryImpl) FindByParticipant(userID uint) ([]models.EventSeries, error) {
+	var series []models.EventSeries
+	result := r.db.
+		Joins("JOIN series_participants ON series_participants.series_id = event_series.id").
+		Where("series_participants.user_id = ?", userID).
+		Order("event_series.id").
+		Find(&series)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if err := r.loadParticipants(series); err != nil {
+		return nil, err
+	}
+	return series, nil
+}
+
+func (r *EventSeriesRepositoryImpl) Delete(id uint) error {
+	return r.db.Delete(&models.EventSeries{}, id).Error
+}
+
+func (r *EventSeriesRepositoryImpl) CreateException(exception *models.SeriesException) error {
+	return r.db.Create(exception).Error
+}
+
+func (r *EventSeriesRepositoryImpl) FindExceptionByID(id uint) (*models.SeriesException, error) {
+	var exception models.SeriesException
+	result := r.db.First(&exception, id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &exception, nil
+}
+
+// FindExceptionsBySeries returns the series' exceptions ordered by the occurrence they change
+func (r *EventSeriesRepositoryImpl) FindExceptionsBySeries(seriesID uint) ([]models.SeriesException, error) {
+	var exceptions []models.SeriesException
+	result := r.db.Where("series_id = ?", seriesID).Order("original_start").Find(&exceptions)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return exceptions, nil
+}
+
+func (r *EventSeriesRepositoryImpl) DeleteException(id uint) error {
+	return r.db.Delete(&models.SeriesException{}, id).Error
+}
+
+func (r *EventSeriesRepositoryImpl) DeleteExceptionsBySeries(seriesID uint) error {
+	return r.db.Where("series_id = ?", seriesID).Delete(&models.SeriesException{}).Error
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"gorm.io/gorm"
+)
+
+// Repositories groups repositories that share one database handle, so a
+// service can perform several writes inside a single transaction
+type Repositories struct {
+	Events       EventRepository
+	TimeSlots    TimeSlotRepository
+	Users        UserRepository
+	Availability UserAvailabilityRepository
+	Outbox       OutboxRepository
+	Trash        TrashRepository
+	Audit        AuditRepository
+	Rules        AvailabilityRuleRepository
+	Series       EventSeriesRepository
+	Participants EventParticipantRepository
+	Votes        SlotVoteRepository
+	Resources    ResourceRepository
+}
+
+// Transactor interface defines how services run work atomically
+type Transactor interface {
+	WithinTransaction(fn func(repos Repositories) error) error
+}
+
+// TransactorImpl implements Transactor on top of gorm transactions
+type TransactorImpl struct {
+	db *gorm.DB
+}
+
+func NewTransactor(db *gorm.DB) Transactor {
+	return &TransactorImpl{db: db}
+}
+
+// WithinTransaction commits when fn returns nil and rolls back otherwise
+func (t *TransactorImpl) WithinTransaction(fn func(repos Repositories) error) error {
+	return t.db.Transaction(func(tx *gorm.DB) error {
+		return fn(newRepositories(tx))
+	})
+}
+
+func newRepositories(db *gorm.DB) Repositories {
+	return Repositories{
+		Events:       NewEventRepository(db),
+		TimeSlots:    NewTimeSlotRepository(db),
+		Users:        NewUserRepository(db),
+		Availability: NewUserAvailabilityRepository(db),
+		Outbox:       NewOutboxRepository(db),
+		Trash:        NewTrashRepository(db),
+		Audit:        NewAuditRepository(db),
+		Rules:        NewAvailabilityRuleRepository(db),
+		Series:       NewEventSeriesRepository(db),
+		Participants: NewEventParticipantRepository(db),
+		Votes:        NewSlotVoteRepository(db),
+		Resources:    NewResourceRepository(db),
+	}
+}
@@ -0,0 +1,216 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/krushnna/meeting-scheduler/models"
+	"gorm.io/gorm"
+)
+
+// TrashRepository interface defines methods for soft-deleted records
+type TrashRepository interface {
+	FindDeletedEvents(page PageRequest) (*Page[models.Event], error)
+	FindDeletedUsers(page PageRequest) (*Page[models.User], error)
+	FindDeletedEventByID(id uint) (*models.Event, error)
+	FindDeletedUserByID(id uint) (*models.User, error)
+	RestoreEvent(id uint, deletedAt time.Time) error
+	RestoreUser(id uint, deletedAt time.Time) error
+	PurgeEvent(id uint) error
+	PurgeUser(id uint) error
+	FindEventsDeletedBefore(cutoff time.Time) ([]models.Event, error)
+	FindUsersDeletedBefore(cutoff time.Time) ([]models.User, error)
+	PurgeDeletedBefore(cutoff time.Time) (int64, error)
+}
+
+// TrashRepositoryImpl implements TrashRepository
+type TrashRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewTrashRepository(db *gorm.DB) TrashRepository {
+	return &TrashRepositoryImpl{db: db}
+}
+
+var deletedEventSortKeys = map[string]sortKey[models.Event]{
+	"id":         {column: "id", value: func(e models.Event) interface{} { return e.ID }},
+	"deleted_at": {column: "deleted_at", value: func(e models.Event) interface{} { return e.DeletedAt.Time }, isTime: true},
+	"title":      {column: "title", value: func(e models.Event) interface{} { return e.Title }},
+}
+
+var deletedUserSortKeys = map[string]sortKey[models.User]{
+	"id":         {column: "id", value: func(u models.User) interface{} { return u.ID }},
+	"deleted_at": {column: "deleted_at", value: func(u models.User) interface{} { return u.DeletedAt.Time }, isTime: true},
+	"name":       {column: "name", value: func(u models.User) interface{} { return u.Name }},
+	"email":      {column: "email", value: func(u models.User) interface{} { return u.Email }},
+}
+
+// FindDeletedEvents returns one page of soft-deleted events, most recently
+// deleted first unless page sorts otherwise
+func (r *TrashRepositoryImpl) FindDeletedEvents(page PageRequest) (*Page[models.Event], error) {
+	if page.Sort == "" {
+		page.Sort = "-deleted_at"
+	}
+	query := r.db.Unscoped().Model(&models.Event{}).Where("deleted_at IS NOT NULL")
+	return findPage(query, page, deletedEventSortKeys, func(e models.Event) uint { return e.ID })
+}
+
+// FindDeletedUsers returns one page of soft-deleted users, most recently
+// deleted first unless page sorts otherwise
+func (r *TrashRepositoryImpl) FindDeletedUsers(page PageRequest) (*Page[models.User], error) {
+	if page.Sort == "" {
+		page.Sort = "-deleted_at"
+	}
+	query := r.db.Unscoped().Model(&models.User{}).Where("deleted_at IS NOT NULL")
+	return findPage(query, page, deletedUserSortKeys, func(u models.User) uint { return u.ID })
+}
+
+func (r *TrashRepositoryImpl) FindDeletedEventByID(id uint) (*models.Event, error) {
+	var event models.Event
+	result := r.db.Unscoped().Where("deleted_at IS NOT NULL").First(&event, id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &event, nil
+}
+
+func (r *TrashRepositoryImpl) FindDeletedUserByID(id uint) (*models.User, error) {
+	var user models.User
+	result := r.db.Unscoped().Where("deleted_at IS NOT NULL").First(&user, id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &user, nil
+}
+
+// RestoreEvent undeletes the event and the time slots, availability,
+// participants and votes that were deleted along with it (those stamped with
+// the same deletion time)
+func (r *TrashRepositoryImpl) RestoreEvent(id uint, deletedAt time.Time) error {
+	if err := r.db.Unscoped().Model(&models.Event{}).Where("id = ?", id).Update("deleted_at", nil).Error; err != nil {
+		return err
+	}
+	if err := r.db.Unscoped().Model(&models.TimeSlot{}).
+		Where("event_id = ? AND deleted_at = ?", id, deletedAt).
+		Update("deleted_at", nil).Error; err != nil {
+		return err
+	}
+	for _, model := range []interface{}{&models.UserAvailability{}, &models.EventParticipant{}, &models.SlotVote{}} {
+		if err := r.db.Unscoped().Model(model).
+			Where("event_id = ? AND deleted_at = ?", id, deletedAt).
+			Where("user_id IN (?)", r.db.Model(&models.User{}).Select("id")).
+			Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RestoreUser undeletes the user and the availability, invitations and votes
+// deleted along with them, skipping those for events that are themselves still
+// deleted
+func (r *TrashRepositoryImpl) RestoreUser(id uint, deletedAt time.Time) error {
+	if err := r.db.Unscoped().Model(&models.User{}).Where("id = ?", id).Update("deleted_at", nil).Error; err != nil {
+		return err
+	}
+	for _, model := range []interface{}{&models.UserAvailability{}, &models.EventParticipant{}, &models.SlotVote{}} {
+		if err := r.db.Unscoped().Model(model).
+			Where("user_id = ? AND deleted_at = ?", id, deletedAt).
+			Where("event_id IN (?)", r.db.Model(&models.Event{}).Select("id")).
+			Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PurgeEvent permanently removes an event and everything attached to it
+func (r *TrashRepositoryImpl) PurgeEvent(id uint) error {
+	if err := r.db.Where("event_id = ?", id).Delete(&models.ScheduleChange{}).Error; err != nil {
+		return err
+	}
+	if err := r.db.Unscoped().Where("event_id = ?", id).Delete(&models.EventParticipant{}).Error; err != nil {
+		return err
+	}
+	if err := r.db.Unscoped().Where("event_id = ?", id).Delete(&models.SlotVote{}).Error; err != nil {
+		return err
+	}
+	if err := r.db.Unscoped().Where("event_id = ?", id).Delete(&models.ResourceBooking{}).Error; err != nil {
+		return err
+	}
+	if err := r.db.Unscoped().Where("event_id = ?", id).Delete(&models.UserAvailability{}).Error; err != nil {
+		return err
+	}
+	if err := r.db.Unscoped().Where("event_id = ?", id).Delete(&models.TimeSlot{}).Error; err != nil {
+		return err
+	}
+	return r.db.Unscoped().Delete(&models.Event{}, id).Error
+}
+
+// PurgeUser permanently removes a user, their availability, invitations and votes
+func (r *TrashRepositoryImpl) PurgeUser(id uint) error {
+	if err := r.db.Unscoped().Where("user_id = ?", id).Delete(&models.EventParticipant{}).Error; err != nil {
+		return err
+	}
+	if err := r.db.Unscoped().Where("user_id = ?", id).Delete(&models.SlotVote{}).Error; err != nil {
+		return err
+	}
+	if err := r.db.Unscoped().Where("user_id = ?", id).Delete(&models.UserAvailability{}).Error; err != nil {
+		return err
+	}
+	return r.db.Unscoped().Delete(&models.User{}, id).Error
+}
+
+// FindEventsDeletedBefore returns the events soft-deleted before cutoff
+func (r *TrashRepositoryImpl) FindEventsDeletedBefore(cutoff time.Time) ([]models.Event, error) {
+	var events []models.Event
+	result := r.db.Unscoped().Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).Order("id").Find(&events)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return events, nil
+}
+
+// FindUsersDeletedBefore returns the users soft-deleted before cutoff
+func (r *TrashRepositoryImpl) FindUsersDeletedBefore(cutoff time.Time) ([]models.User, error) {
+	var users []models.User
+	result := r.db.Unscoped().Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).Order("id").Find(&users)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return users, nil
+}
+
+// PurgeDeletedBefore permanently removes every record soft-deleted before cutoff,
+// children first, and returns the number of rows removed
+func (r *TrashRepositoryImpl) PurgeDeletedBefore(cutoff time.Time) (int64, error) {
+	// Schedule history and resource bookings stay live while their event is in the
+	// trash; they go with it.
+	expired := r.db.Unscoped().Model(&models.Event{}).Select("id").Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff)
+	result := r.db.Where("event_id IN (?)", expired).Delete(&models.ScheduleChange{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	purged := result.RowsAffected
+	result = r.db.Unscoped().Where("event_id IN (?)", expired).Delete(&models.ResourceBooking{})
+	if result.Error != nil {
+		return purged, result.Error
+	}
+	purged += result.RowsAffected
+	for _, model := range []interface{}{
+		&models.EventParticipant{},
+		&models.SlotVote{},
+		&models.UserAvailability{},
+		&models.TimeSlot{},
+		&models.ResourceBooking{},
+		&models.Event{},
+		&models.User{},
+		&models.Resource{},
+	} {
+		result := r.db.Unscoped().Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).Delete(model)
+		if result.Error != nil {
+			return purged, result.Error
+		}
+		purged += result.RowsAffected
+	}
+	return purged, nil
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"github.com/krushnna/meeting-scheduler/models"
+	"gorm.io/gorm"
+)
+
+// SlotVoteRepository interface defines methods for votes on time slots
+type SlotVoteRepository interface {
+	Create(vote *models.SlotVote) error
+	FindByEvent(eventID uint) ([]models.SlotVote, error)
+	FindByUserAndEvent(userID, eventID uint) ([]models.SlotVote, error)
+	FindByUser(userID uint) ([]models.SlotVote, error)
+	UpdateVote(id uint, vote string) error
+	Delete(id uint) error
+	DeleteByEventID(eventID uint) error
+	DeleteByUserID(userID uint) error
+}
+
+// SlotVoteRepositoryImpl implements SlotVoteRepository
+type SlotVoteRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewSlotVoteRepository(db *gorm.DB) SlotVoteRepository {
+	return &SlotVoteRepositoryImpl{db: db}
+}
+
+func (r *SlotVoteRepositoryImpl) Create(vote *models.SlotVote) error {
+	return r.db.Omit("User").Create(vote).Error
+}
+
+// FindByEvent returns the votes cast on the event's live time slots by live
+// users, with the voters loaded, ordered by slot and user
+func (r *SlotVoteRepositoryImpl) FindByEvent(eventID uint) ([]models.SlotVote, error) {
+	var votes []models.SlotVote
+	result := r.db.
+		Joins("JOIN users ON users.id = slot_votes.user_id AND users.deleted_at IS NULL").
+		Joins("JOIN time_slots ON time_slots.id = slot_votes.time_slot_id AND time_slots.deleted_at IS NULL").
+		Preload("User").
+		Where("slot_votes.event_id = ?", eventID).
+		Order("slot_votes.time_slot_id, slot_votes.user_id").
+		Find(&votes)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return votes, nil
+}
+
+// FindByUserAndEvent returns the user's votes on the event's live time slots
+// ordered by slot
+func (r *SlotVoteRepositoryImpl) FindByUserAndEvent(userID, eventID uint) ([]models.SlotVote, error) {
+	var votes []models.SlotVote
+	result := r.db.
+		Joins("JOIN time_slots ON time_slots.id = slot_votes.time_slot_id AND time_slots.deleted_at IS NULL").
+		Where("slot_votes.user_id = ? AND slot_votes.event_id = ?", userID, eventID).
+		Order("slot_votes.time_slot_id").
+		Find(&votes)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return votes, nil
+}
+
+// FindByUser returns the user's votes across all events
+func (r *SlotVoteRepositoryImpl) FindByUser(userID uint) ([]models.SlotVote, error) {
+	var votes []models.SlotVote
+	result := r.db.Where("user_id = ?", userID).Order("event_id, time_slot_id").Find(&votes)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return votes, nil
+}
+
+func (r *SlotVoteRepositoryImpl) UpdateVote(id uint, vote string) error {
+	return r.db.Model(&models.SlotVote{}).Where("id = ?", id).Update("vote", vote).Error
+}
+
+func (r *SlotVoteRepositoryImpl) Delete(id uint) error {
+	return r.db.Delete(&models.SlotVote{}, id).Error
+}
+
+// DeleteByEventID soft-deletes an event's votes, stamped with the event's deletion time
+func (r *SlotVoteRepositoryImpl) DeleteByEventID(eventID uint) error {
+	return r.db.Model(&models.SlotVote{}).
+		Where("event_id = ?", eventID).
+		Update("deleted_at", r.db.Unscoped().Model(&models.Event{}).Select("deleted_at").Where("id = ?", eventID)).Error
+}
+
+// DeleteByUserID soft-deletes a user's votes, stamped with the user's deletion time
+func (r *SlotVoteRepositoryImpl) DeleteByUserID(userID uint) error {
+	return r.db.Model(&models.SlotVote{}).
+		Where("user_id = ?", userID).
+		Update("deleted_at", r.db.Unscoped().Model(&models.User{}).Select("deleted_at").Where("id = ?", userID)).Error
+}
@@ -7,7 +7,9 @@ import (
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 
+	"github.com/krushnna/meeting-scheduler/config"
 	"github.com/krushnna/meeting-scheduler/controllers"
+	"github.com/krushnna/meeting-scheduler/middleware"
 	"github.com/krushnna/meeting-scheduler/repository"
 	"github.com/krushnna/meeting-scheduler/services"
 	swaggerFiles "github.com/swaggo/files"
@@ -22,23 +24,46 @@ func SetupRouter(db *gorm.DB, logger *zap.Logger) *gin.Engine {
 	timeSlotRepo := repository.NewTimeSlotRepository(db)
 	userRepo := repository.NewUserRepository(db)
 	userAvailabilityRepo := repository.NewUserAvailabilityRepository(db)
+	transactor := repository.NewTransactor(db)
 
 	// Initialize services
-	eventService := services.NewEventService(eventRepo)
-	timeSlotService := services.NewTimeSlotService(timeSlotRepo)
-	userService := services.NewUserService(userRepo)
-	availabilityService := services.NewAvailabilityService(userAvailabilityRepo)
-	recommendationService := services.NewRecommendationService(eventRepo, timeSlotRepo, userAvailabilityRepo)
+	eventService := services.NewEventService(eventRepo, transactor)
+	timeSlotService := services.NewTimeSlotService(timeSlotRepo, transactor)
+	userService := services.NewUserService(userRepo, transactor)
+	availabilityService := services.NewAvailabilityService(userAvailabilityRepo, transactor)
+	availabilityRuleService := services.NewAvailabilityRuleService(repository.NewAvailabilityRuleRepository(db), transactor)
+	seriesService := services.NewEventSeriesService(repository.NewEventSeriesRepository(db), transactor)
+	participantService := services.NewParticipantService(repository.NewEventParticipantRepository(db), transactor)
+	voteService := services.NewVoteService(repository.NewSlotVoteRepository(db), transactor)
+	resourceService := services.NewResourceService(repository.NewResourceRepository(db), transactor)
+	recommendationService := services.NewRecommendationService(transactor)
+	batchScheduleService := services.NewBatchScheduleService(recommendationService, transactor)
+	auditService := services.NewAuditService(repository.NewAuditRepository(db))
+	trashService := services.NewTrashService(repository.NewTrashRepository(db), transactor, config.LoadTrashConfig().Retention)
 
 	// Initialize controllers
 	eventController := controllers.NewEventController(eventService, logger)
 	timeSlotController := controllers.NewTimeSlotController(timeSlotService, logger)
 	userController := controllers.NewUserController(userService, logger)
 	availabilityController := controllers.NewAvailabilityController(availabilityService, logger)
+	availabilityRuleController := controllers.NewAvailabilityRuleController(availabilityRuleService, logger)
+	seriesController := controllers.NewSeriesController(seriesService, logger)
+	participantController := controllers.NewParticipantController(participantService, logger)
+	voteController := controllers.NewVoteController(voteService, logger)
+	resourceController := controllers.NewResourceController(resourceService, logger)
 	recommendationController := controllers.NewRecommendationController(recommendationService, logger)
+	batchScheduleController := controllers.NewBatchScheduleController(batchScheduleService, logger)
+	trashController := controllers.NewTrashController(trashService, logger)
+	auditController := controllers.NewAuditController(auditService, logger)
+
+	// Create endpoints honour the Idempotency-Key header
+	idempotent := middleware.Idempotency(repository.NewIdempotencyRepository(db), config.LoadIdempotencyConfig().TTL, logger)
 
 	// Create router and apply middleware
 	router := gin.Default()
+	router.Use(middleware.RequestContext())
+	router.Use(middleware.ErrorHandler(logger))
+	router.NoRoute(middleware.NoRoute)
 
 
 	// Serve docs folder for static files (if needed)
@@ -58,33 +83,102 @@ func SetupRouter(db *gorm.DB, logger *zap.Logger) *gin.Engine {
 		// Events endpoints
 		events := api.Group("/events")
 		{
-			events.POST("", eventController.CreateEvent)
+			events.POST("", idempotent, eventController.CreateEvent)
 			events.GET("", eventController.GetAllEvents)
+			events.POST("/batch-schedule", batchScheduleController.ScheduleBatch)
 			events.GET("/:id", eventController.GetEvent)
 			events.PUT("/:id", eventController.UpdateEvent)
+			events.PATCH("/:id", eventController.PatchEvent)
 			events.DELETE("/:id", eventController.DeleteEvent)
+			events.POST("/:id/finalize", eventController.FinalizeEvent)
+			events.POST("/:id/reschedule", eventController.RescheduleEvent)
 			events.GET("/:id/recommendations", recommendationController.GetRecommendations)
+			events.GET("/:id/availability", availabilityController.GetEventAvailability)
+			events.POST("/:id/participants", idempotent, participantController.InviteParticipant)
+			events.GET("/:id/participants", participantController.GetParticipants)
+			events.PUT("/:id/participants/:userId", participantController.UpdateParticipant)
+			events.PUT("/:id/participants/:userId/rsvp", participantController.RespondToEvent)
+			events.DELETE("/:id/participants/:userId", participantController.RemoveParticipant)
+			events.GET("/:id/votes", voteController.GetResults)
 
 			// TimeSlots endpoints for an event
 			timeslots := events.Group("/:id/timeslots")
 			{
-				timeslots.POST("", timeSlotController.CreateTimeSlot)
+				timeslots.POST("", idempotent, timeSlotController.CreateTimeSlot)
 				timeslots.GET("", timeSlotController.GetTimeSlotsByEvent)
 				timeslots.PUT("/:slotId", timeSlotController.UpdateTimeSlot)
+				timeslots.PATCH("/:slotId", timeSlotController.PatchTimeSlot)
 				timeslots.DELETE("/:slotId", timeSlotController.DeleteTimeSlot)
 			}
 		}
 
+		// Recurring event series endpoints
+		series := api.Group("/series")
+		{
+			series.POST("", idempotent, seriesController.CreateSeries)
+			series.GET("", seriesController.GetAllSeries)
+			series.GET("/:id", seriesController.GetSeries)
+			series.DELETE("/:id", seriesController.DeleteSeries)
+			series.GET("/:id/occurrences", seriesController.GetOccurrences)
+			series.GET("/:id/recommendations", seriesController.GetRecommendations)
+			series.POST("/:id/exceptions", idempotent, seriesController.CreateException)
+			series.GET("/:id/exceptions", seriesController.GetExceptions)
+			series.DELETE("/:id/exceptions/:exceptionId", seriesController.DeleteException)
+		}
+
+		// Rooms, equipment and their bookings
+		resources := api.Group("/resources")
+		{
+			resources.POST("", idempotent, resourceController.CreateResource)
+			resources.GET("", resourceController.GetAllResources)
+			resources.GET("/:id", resourceController.GetResource)
+			resources.DELETE("/:id", resourceController.DeleteResource)
+			resources.POST("/:id/bookings", idempotent, resourceController.CreateBooking)
+			resources.GET("/:id/bookings", resourceController.GetBookings)
+			resources.DELETE("/:id/bookings/:bookingId", resourceController.DeleteBooking)
+		}
+
 		// Users endpoints
 		users := api.Group("/users")
 		{
-			users.POST("", userController.CreateUser)
+			users.POST("", idempotent, userController.CreateUser)
 			users.GET("", userController.GetAllUsers)
 			users.GET("/:id", userController.GetUser)
 			users.PUT("/:id", userController.UpdateUser)
+			users.PATCH("/:id", userController.PatchUser)
 			users.DELETE("/:id", userController.DeleteUser)
-			users.POST("/:id/events/:eventId/availability", availabilityController.CreateAvailability)
+			users.GET("/:id/conflicts", userController.GetConflicts)
+			users.POST("/:id/events/:eventId/availability", idempotent, availabilityController.CreateAvailability)
 			users.GET("/:id/events/:eventId/availability", availabilityController.GetUserAvailability)
+			users.PUT("/:id/events/:eventId/availability", availabilityController.ReplaceAvailability)
+			users.POST("/:id/events/:eventId/availability/batch", idempotent, availabilityController.SubmitAvailability)
+			users.PUT("/:id/events/:eventId/availability/:availId", availabilityController.UpdateAvailability)
+			users.DELETE("/:id/events/:eventId/availability/:availId", availabilityController.DeleteAvailability)
+			users.PUT("/:id/events/:eventId/votes", voteController.SubmitVotes)
+			users.GET("/:id/events/:eventId/votes", voteController.GetUserVotes)
+
+			// Recurring weekly availability
+			users.POST("/:id/availability-rules", idempotent, availabilityRuleController.CreateRule)
+			users.GET("/:id/availability-rules", availabilityRuleController.GetRules)
+			users.DELETE("/:id/availability-rules/:ruleId", availabilityRuleController.DeleteRule)
+			users.POST("/:id/availability-exceptions", idempotent, availabilityRuleController.CreateException)
+			users.GET("/:id/availability-exceptions", availabilityRuleController.GetExceptions)
+			users.DELETE("/:id/availability-exceptions/:exceptionId", availabilityRuleController.DeleteException)
+		}
+
+		// Audit trail of every mutation
+		api.GET("/audit", auditController.GetAuditLogs)
+
+		// Admin endpoints for soft-deleted records
+		trash := api.Group("/admin/trash")
+		{
+			trash.GET("/events", trashController.GetDeletedEvents)
+			trash.POST("/events/:id/restore", trashController.RestoreEvent)
+			trash.DELETE("/events/:id", trashController.PurgeEvent)
+			trash.GET("/users", trashController.GetDeletedUsers)
+			trash.POST("/users/:id/restore", trashController.RestoreUser)
+			trash.DELETE("/users/:id", trashController.PurgeUser)
+			trash.POST("/purge", trashController.PurgeExpired)
 		}
 	}
 
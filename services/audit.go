@@ -0,0 +1,120 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+
+	"github.com/krushnna/meeting-scheduler/models"
+	"github.com/krushnna/meeting-scheduler/repository"
+	"github.com/krushnna/meeting-scheduler/utils"
+)
+
+// Audit actions
+const (
+	AuditCreate  = "create"
+	AuditUpdate  = "update"
+	AuditDelete  = "delete"
+	AuditRestore = "restore"
+	AuditPurge   = "purge"
+)
+
+// Entity types that only appear in the audit log
+const (
+	AggregateUser                  = "user"
+	AggregateAvailabilityRule      = "availability_rule"
+	AggregateAvailabilityException = "availability_exception"
+	AggregateEventSeries           = "event_series"
+	AggregateSeriesException       = "series_exception"
+	AggregateSlotVote              = "slot_vote"
+	AggregateResource              = "resource"
+	AggregateResourceBooking       = "resource_booking"
+)
+
+// bookkeepingFields change on every write and are left out of audit diffs
+var bookkeepingFields = map[string]bool{
+	"ID":        true,
+	"CreatedAt": true,
+	"UpdatedAt": true,
+	"DeletedAt": true,
+	"version":   true,
+}
+
+// recordAudit appends an audit entry attributed to the actor and request in ctx.
+// before is nil for creates and after is nil for deletes.
+func recordAudit(ctx context.Context, audit repository.AuditRepository, action, entityType string, entityID uint, before, after interface{}) error {
+	changes, err := diffStates(before, after)
+	if err != nil {
+		return err
+	}
+
+	meta := utils.RequestMetaFrom(ctx)
+	return audit.Create(&models.AuditLog{
+		Actor:      meta.Actor,
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Changes:    changes,
+		RequestID:  meta.RequestID,
+	})
+}
+
+// diffStates compares the JSON representations of two states field by field
+func diffStates(before, after interface{}) (map[string]models.FieldChange, error) {
+	from, err := toFieldMap(before)
+	if err != nil {
+		return nil, err
+	}
+	to, err := toFieldMap(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(map[string]models.FieldChange)
+	for field, value := range from {
+		if bookkeepingFields[field] {
+			continue
+		}
+		if next, ok := to[field]; !ok || !reflect.DeepEqual(value, next) {
+			changes[field] = models.FieldChange{From: value, To: to[field]}
+		}
+	}
+	for field, value := range to {
+		if _, ok := from[field]; !ok && !bookkeepingFields[field] {
+			changes[field] = models.FieldChange{To: value}
+		}
+	}
+	return changes, nil
+}
+
+func toFieldMap(state interface{}) (map[string]interface{}, error) {
+	fields := make(map[string]interface{})
+	if state == nil {
+		return fields, nil
+	}
+	if v := reflect.ValueOf(state); v.Kind() == reflect.Ptr && v.IsNil() {
+		return fields, nil
+	}
+	body, err := json.Marshal(state)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// AuditService exposes the audit trail
+type AuditService struct {
+	repo repository.AuditRepository
+}
+
+func NewAuditService(repo repository.AuditRepository) *AuditService {
+	return &AuditService{repo: repo}
+}
+
+// GetAuditLogs returns one page of the entries matching filter
+func (s *AuditService) GetAuditLogs(filter repository.AuditFilter, page repository.PageRequest) (*repository.Page[models.AuditLog], error) {
+	return s.repo.FindPage(filter, page)
+}
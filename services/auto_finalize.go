@@ -0,0 +1,212 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/krushnna/meeting-scheduler/models"
+	"github.com/krushnna/meeting-scheduler/repository"
+	"github.com/krushnna/meeting-scheduler/utils"
+	"go.uber.org/zap"
+)
+
+// AutoFinalizer applies events' auto-finalize policies. Once an event is due it
+// is scheduled at the best recommended start that still suits every invitee; if
+// there is none the event stays in polling with a no_viable_option outcome. The
+// rationale for either outcome is stored on the event.
+type AutoFinalizer struct {
+	repo            repository.EventRepository
+	recommendations *RecommendationService
+	tx              repository.Transactor
+	logger          *zap.Logger
+	interval        time.Duration
+}
+
+func NewAutoFinalizer(repo repository.EventRepository, recommendations *RecommendationService, tx repository.Transactor, interval time.Duration, logger *zap.Logger) *AutoFinalizer {
+	return &AutoFinalizer{
+		repo:            repo,
+		recommendations: recommendations,
+		tx:              tx,
+		logger:          logger.With(zap.String("component", "auto_finalize")),
+		interval:        interval,
+	}
+}
+
+// Run applies due policies until ctx is cancelled
+func (f *AutoFinalizer) Run(ctx context.Context) {
+	ticker := time.NewTicker(f.interval)
+	defer ticker.Stop()
+
+	for {
+		if decided, err := f.ApplyDue(ctx, time.Now()); err != nil {
+			f.logger.Error("Failed to auto-finalize events", zap.Error(err))
+		} else if decided > 0 {
+			f.logger.Info("Auto-finalized events", zap.Int("count", decided))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ApplyDue decides every event whose policy is due at now and returns how many
+// were decided. Events waiting for responses that have not all arrived are left
+// for a later pass.
+func (f *AutoFinalizer) ApplyDue(ctx context.Context, now time.Time) (int, error) {
+	events, err := f.repo.FindAutoFinalizeDue(now)
+	if err != nil {
+		return 0, err
+	}
+
+	ctx = utils.WithRequestMeta(ctx, utils.RequestMeta{Actor: utils.SystemActor})
+	decided := 0
+	for i := range events {
+		ok, err := f.apply(ctx, &events[i], now)
+		if err != nil {
+			f.logger.Error("Failed to auto-finalize event", zap.Uint("event_id", events[i].ID), zap.Error(err))
+			continue
+		}
+		if ok {
+			decided++
+		}
+	}
+	return decided, nil
+}
+
+// apply decides one event, reporting false when it is not due yet
+func (f *AutoFinalizer) apply(ctx context.Context, event *models.Event, now time.Time) (bool, error) {
+	trigger := "the response deadline passed"
+	if event.ResponseDeadline == nil || now.Before(*event.ResponseDeadline) {
+		responded, err := f.requiredResponded(event.ID)
+		if err != nil || !responded {
+			return false, err
+		}
+		trigger = "every required participant responded"
+	}
+
+	// Recommendations open their own transactions, so they are computed first.
+	recommendations, err := f.recommendations.GetRecommendations(event.ID)
+	if err != nil {
+		return false, err
+	}
+
+	decided := false
+	err = f.tx.WithinTransaction(func(r repository.Repositories) error {
+		before, err := r.Events.FindByID(event.ID)
+		if err != nil {
+			return err
+		}
+		// Someone may have finalized the event or decided it since it was listed.
+		if before.Status != models.EventStatusPolling || before.AutoFinalizeOutcome != "" {
+			return nil
+		}
+		decided = true
+
+		var skipped []string
+		for _, recommendation := range recommendations {
+			if !recommendation.QuorumMet {
+				skipped = append(skipped, fmt.Sprintf("%s (below quorum)", recommendation.StartOptions[0].UTC().Format(time.RFC3339)))
+				continue
+			}
+			for _, start := range recommendation.StartOptions {
+				attendees := len(recommendation.MatchingUsers)
+				invitees := attendees + len(recommendation.NonMatchingUsers) + len(recommendation.NonResponders)
+				decision := *before
+				decision.AutoFinalizeOutcome = models.AutoFinalizeOutcomeFinalized
+				decision.AutoFinalizeRationale = fmt.Sprintf("%s; picked %s, where %d of %d invitees (%.0f%%) can attend",
+					trigger, start.UTC().Format(time.RFC3339), attendees, invitees, recommendation.MatchingPercentage)
+				if len(skipped) > 0 {
+					decision.AutoFinalizeRationale += "; skipped " + strings.Join(skipped, "; ")
+				}
+				decision.AutoFinalizeDecidedAt = &now
+
+				_, err := scheduleEvent(ctx, r, before, &decision, start, nil)
+				var conflict *ConflictError
+				var invalid *ValidationError
+				if errors.As(err, &conflict) || errors.As(err, &invalid) {
+					skipped = append(skipped, fmt.Sprintf("%s (%s)", start.UTC().Format(time.RFC3339), err.Error()))
+					continue
+				}
+				return err
+			}
+		}
+
+		reason := "no start time suits any invitee"
+		if len(skipped) > 0 {
+			reason = "every recommended start was rejected: " + strings.Join(skipped, "; ")
+		}
+		decision := *before
+		decision.AutoFinalizeOutcome = models.AutoFinalizeOutcomeNoOption
+		decision.AutoFinalizeRationale = fmt.Sprintf("%s but %s; the event stays in polling", trigger, reason)
+		decision.AutoFinalizeDecidedAt = &now
+		if err := r.Events.Save(&decision); err != nil {
+			return err
+		}
+		updated, err := r.Events.FindByID(before.ID)
+		if err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, r.Audit, AuditUpdate, AggregateEvent, before.ID, before, updated); err != nil {
+			return err
+		}
+		return recordDomainEvent(r.Outbox, EventAutoFinalizeFailed, AggregateEvent, before.ID, updated)
+	})
+	return decided, err
+}
+
+// requiredResponded reports whether every required participant of the event has
+// answered, accepting or declining the invitation. Without required participants
+// every invitee counts; an event nobody is invited to never qualifies.
+func (f *AutoFinalizer) requiredResponded(eventID uint) (bool, error) {
+	var participants []models.EventParticipant
+	err := f.tx.WithinTransaction(func(r repository.Repositories) error {
+		var err error
+		participants, err = r.Participants.FindByEvent(eventID)
+		return err
+	})
+	if err != nil {
+		return false, err
+	}
+
+	var required []models.EventParticipant
+	for _, participant := range participants {
+		if participant.Required {
+			required = append(required, participant)
+		}
+	}
+	if len(required) == 0 {
+		required = participants
+	}
+	for _, participant := range required {
+		if participant.Status == models.ParticipantStatusInvited {
+			return false, nil
+		}
+	}
+	return len(required) > 0, nil
+}
+
+// keepAutoFinalizeOutcome stops clients from writing the worker's decision by
+// carrying it over from before
+func keepAutoFinalizeOutcome(before, event *models.Event) {
+	event.AutoFinalizeOutcome = before.AutoFinalizeOutcome
+	event.AutoFinalizeRationale = before.AutoFinalizeRationale
+	event.AutoFinalizeDecidedAt = before.AutoFinalizeDecidedAt
+}
+
+// autoFinalizeChanged reports whether event sets a different deadline or policy
+// than before, which makes the worker consider the event again. Zero values
+// count as unchanged, since updates leave them alone.
+func autoFinalizeChanged(before, event *models.Event) bool {
+	if event.AutoFinalize != "" && event.AutoFinalize != before.AutoFinalize {
+		return true
+	}
+	if event.ResponseDeadline == nil {
+		return false
+	}
+	return before.ResponseDeadline == nil || !event.ResponseDeadline.Equal(*before.ResponseDeadline)
+}
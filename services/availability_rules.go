@@ -0,0 +1,318 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/krushnna/meeting-scheduler/models"
+	"github.com/krushnna/meeting-scheduler/repository"
+)
+
+const localDateLayout = "2006-01-02"
+
+// AvailabilityRuleService handles recurring availability rules, which are
+// materialized into availability for the events their user is invited to
+type AvailabilityRuleService struct {
+	repo repository.AvailabilityRuleRepository
+	tx   repository.Transactor
+}
+
+func NewAvailabilityRuleService(repo repository.AvailabilityRuleRepository, tx repository.Transactor) *AvailabilityRuleService {
+	return &AvailabilityRuleService{repo: repo, tx: tx}
+}
+
+// CreateRule adds a weekly rule and refreshes the availability materialized from the user's rules
+func (s *AvailabilityRuleService) CreateRule(ctx context.Context, rule *models.AvailabilityRule) error {
+	if err := validateRule(rule); err != nil {
+		return err
+	}
+	return s.tx.WithinTransaction(func(r repository.Repositories) error {
+		if _, err := r.Users.FindByID(rule.UserID); err != nil {
+			return notFound(err, "user", rule.UserID)
+		}
+		if err := r.Rules.CreateRule(rule); err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, r.Audit, AuditCreate, AggregateAvailabilityRule, rule.ID, nil, rule); err != nil {
+			return err
+		}
+		return refreshRuleAvailability(ctx, r, rule.UserID)
+	})
+}
+
+func (s *AvailabilityRuleService) GetRules(userID uint) ([]models.AvailabilityRule, error) {
+	var rules []models.AvailabilityRule
+	err := s.tx.WithinTransaction(func(r repository.Repositories) error {
+		if _, err := r.Users.FindByID(userID); err != nil {
+			return notFound(err, "user", userID)
+		}
+		var err error
+		rules, err = r.Rules.FindRulesByUser(userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// DeleteRule removes one of the user's rules and refreshes their materialized availability
+func (s *AvailabilityRuleService) DeleteRule(ctx context.Context, userID, id uint) error {
+	return s.tx.WithinTransaction(func(r repository.Repositories) error {
+		before, err := r.Rules.FindRuleByID(id)
+		if err != nil {
+			return notFound(err, "availability rule", id)
+		}
+		if before.UserID != userID {
+			return &NotFoundError{Resource: "availability rule", ID: id}
+		}
+		if err := r.Rules.DeleteRule(id); err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, r.Audit, AuditDelete, AggregateAvailabilityRule, id, before, nil); err != nil {
+			return err
+		}
+		return refreshRuleAvailability(ctx, r, userID)
+	})
+}
+
+// CreateException adds an exception and refreshes the availability materialized from the user's rules
+func (s *AvailabilityRuleService) CreateException(ctx context.Context, exception *models.AvailabilityException) error {
+	if err := validateException(exception); err != nil {
+		return err
+	}
+	return s.tx.WithinTransaction(func(r repository.Repositories) error {
+		if _, err := r.Users.FindByID(exception.UserID); err != nil {
+			return notFound(err, "user", exception.UserID)
+		}
+		if err := r.Rules.CreateException(exception); err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, r.Audit, AuditCreate, AggregateAvailabilityException, exception.ID, nil, exception); err != nil {
+			return err
+		}
+		return refreshRuleAvailability(ctx, r, exception.UserID)
+	})
+}
+
+func (s *AvailabilityRuleService) GetExceptions(userID uint) ([]models.AvailabilityException, error) {
+	var exceptions []models.AvailabilityException
+	err := s.tx.WithinTransaction(func(r repository.Repositories) error {
+		if _, err := r.Users.FindByID(userID); err != nil {
+			return notFound(err, "user", userID)
+		}
+		var err error
+		exceptions, err = r.Rules.FindExceptionsByUser(userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return exceptions, nil
+}
+
+// DeleteException removes one of the user's exceptions and refreshes their materialized availability
+func (s *AvailabilityRuleService) DeleteException(ctx context.Context, userID, id uint) error {
+	return s.tx.WithinTransaction(func(r repository.Repositories) error {
+		before, err := r.Rules.FindExceptionByID(id)
+		if err != nil {
+			return notFound(err, "availability exception", id)
+		}
+		if before.UserID != userID {
+			return &NotFoundError{Resource: "availability exception", ID: id}
+		}
+		if err := r.Rules.DeleteException(id); err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, r.Audit, AuditDelete, AggregateAvailabilityException, id, before, nil); err != nil {
+			return err
+		}
+		return refreshRuleAvailability(ctx, r, userID)
+	})
+}
+
+// refreshRuleAvailability re-materializes the user's availability for every event
+// they are invited to or already have rule-based availability for
+func refreshRuleAvailability(ctx context.Context, r repository.Repositories, userID uint) error {
+	eventIDs, err := r.Availability.FindEventIDsBySource(userID, models.AvailabilitySourceRule)
+	if err != nil {
+		return err
+	}
+	invited, err := r.Participants.FindEventIDsByUser(userID)
+	if err != nil {
+		return err
+	}
+	seen := make(map[uint]bool)
+	for _, eventID := range append(eventIDs, invited...) {
+		if seen[eventID] {
+			continue
+		}
+		seen[eventID] = true
+		if err := materializeAvailability(ctx, r, userID, eventID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// refreshEventRuleAvailability re-materializes every invitee's availability for
+// an event, after its time slots change
+func refreshEventRuleAvailability(ctx context.Context, r repository.Repositories, eventID uint) error {
+	participants, err := r.Participants.FindByEvent(eventID)
+	if err != nil {
+		return err
+	}
+	for _, participant := range participants {
+		if participant.Status == models.ParticipantStatusDeclined {
+			continue
+		}
+		if err := materializeAvailability(ctx, r, participant.UserID, eventID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// materializeAvailability replaces the user's rule-based availability for an event
+// with their rules expanded over the event's time slots. Availability the user
+// entered by hand takes precedence, so the event is left alone if there is any,
+// and so is an event that is no longer polling.
+func materializeAvailability(ctx context.Context, r repository.Repositories, userID, eventID uint) error {
+	event, err := r.Events.FindByID(eventID)
+	if err != nil {
+		return notFound(err, "event", eventID)
+	}
+	if event.Status != models.EventStatusPolling {
+		return nil
+	}
+	user, err := r.Users.FindByID(userID)
+	if err != nil {
+		return notFound(err, "user", userID)
+	}
+	location, err := time.LoadLocation(user.Timezone)
+	if err != nil {
+		return &ValidationError{Field: "timezone", Message: fmt.Sprintf("user timezone %q is not a known time zone", user.Timezone)}
+	}
+
+	existing, err := r.Availability.FindByUserAndEvent(userID, eventID)
+	if err != nil {
+		return err
+	}
+	for _, availability := range existing {
+		if availability.Source != models.AvailabilitySourceRule {
+			return nil
+		}
+	}
+
+	slots, err := r.TimeSlots.FindByEventID(eventID)
+	if err != nil {
+		return err
+	}
+	rules, err := r.Rules.FindRulesByUser(userID)
+	if err != nil {
+		return err
+	}
+	exceptions, err := r.Rules.FindExceptionsByUser(userID)
+	if err != nil {
+		return err
+	}
+
+	windows := make([]interval, 0, len(slots))
+	for _, slot := range slots {
+		windows = append(windows, interval{Start: slot.StartTime, End: slot.EndTime})
+	}
+	intervals := expandRules(rules, exceptions, location, mergeIntervals(windows))
+	_, err = syncAvailability(ctx, r, userID, eventID, models.AvailabilitySourceRule, existing, intervals)
+	return err
+}
+
+// expandRules turns weekly rules into concrete intervals within windows, minus the
+// exceptions. Each local day is built with time.Date in the user's location, so
+// rules keep their wall-clock times across DST transitions.
+func expandRules(rules []models.AvailabilityRule, exceptions []models.AvailabilityException, location *time.Location, windows []interval) []interval {
+	var available []interval
+	for _, window := range windows {
+		local := window.Start.In(location)
+		for day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, location); day.Before(window.End); day = day.AddDate(0, 0, 1) {
+			for _, rule := range rules {
+				if time.Weekday(rule.Weekday) != day.Weekday() {
+					continue
+				}
+				start, end := onLocalDay(day, rule.StartTime), onLocalDay(day, rule.EndTime)
+				if start.Before(window.Start) {
+					start = window.Start
+				}
+				if end.After(window.End) {
+					end = window.End
+				}
+				if start.Before(end) {
+					available = append(available, interval{Start: start, End: end})
+				}
+			}
+		}
+	}
+
+	var blocked []interval
+	for _, exception := range exceptions {
+		day, err := time.ParseInLocation(localDateLayout, exception.Date, location)
+		if err != nil {
+			continue
+		}
+		if exception.StartTime == "" {
+			blocked = append(blocked, interval{Start: day, End: day.AddDate(0, 0, 1)})
+			continue
+		}
+		blocked = append(blocked, interval{Start: onLocalDay(day, exception.StartTime), End: onLocalDay(day, exception.EndTime)})
+	}
+	return subtractIntervals(mergeIntervals(available), mergeIntervals(blocked))
+}
+
+// onLocalDay returns the instant a validated "HH:MM" clock time falls on day
+func onLocalDay(day time.Time, clock string) time.Time {
+	hour, minute, _ := parseClock(clock)
+	return time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, day.Location())
+}
+
+// parseClock parses an "HH:MM" wall-clock time from 00:00 to 24:00
+func parseClock(clock string) (int, int, error) {
+	if clock == "24:00" {
+		return 24, 0, nil
+	}
+	parsed, err := time.Parse("15:04", clock)
+	if err != nil || len(clock) != len("15:04") {
+		return 0, 0, fmt.Errorf("invalid clock time %q", clock)
+	}
+	return parsed.Hour(), parsed.Minute(), nil
+}
+
+// validateClockRange checks start and end are "HH:MM" times with start first
+func validateClockRange(start, end string) error {
+	if _, _, err := parseClock(start); err != nil {
+		return &ValidationError{Field: "start_time", Message: "start time must be an HH:MM time"}
+	}
+	if _, _, err := parseClock(end); err != nil {
+		return &ValidationError{Field: "end_time", Message: "end time must be an HH:MM time up to 24:00"}
+	}
+	if start >= end {
+		return &ValidationError{Field: "end_time", Message: "start time must be before end time"}
+	}
+	return nil
+}
+
+func validateRule(rule *models.AvailabilityRule) error {
+	if rule.Weekday < 0 || rule.Weekday > 6 {
+		return &ValidationError{Field: "weekday", Message: "weekday must be between 0 (Sunday) and 6 (Saturday)"}
+	}
+	return validateClockRange(rule.StartTime, rule.EndTime)
+}
+
+func validateException(exception *models.AvailabilityException) error {
+	if _, err := time.Parse(localDateLayout, exception.Date); err != nil {
+		return &ValidationError{Field: "date", Message: "date must be a YYYY-MM-DD date"}
+	}
+	if exception.StartTime == "" && exception.EndTime == "" {
+		return nil
+	}
+	return validateClockRange(exception.StartTime, exception.EndTime)
+}
@@ -0,0 +1,451 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/krushnna/meeting-scheduler/models"
+	"github.com/krushnna/meeting-scheduler/repository"
+)
+
+const (
+	// MaxBatchEvents caps how many events one batch can schedule
+	MaxBatchEvents = 20
+	// batchRestarts is how many random starting points the search tries after
+	// the greedy one
+	batchRestarts = 50
+)
+
+// BatchScheduleOptions lists the events to place together and how they relate.
+// Timezone decides what same_day means and defaults to UTC. With Apply set the
+// assignment found is finalized in one transaction.
+type BatchScheduleOptions struct {
+	EventIDs    []uint
+	Constraints []models.BatchConstraint
+	Timezone    string
+	Apply       bool
+}
+
+// BatchScheduleService places several polling events at once so that no shared
+// invitee is double-booked and the batch's constraints hold
+type BatchScheduleService struct {
+	recommendations *RecommendationService
+	tx              repository.Transactor
+}
+
+func NewBatchScheduleService(recommendations *RecommendationService, tx repository.Transactor) *BatchScheduleService {
+	return &BatchScheduleService{recommendations: recommendations, tx: tx}
+}
+
+// batchCandidate is a start an event could be scheduled at, who could attend and,
+// for events that need one, the resource it would hold
+type batchCandidate struct {
+	start, end time.Time
+	attendees  []models.User
+	resource   *models.Resource
+}
+
+// batchRelation must hold between the candidates picked for the events it
+// lists; ok gets them in the same order
+type batchRelation struct {
+	events []int
+	ok     func(picked []batchCandidate) bool
+}
+
+// pairRelation is a relation between the candidates picked for events a and b
+func pairRelation(a, b int, ok func(x, y batchCandidate) bool) batchRelation {
+	return batchRelation{events: []int{a, b}, ok: func(picked []batchCandidate) bool {
+		return ok(picked[0], picked[1])
+	}}
+}
+
+// batchInvitee is a user invited to events of the batch with the commitments
+// and timezone their buffers and daily cap are counted against
+type batchInvitee struct {
+	user        models.User
+	location    *time.Location
+	commitments []models.Commitment
+}
+
+// ScheduleBatch finds start times for every event that maximize the number of
+// attendees over the batch. Shared invitees' events are kept apart by their
+// buffers and stay within their daily caps together with their commitments, and
+// each event needing a resource is assigned one no overlapping event holds.
+// Starts clashing with an invitee's existing commitments or below an event's
+// quorum are never picked, so the assignment can be finalized as is. The search
+// is a best-response local search from the greedy assignment and from seeded
+// random restarts; it fails with 409 when it finds no assignment without
+// violations.
+func (s *BatchScheduleService) ScheduleBatch(ctx context.Context, options BatchScheduleOptions) (*models.BatchSchedule, error) {
+	location, err := validateBatch(options)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([][]batchCandidate, len(options.EventIDs))
+	invitees := make([]map[uint]bool, len(options.EventIDs))
+	users := make(map[uint]*batchInvitee)
+	needsResources := make([]bool, len(options.EventIDs))
+	index := make(map[uint]int, len(options.EventIDs))
+	for i, eventID := range options.EventIDs {
+		index[eventID] = i
+		// Recommendations open their own transactions, so inputs are loaded one
+		// event at a time outside of ours.
+		in, err := s.recommendations.loadInputs(eventID)
+		if err != nil {
+			return nil, err
+		}
+		if in.event.Status != models.EventStatusPolling {
+			return nil, &ConflictError{Message: fmt.Sprintf("event %d is %s; only polling events can be scheduled", eventID, in.event.Status)}
+		}
+		needsResources[i] = in.needsResource
+		invitees[i] = make(map[uint]bool)
+		for _, user := range append(append([]models.User{}, in.users...), in.nonResponders...) {
+			invitees[i][user.ID] = true
+			users[user.ID] = mergeInvitee(users[user.ID], in, user)
+		}
+		if candidates[i] = batchCandidates(in); len(candidates[i]) == 0 {
+			return nil, &ConflictError{Message: fmt.Sprintf("event %d has no start time an invitee can attend without a clash", eventID)}
+		}
+	}
+
+	relations := batchRelations(options, index, invitees, users, needsResources, location)
+	assignment, ok := searchBatch(candidates, relations)
+	if !ok {
+		return nil, &ConflictError{Message: "no assignment keeps shared invitees within their buffers and daily caps and satisfies every constraint"}
+	}
+
+	schedule := &models.BatchSchedule{Assignments: make([]models.BatchAssignment, len(options.EventIDs))}
+	for i, eventID := range options.EventIDs {
+		picked := candidates[i][assignment[i]]
+		schedule.Assignments[i] = models.BatchAssignment{
+			EventID:   eventID,
+			StartTime: picked.start,
+			EndTime:   picked.end,
+			Attendees: picked.attendees,
+			Invitees:  len(invitees[i]),
+		}
+		if picked.resource != nil {
+			schedule.Assignments[i].ResourceID = &picked.resource.ID
+		}
+		schedule.TotalAttendance += len(picked.attendees)
+	}
+	if !options.Apply {
+		return schedule, nil
+	}
+
+	err = s.tx.WithinTransaction(func(r repository.Repositories) error {
+		for _, assigned := range schedule.Assignments {
+			before, err := r.Events.FindByID(assigned.EventID)
+			if err != nil {
+				return notFound(err, "event", assigned.EventID)
+			}
+			event := *before
+			updated, err := scheduleEvent(ctx, r, before, &event, assigned.StartTime, assigned.ResourceID)
+			if err != nil {
+				return err
+			}
+			schedule.Events = append(schedule.Events, *updated)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	schedule.Applied = true
+	return schedule, nil
+}
+
+// validateBatch checks the events and constraints of a batch and returns the
+// location same_day is judged in
+func validateBatch(options BatchScheduleOptions) (*time.Location, error) {
+	if len(options.EventIDs) == 0 {
+		return nil, &ValidationError{Field: "event_ids", Message: "at least one event is required"}
+	}
+	if len(options.EventIDs) > MaxBatchEvents {
+		return nil, &ValidationError{Field: "event_ids", Message: fmt.Sprintf("at most %d events can be scheduled together", MaxBatchEvents)}
+	}
+	inBatch := make(map[uint]bool, len(options.EventIDs))
+	for i, eventID := range options.EventIDs {
+		if inBatch[eventID] {
+			return nil, &ValidationError{Field: fmt.Sprintf("event_ids[%d]", i), Message: "events can only be listed once"}
+		}
+		inBatch[eventID] = true
+	}
+
+	for i, constraint := range options.Constraints {
+		switch constraint.Type {
+		case models.ConstraintOrder, models.ConstraintMinGap, models.ConstraintSameDay:
+		default:
+			return nil, &ValidationError{Field: fmt.Sprintf("constraints[%d].type", i), Message: "type must be order, min_gap or same_day"}
+		}
+		if !inBatch[constraint.First] || !inBatch[constraint.Second] {
+			return nil, &ValidationError{Field: fmt.Sprintf("constraints[%d]", i), Message: "constraints can only relate events of the batch"}
+		}
+		if constraint.First == constraint.Second {
+			return nil, &ValidationError{Field: fmt.Sprintf("constraints[%d].second", i), Message: "a constraint relates two different events"}
+		}
+		if constraint.MinGapMinutes < 0 {
+			return nil, &ValidationError{Field: fmt.Sprintf("constraints[%d].min_gap_minutes", i), Message: "min_gap_minutes can't be negative"}
+		}
+	}
+
+	location := time.UTC
+	if options.Timezone != "" {
+		loaded, err := time.LoadLocation(options.Timezone)
+		if err != nil {
+			return nil, &ValidationError{Field: "timezone", Message: fmt.Sprintf("unknown timezone %q", options.Timezone)}
+		}
+		location = loaded
+	}
+	return location, nil
+}
+
+// batchCandidates lists the starts an event could be scheduled at, best attended
+// first. Every start the event's recommendations would consider is a candidate,
+// unless nobody can attend it, it falls short of the event's quorum, it clashes
+// with an invitee's commitments or the event needs a resource and none is free.
+// Events needing a resource get one candidate per free resource, smallest first.
+func batchCandidates(in *recommendationInputs) []batchCandidate {
+	duration := time.Duration(in.event.DurationMinutes) * time.Minute
+	var candidates []batchCandidate
+	seen := make(map[int64]bool)
+	add := func(start time.Time, attendees []models.User) {
+		end := start.Add(duration)
+		if len(attendees) == 0 || seen[start.Unix()] || in.clashes(start, end) || in.quorumShortfall(attendees) != "" {
+			return
+		}
+		seen[start.Unix()] = true
+		if !in.needsResource {
+			candidates = append(candidates, batchCandidate{start: start, end: end, attendees: attendees})
+			return
+		}
+		resources := in.freeResources(start, end)
+		for i := range resources {
+			candidates = append(candidates, batchCandidate{start: start, end: end, attendees: attendees, resource: &resources[i]})
+		}
+	}
+
+	if in.event.RecommendationStrategy == models.StrategyVotes {
+		for _, recommendation := range voteRecommendations(in) {
+			add(recommendation.TimeSlot.StartTime, recommendation.MatchingUsers)
+		}
+	} else {
+		for _, slot := range in.slots {
+			for start := slot.StartTime; !start.Add(duration).After(slot.EndTime); start = start.Add(15 * time.Minute) {
+				attendees, _ := in.attendance(start, start.Add(duration))
+				add(start, attendees)
+			}
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if len(candidates[i].attendees) != len(candidates[j].attendees) {
+			return len(candidates[i].attendees) > len(candidates[j].attendees)
+		}
+		return candidates[i].start.Before(candidates[j].start)
+	})
+	return candidates
+}
+
+// mergeInvitee adds what an event's inputs know about user to what the earlier
+// events of the batch did. Commitments are loaded around each event's slots, so
+// the union covers every day the batch can touch.
+func mergeInvitee(invitee *batchInvitee, in *recommendationInputs, user models.User) *batchInvitee {
+	if invitee == nil {
+		invitee = &batchInvitee{user: user, location: in.location(user)}
+	}
+	type key struct {
+		eventID, seriesID uint
+		start             time.Time
+	}
+	seen := make(map[key]bool, len(invitee.commitments))
+	for _, commitment := range invitee.commitments {
+		seen[key{commitment.EventID, commitment.SeriesID, commitment.StartTime}] = true
+	}
+	for _, commitment := range in.busy[user.ID] {
+		if k := (key{commitment.EventID, commitment.SeriesID, commitment.StartTime}); !seen[k] {
+			seen[k] = true
+			invitee.commitments = append(invitee.commitments, commitment)
+		}
+	}
+	return invitee
+}
+
+// batchRelations turns shared invitees, shared resources and the batch's
+// constraints into the relations the picked candidates must satisfy
+func batchRelations(options BatchScheduleOptions, index map[uint]int, invitees []map[uint]bool, users map[uint]*batchInvitee, needsResources []bool, location *time.Location) []batchRelation {
+	var relations []batchRelation
+	for a := range invitees {
+		for b := a + 1; b < len(invitees); b++ {
+			// Overlapping events that need resources can't hold the same one.
+			if needsResources[a] && needsResources[b] {
+				relations = append(relations, pairRelation(a, b, func(x, y batchCandidate) bool {
+					return x.resource.ID != y.resource.ID || !x.start.Before(y.end) || !y.start.Before(x.end)
+				}))
+			}
+			// Events sharing invitees are kept apart by the largest buffer any
+			// of them keeps on either side.
+			shared, gap := false, time.Duration(0)
+			for userID := range invitees[a] {
+				if !invitees[b][userID] {
+					continue
+				}
+				shared = true
+				user := users[userID].user
+				gap = max(gap, time.Duration(max(user.BufferBeforeMinutes, user.BufferAfterMinutes))*time.Minute)
+			}
+			if shared {
+				relations = append(relations, pairRelation(a, b, func(x, y batchCandidate) bool {
+					return !x.end.Add(gap).After(y.start) || !y.end.Add(gap).After(x.start)
+				}))
+			}
+		}
+	}
+
+	// A capped invitee's events must fit their daily cap together; candidates
+	// already fit it on their own.
+	for _, invitee := range users {
+		if invitee.user.MaxDailyMeetingMinutes == 0 {
+			continue
+		}
+		var events []int
+		for i := range invitees {
+			if invitees[i][invitee.user.ID] {
+				events = append(events, i)
+			}
+		}
+		if len(events) > 1 {
+			relations = append(relations, batchRelation{events: events, ok: invitee.withinDailyCap})
+		}
+	}
+
+	for _, constraint := range options.Constraints {
+		gap := time.Duration(constraint.MinGapMinutes) * time.Minute
+		first, second := index[constraint.First], index[constraint.Second]
+		switch constraint.Type {
+		case models.ConstraintOrder:
+			relations = append(relations, pairRelation(first, second, func(first, second batchCandidate) bool {
+				return !first.end.Add(gap).After(second.start)
+			}))
+		case models.ConstraintMinGap:
+			relations = append(relations, pairRelation(first, second, func(x, y batchCandidate) bool {
+				return !x.end.Add(gap).After(y.start) || !y.end.Add(gap).After(x.start)
+			}))
+		case models.ConstraintSameDay:
+			relations = append(relations, pairRelation(first, second, func(x, y batchCandidate) bool {
+				xYear, xMonth, xDay := x.start.In(location).Date()
+				yYear, yMonth, yDay := y.start.In(location).Date()
+				return xYear == yYear && xMonth == yMonth && xDay == yDay
+			}))
+		}
+	}
+	return relations
+}
+
+// withinDailyCap reports whether the invitee's picked events fit their daily cap
+// on every day they touch, counting each against the others and the commitments
+func (invitee *batchInvitee) withinDailyCap(picked []batchCandidate) bool {
+	for i, candidate := range picked {
+		commitments := append([]models.Commitment(nil), invitee.commitments...)
+		for j, other := range picked {
+			if j != i {
+				commitments = append(commitments, models.Commitment{StartTime: other.start, EndTime: other.end})
+			}
+		}
+		if !withinDailyCap(invitee.user, invitee.location, commitments, candidate.start, candidate.end) {
+			return false
+		}
+	}
+	return true
+}
+
+// searchBatch picks a candidate index per event. Violated relations weigh more
+// than any attendance, so the search first removes violations and then adds
+// attendees. It reports false when every run ended with violations.
+func searchBatch(candidates [][]batchCandidate, relations []batchRelation) ([]int, bool) {
+	involving := make([][]int, len(candidates))
+	weight := 1
+	for _, options := range candidates {
+		weight += len(options[0].attendees)
+	}
+	for r, relation := range relations {
+		for _, i := range relation.events {
+			involving[i] = append(involving[i], r)
+		}
+	}
+	// picked returns the candidates the relation's events hold, with event i
+	// holding candidate c
+	picked := func(relation batchRelation, assignment []int, i, c int) []batchCandidate {
+		held := make([]batchCandidate, len(relation.events))
+		for k, e := range relation.events {
+			if e == i {
+				held[k] = candidates[i][c]
+			} else {
+				held[k] = candidates[e][assignment[e]]
+			}
+		}
+		return held
+	}
+
+	// cost is the score event i contributes with candidate c given the others
+	cost := func(assignment []int, i, c int) int {
+		score := len(candidates[i][c].attendees)
+		for _, r := range involving[i] {
+			if !relations[r].ok(picked(relations[r], assignment, i, c)) {
+				score -= weight
+			}
+		}
+		return score
+	}
+	improve := func(assignment []int) {
+		for improved := true; improved; {
+			improved = false
+			for i := range candidates {
+				best, bestScore := assignment[i], cost(assignment, i, assignment[i])
+				for c := range candidates[i] {
+					if score := cost(assignment, i, c); score > bestScore {
+						best, bestScore = c, score
+					}
+				}
+				if best != assignment[i] {
+					assignment[i] = best
+					improved = true
+				}
+			}
+		}
+	}
+	evaluate := func(assignment []int) (int, bool) {
+		for _, relation := range relations {
+			if !relation.ok(picked(relation, assignment, -1, 0)) {
+				return 0, false
+			}
+		}
+		attendance := 0
+		for i, c := range assignment {
+			attendance += len(candidates[i][c].attendees)
+		}
+		return attendance, true
+	}
+
+	// A fixed seed keeps the answer the same for the same inputs.
+	random := rand.New(rand.NewSource(1))
+	var best []int
+	bestAttendance := -1
+	assignment := make([]int, len(candidates))
+	for run := 0; run <= batchRestarts; run++ {
+		if run > 0 {
+			for i := range assignment {
+				assignment[i] = random.Intn(len(candidates[i]))
+			}
+		}
+		improve(assignment)
+		if attendance, ok := evaluate(assignment); ok && attendance > bestAttendance {
+			best, bestAttendance = append([]int(nil), assignment...), attendance
+		}
+	}
+	return best, best != nil
+}
@@ -0,0 +1,56 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// ValidationError reports an entity that breaks a business rule
+type ValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+// NotFoundError reports a record that does not exist
+type NotFoundError struct {
+	Resource string
+	ID       uint
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("%s %d not found", e.Resource, e.ID)
+}
+
+// ConflictError reports a change that clashes with the current state of other records
+type ConflictError struct {
+	Message string
+}
+
+func (e *ConflictError) Error() string {
+	return e.Message
+}
+
+// ErrEmailInUse is returned when a user would take an email that a live user already has
+var ErrEmailInUse = &ConflictError{Message: "email is already used by another user"}
+
+// notFound reports a missing record as a NotFoundError
+func notFound(err error, resource string, id uint) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &NotFoundError{Resource: resource, ID: id}
+	}
+	return err
+}
+
+// emailTaken reports a unique email violation as ErrEmailInUse
+func emailTaken(err error) error {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return ErrEmailInUse
+	}
+	return err
+}
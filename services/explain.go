@@ -0,0 +1,183 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/krushnna/meeting-scheduler/models"
+	"github.com/krushnna/meeting-scheduler/repository"
+)
+
+// loadWorkingHours expands each responder's weekly availability rules over the
+// event's time slots. Users without rules have no working hours to be outside of.
+func (in *recommendationInputs) loadWorkingHours(r repository.Repositories) error {
+	in.workingHours = make(map[uint][]interval)
+	windows := make([]interval, 0, len(in.slots))
+	for _, slot := range in.slots {
+		windows = append(windows, interval{Start: slot.StartTime, End: slot.EndTime})
+	}
+	windows = mergeIntervals(windows)
+	for _, user := range in.users {
+		rules, err := r.Rules.FindRulesByUser(user.ID)
+		if err != nil {
+			return err
+		}
+		if len(rules) == 0 {
+			continue
+		}
+		exceptions, err := r.Rules.FindExceptionsByUser(user.ID)
+		if err != nil {
+			return err
+		}
+		in.workingHours[user.ID] = expandRules(rules, exceptions, in.location(user), windows)
+	}
+	return nil
+}
+
+// explainStart lists the invitees other than attendees who can't make a meeting
+// from start to end and why
+func (in *recommendationInputs) explainStart(start, end time.Time, attendees []models.User, noResource bool) models.StartExplanation {
+	explanation := models.StartExplanation{
+		StartTime:  start,
+		EndTime:    end,
+		Attendees:  len(attendees),
+		QuorumMet:  in.quorumShortfall(attendees) == "",
+		NoResource: noResource,
+		Conflicted: in.clashes(start, end),
+		Excluded:   []models.ExcludedUser{},
+	}
+	attending := make(map[uint]bool, len(attendees))
+	for _, user := range attendees {
+		attending[user.ID] = true
+	}
+	for _, user := range in.users {
+		if attending[user.ID] {
+			continue
+		}
+		var reasons []models.ExclusionReason
+		if in.event.RecommendationStrategy == models.StrategyVotes {
+			reasons = in.voteReasons(user, start, end)
+		} else {
+			reasons = in.availabilityReasons(user, start, end)
+		}
+		explanation.Excluded = append(explanation.Excluded, models.ExcludedUser{User: user, Reasons: reasons})
+	}
+	for _, user := range in.nonResponders {
+		reasons := []models.ExclusionReason{{
+			Reason: models.ExclusionNotResponded,
+			Detail: "has not submitted availability or votes yet",
+		}}
+		explanation.Excluded = append(explanation.Excluded, models.ExcludedUser{User: user, Reasons: append(reasons, in.conflictReasons(user, start, end)...)})
+	}
+	return explanation
+}
+
+// availabilityReasons explains why a responder isn't available for a meeting
+// from start to end
+func (in *recommendationInputs) availabilityReasons(user models.User, start, end time.Time) []models.ExclusionReason {
+	var reasons []models.ExclusionReason
+	covered := false
+	var best time.Duration
+	for _, avail := range in.availability[user.ID] {
+		if !start.Before(avail.StartTime) && !end.After(avail.EndTime) {
+			covered = true
+			break
+		}
+		if partial := overlap(start, end, avail.StartTime, avail.EndTime); partial > best {
+			best = partial
+		}
+	}
+	if !covered {
+		if best > 0 {
+			reasons = append(reasons, models.ExclusionReason{
+				Reason:         models.ExclusionPartialOverlap,
+				Detail:         fmt.Sprintf("available for %d of the %d minutes", int(best.Minutes()), int(end.Sub(start).Minutes())),
+				OverlapMinutes: int(best.Minutes()),
+			})
+		} else {
+			reasons = append(reasons, models.ExclusionReason{
+				Reason: models.ExclusionNoAvailability,
+				Detail: "no availability during the meeting",
+			})
+		}
+		if hours, ok := in.workingHours[user.ID]; ok && !containedIn(hours, start, end) {
+			reasons = append(reasons, models.ExclusionReason{
+				Reason: models.ExclusionOutsideWorkingHours,
+				Detail: "outside their weekly working hours",
+			})
+		}
+	}
+
+	return append(reasons, in.limitReasons(user, start, end)...)
+}
+
+// limitReasons explains why a meeting from start to end doesn't fit around the
+// user's commitments, buffers and daily cap
+func (in *recommendationInputs) limitReasons(user models.User, start, end time.Time) []models.ExclusionReason {
+	commitments := in.busy[user.ID]
+	reasons := in.conflictReasons(user, start, end)
+	if len(reasons) == 0 {
+		if commitment := bufferClash(user, commitments, start, end); commitment != nil {
+			reasons = append(reasons, models.ExclusionReason{
+				Reason: models.ExclusionBuffer,
+				Detail: fmt.Sprintf("keeps %d minutes before and %d after meetings free, too close to %s",
+					user.BufferBeforeMinutes, user.BufferAfterMinutes, describeCommitment(*commitment)),
+				Commitment: commitment,
+			})
+		}
+	}
+	if !withinDailyCap(user, in.location(user), commitments, start, end) {
+		reasons = append(reasons, models.ExclusionReason{
+			Reason: models.ExclusionDailyCap,
+			Detail: fmt.Sprintf("would go over their cap of %d meeting minutes a day", user.MaxDailyMeetingMinutes),
+		})
+	}
+	return reasons
+}
+
+// conflictReasons lists the user's commitments overlapping start to end
+func (in *recommendationInputs) conflictReasons(user models.User, start, end time.Time) []models.ExclusionReason {
+	var reasons []models.ExclusionReason
+	commitments := in.busy[user.ID]
+	for i, commitment := range commitments {
+		if start.Before(commitment.EndTime) && end.After(commitment.StartTime) {
+			reasons = append(reasons, models.ExclusionReason{
+				Reason:     models.ExclusionConflict,
+				Detail:     "busy with " + describeCommitment(commitment),
+				Commitment: &commitments[i],
+			})
+		}
+	}
+	return reasons
+}
+
+// voteReasons explains why a responder isn't counted for a meeting from start
+// to end under the votes strategy: they voted no, didn't vote, or voted for it
+// but can't fit it around their commitments and limits
+func (in *recommendationInputs) voteReasons(user models.User, start, end time.Time) []models.ExclusionReason {
+	for _, vote := range in.votes {
+		if vote.UserID != user.ID {
+			continue
+		}
+		for _, slot := range in.slots {
+			if slot.ID != vote.TimeSlotID || start.Before(slot.StartTime) || end.After(slot.EndTime) {
+				continue
+			}
+			if vote.Vote == models.VoteNo {
+				return []models.ExclusionReason{{Reason: models.ExclusionVotedNo, Detail: "voted no on the slot"}}
+			}
+			return in.limitReasons(user, start, end)
+		}
+	}
+	return []models.ExclusionReason{{Reason: models.ExclusionNoVote, Detail: "has not voted on the slot"}}
+}
+
+// containedIn reports whether one of intervals covers start to end
+func containedIn(intervals []interval, start, end time.Time) bool {
+	for _, window := range intervals {
+		if !start.Before(window.Start) && !end.After(window.End) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+package services
+
+import (
+	"sort"
+	"time"
+
+	"github.com/krushnna/meeting-scheduler/models"
+)
+
+// interval is a half-open time range [Start, End)
+type interval struct {
+	Start time.Time
+	End   time.Time
+}
+
+func availabilityIntervals(availabilities []models.UserAvailability) []interval {
+	intervals := make([]interval, 0, len(availabilities))
+	for _, availability := range availabilities {
+		intervals = append(intervals, interval{Start: availability.StartTime, End: availability.EndTime})
+	}
+	return intervals
+}
+
+// mergeIntervals sorts intervals and joins the ones that overlap or touch, so
+// the result is the smallest set of disjoint ranges covering the same time
+func mergeIntervals(intervals []interval) []interval {
+	if len(intervals) == 0 {
+		return nil
+	}
+	sorted := make([]interval, len(intervals))
+	for i, iv := range intervals {
+		sorted[i] = interval{Start: iv.Start.UTC(), End: iv.End.UTC()}
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Start.Before(sorted[j].Start) })
+
+	merged := []interval{sorted[0]}
+	for _, iv := range sorted[1:] {
+		last := &merged[len(merged)-1]
+		if iv.Start.After(last.End) {
+			merged = append(merged, iv)
+			continue
+		}
+		if iv.End.After(last.End) {
+			last.End = iv.End
+		}
+	}
+	return merged
+}
+
+// subtractIntervals removes the time covered by blocked from intervals. Both
+// must be sorted and disjoint, as returned by mergeIntervals.
+func subtractIntervals(intervals, blocked []interval) []interval {
+	var result []interval
+	for _, iv := range intervals {
+		current := iv
+		for _, b := range blocked {
+			if !b.End.After(current.Start) {
+				continue
+			}
+			if !b.Start.Before(current.End) {
+				break
+			}
+			if b.Start.After(current.Start) {
+				result = append(result, interval{Start: current.Start, End: b.Start})
+			}
+			current.Start = b.End
+			if !current.Start.Before(current.End) {
+				break
+			}
+		}
+		if current.Start.Before(current.End) {
+			result = append(result, current)
+		}
+	}
+	return result
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"time"
+
+	"github.com/krushnna/meeting-scheduler/models"
+)
+
+// MaxBufferMinutes caps the buffer a user can keep before or after a meeting
+const MaxBufferMinutes = 240
+
+// limitsWindow is how far beyond the slots commitments are loaded, so buffers
+// and whole days in any timezone around a start are covered
+const limitsWindow = 24 * time.Hour
+
+// withinLimits reports whether a meeting from start to end respects the user's
+// buffers and daily cap given their commitments
+func withinLimits(user models.User, location *time.Location, commitments []models.Commitment, start, end time.Time) bool {
+	return bufferClash(user, commitments, start, end) == nil && withinDailyCap(user, location, commitments, start, end)
+}
+
+// bufferClash returns the first commitment the meeting, widened by the user's
+// buffers, overlaps, or nil when there is none
+func bufferClash(user models.User, commitments []models.Commitment, start, end time.Time) *models.Commitment {
+	before := start.Add(-time.Duration(user.BufferBeforeMinutes) * time.Minute)
+	after := end.Add(time.Duration(user.BufferAfterMinutes) * time.Minute)
+	for i, commitment := range commitments {
+		if before.Before(commitment.EndTime) && after.After(commitment.StartTime) {
+			return &commitments[i]
+		}
+	}
+	return nil
+}
+
+// withinDailyCap reports whether the meeting and the user's commitments on each
+// local day it touches fit into the user's daily cap
+func withinDailyCap(user models.User, location *time.Location, commitments []models.Commitment, start, end time.Time) bool {
+	if user.MaxDailyMeetingMinutes == 0 {
+		return true
+	}
+	limit := time.Duration(user.MaxDailyMeetingMinutes) * time.Minute
+	year, month, day := start.In(location).Date()
+	for dayStart := time.Date(year, month, day, 0, 0, 0, 0, location); dayStart.Before(end); dayStart = dayStart.AddDate(0, 0, 1) {
+		dayEnd := dayStart.AddDate(0, 0, 1)
+		total := overlap(start, end, dayStart, dayEnd)
+		for _, commitment := range commitments {
+			total += overlap(commitment.StartTime, commitment.EndTime, dayStart, dayEnd)
+		}
+		if total > limit {
+			return false
+		}
+	}
+	return true
+}
+
+// overlap returns how long [start, end) and [from, to) overlap
+func overlap(start, end, from, to time.Time) time.Duration {
+	if start.Before(from) {
+		start = from
+	}
+	if end.After(to) {
+		end = to
+	}
+	if !start.Before(end) {
+		return 0
+	}
+	return end.Sub(start)
+}
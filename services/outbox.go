@@ -0,0 +1,218 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/krushnna/meeting-scheduler/models"
+	"github.com/krushnna/meeting-scheduler/repository"
+	"go.uber.org/zap"
+)
+
+// Domain event types written to the outbox
+const (
+	EventCreated            = "event.created"
+	EventUpdated            = "event.updated"
+	EventDeleted            = "event.deleted"
+	EventRestored           = "event.restored"
+	EventPurged             = "event.purged"
+	EventFinalized          = "event.finalized"
+	EventReopened           = "event.reopened"
+	EventRescheduled        = "event.rescheduled"
+	EventAutoFinalizeFailed = "event.auto_finalize_failed"
+	TimeSlotCreated         = "timeslot.created"
+	TimeSlotUpdated         = "timeslot.updated"
+	TimeSlotDeleted         = "timeslot.deleted"
+	AvailabilityCreated     = "availability.created"
+	AvailabilityUpdated     = "availability.updated"
+	AvailabilityDeleted     = "availability.deleted"
+	ParticipantInvited      = "participant.invited"
+	ParticipantUpdated      = "participant.updated"
+	ParticipantRemoved      = "participant.removed"
+	ParticipantRSVP         = "participant.rsvp"
+	VotesSubmitted          = "votes.submitted"
+)
+
+// Aggregate types used on outbox rows
+const (
+	AggregateEvent        = "event"
+	AggregateTimeSlot     = "timeslot"
+	AggregateAvailability = "availability"
+	AggregateParticipant  = "event_participant"
+)
+
+// recordDomainEvent appends a domain event to the outbox. It must be called with
+// the outbox repository of the transaction performing the change.
+func recordDomainEvent(outbox repository.OutboxRepository, eventType, aggregateType string, aggregateID uint, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal %s payload: %w", eventType, err)
+	}
+	return outbox.Create(&models.OutboxEvent{
+		EventType:     eventType,
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		Payload:       string(body),
+		Status:        models.OutboxStatusPending,
+		NextAttemptAt: time.Now(),
+	})
+}
+
+// Notifier delivers a domain event to an external integration
+type Notifier interface {
+	Notify(ctx context.Context, event models.OutboxEvent) error
+}
+
+// WebhookNotifier posts domain events as JSON to a URL. The outbox ID is sent
+// as the Idempotency-Key header so receivers can drop redeliveries.
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookNotifier(url string, timeout time.Duration) *WebhookNotifier {
+	return &WebhookNotifier{url: url, client: &http.Client{Timeout: timeout}}
+}
+
+func (n *WebhookNotifier) Notify(ctx context.Context, event models.OutboxEvent) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"id":             event.ID,
+		"type":           event.EventType,
+		"aggregate_type": event.AggregateType,
+		"aggregate_id":   event.AggregateID,
+		"occurred_at":    event.CreatedAt,
+		"data":           json.RawMessage(event.Payload),
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", strconv.FormatUint(uint64(event.ID), 10))
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook %s responded with status %d", n.url, resp.StatusCode)
+	}
+	return nil
+}
+
+// LogNotifier writes domain events to the application log
+type LogNotifier struct {
+	logger *zap.Logger
+}
+
+func NewLogNotifier(logger *zap.Logger) *LogNotifier {
+	return &LogNotifier{logger: logger}
+}
+
+func (n *LogNotifier) Notify(ctx context.Context, event models.OutboxEvent) error {
+	n.logger.Info("Domain event",
+		zap.Uint("outbox_id", event.ID),
+		zap.String("type", event.EventType),
+		zap.String("aggregate_type", event.AggregateType),
+		zap.Uint("aggregate_id", event.AggregateID),
+	)
+	return nil
+}
+
+// OutboxDispatcher drains pending outbox rows to the configured notifiers.
+// Delivery is at-least-once: a row is marked dispatched only after every
+// notifier accepted it, and failed rows are retried with backoff.
+type OutboxDispatcher struct {
+	repo        repository.OutboxRepository
+	notifiers   []Notifier
+	logger      *zap.Logger
+	interval    time.Duration
+	lease       time.Duration
+	batchSize   int
+	maxAttempts int
+}
+
+func NewOutboxDispatcher(repo repository.OutboxRepository, notifiers []Notifier, interval time.Duration, logger *zap.Logger) *OutboxDispatcher {
+	return &OutboxDispatcher{
+		repo:        repo,
+		notifiers:   notifiers,
+		logger:      logger.With(zap.String("component", "outbox")),
+		interval:    interval,
+		lease:       time.Minute,
+		batchSize:   100,
+		maxAttempts: 10,
+	}
+}
+
+// Run polls the outbox until ctx is cancelled
+func (d *OutboxDispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := d.DispatchPending(ctx); err != nil {
+			d.logger.Error("Failed to dispatch outbox", zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchPending delivers one batch of due events and returns how many were dispatched
+func (d *OutboxDispatcher) DispatchPending(ctx context.Context) (int, error) {
+	events, err := d.repo.ClaimPending(time.Now(), d.lease, d.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	dispatched := 0
+	for _, event := range events {
+		if err := d.deliver(ctx, event); err != nil {
+			d.handleFailure(event, err)
+			continue
+		}
+		if err := d.repo.MarkDispatched(event.ID, time.Now()); err != nil {
+			return dispatched, err
+		}
+		dispatched++
+	}
+	return dispatched, nil
+}
+
+func (d *OutboxDispatcher) deliver(ctx context.Context, event models.OutboxEvent) error {
+	for _, notifier := range d.notifiers {
+		if err := notifier.Notify(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *OutboxDispatcher) handleFailure(event models.OutboxEvent, cause error) {
+	attempts := event.Attempts + 1
+	var err error
+	if attempts >= d.maxAttempts {
+		d.logger.Error("Giving up on outbox event", zap.Uint("outbox_id", event.ID), zap.Int("attempts", attempts), zap.Error(cause))
+		err = d.repo.MarkFailed(event.ID, attempts, cause.Error())
+	} else {
+		backoff := time.Duration(attempts*attempts) * d.interval
+		d.logger.Warn("Outbox delivery failed, will retry", zap.Uint("outbox_id", event.ID), zap.Int("attempts", attempts), zap.Duration("backoff", backoff), zap.Error(cause))
+		err = d.repo.MarkRetry(event.ID, attempts, cause.Error(), time.Now().Add(backoff))
+	}
+	if err != nil {
+		d.logger.Error("Failed to record outbox failure", zap.Uint("outbox_id", event.ID), zap.Error(err))
+	}
+}
@@ -0,0 +1,298 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/krushnna/meeting-scheduler/models"
+	"github.com/krushnna/meeting-scheduler/repository"
+	"gorm.io/gorm"
+)
+
+// ParticipantService handles business logic for event invitees
+type ParticipantService struct {
+	repo repository.EventParticipantRepository
+	tx   repository.Transactor
+}
+
+func NewParticipantService(repo repository.EventParticipantRepository, tx repository.Transactor) *ParticipantService {
+	return &ParticipantService{repo: repo, tx: tx}
+}
+
+// InviteParticipant adds a user to an event's invitee list and materializes their
+// availability rules for it. A user who already submitted availability or votes
+// for the event, or whose rules cover some of it, is recorded as responded. The
+// RSVP always starts out pending.
+func (s *ParticipantService) InviteParticipant(ctx context.Context, participant *models.EventParticipant) error {
+	return s.tx.WithinTransaction(func(r repository.Repositories) error {
+		if _, err := r.Events.FindByID(participant.EventID); err != nil {
+			return notFound(err, "event", participant.EventID)
+		}
+		user, err := r.Users.FindByID(participant.UserID)
+		if err != nil {
+			return notFound(err, "user", participant.UserID)
+		}
+		if _, err := r.Participants.FindByEventAndUser(participant.EventID, participant.UserID); err == nil {
+			return &ConflictError{Message: fmt.Sprintf("user %d is already invited to event %d", participant.UserID, participant.EventID)}
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		responded, err := hasResponded(r, participant.UserID, participant.EventID)
+		if err != nil {
+			return err
+		}
+		participant.Status = models.ParticipantStatusInvited
+		if responded {
+			participant.Status = models.ParticipantStatusResponded
+		}
+		participant.RSVP, participant.RSVPAt = models.RSVPPending, nil
+		if err := r.Participants.Create(participant); err != nil {
+			return err
+		}
+		participant.User = user
+		if err := recordAudit(ctx, r.Audit, AuditCreate, AggregateParticipant, participant.ID, nil, participant); err != nil {
+			return err
+		}
+		if err := recordDomainEvent(r.Outbox, ParticipantInvited, AggregateParticipant, participant.ID, participant); err != nil {
+			return err
+		}
+
+		// The invitee's weekly rules answer for them until they respond by hand.
+		if err := materializeAvailability(ctx, r, participant.UserID, participant.EventID); err != nil {
+			return err
+		}
+		current, err := r.Participants.FindByEventAndUser(participant.EventID, participant.UserID)
+		if err != nil {
+			return err
+		}
+		participant.Status = current.Status
+		return nil
+	})
+}
+
+// GetParticipants returns an event's invitees ordered by user
+func (s *ParticipantService) GetParticipants(eventID uint) ([]models.EventParticipant, error) {
+	participants := []models.EventParticipant{}
+	err := s.tx.WithinTransaction(func(r repository.Repositories) error {
+		if _, err := r.Events.FindByID(eventID); err != nil {
+			return notFound(err, "event", eventID)
+		}
+		found, err := r.Participants.FindByEvent(eventID)
+		if err != nil {
+			return err
+		}
+		participants = append(participants, found...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return participants, nil
+}
+
+// UpdateParticipant declines an invitation or takes a decline back, and sets
+// whether the participant is required when required is not nil. Only "declined"
+// and "invited" can be set as status; taking a decline back restores responded
+// when the user has availability or votes for the event. An empty status keeps
+// the current one.
+func (s *ParticipantService) UpdateParticipant(ctx context.Context, eventID, userID uint, status string, required *bool) (*models.EventParticipant, error) {
+	if status == "" && required == nil {
+		return nil, &ValidationError{Field: "status", Message: "status or required must be set"}
+	}
+	if status != "" && status != models.ParticipantStatusDeclined && status != models.ParticipantStatusInvited {
+		return nil, &ValidationError{Field: "status", Message: "status must be declined or invited"}
+	}
+
+	var updated *models.EventParticipant
+	err := s.tx.WithinTransaction(func(r repository.Repositories) error {
+		before, err := r.Participants.FindByEventAndUser(eventID, userID)
+		if err != nil {
+			return notFound(err, "participant", userID)
+		}
+		if status == models.ParticipantStatusInvited {
+			responded, err := hasResponded(r, userID, eventID)
+			if err != nil {
+				return err
+			}
+			if responded {
+				status = models.ParticipantStatusResponded
+			}
+		}
+		if status != "" {
+			if err := r.Participants.UpdateStatus(before.ID, status); err != nil {
+				return err
+			}
+		}
+		if required != nil && *required != before.Required {
+			if err := r.Participants.UpdateRequired(before.ID, *required); err != nil {
+				return err
+			}
+		}
+		if updated, err = r.Participants.FindByEventAndUser(eventID, userID); err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, r.Audit, AuditUpdate, AggregateParticipant, before.ID, before, updated); err != nil {
+			return err
+		}
+		return recordDomainEvent(r.Outbox, ParticipantUpdated, AggregateParticipant, before.ID, updated)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// RespondToEvent records a participant's RSVP to a scheduled event's confirmed
+// time. When a required participant declines and the event has AutoReschedule
+// set, the event goes back into polling.
+func (s *ParticipantService) RespondToEvent(ctx context.Context, eventID, userID uint, response string) (*models.EventParticipant, error) {
+	if response != models.RSVPAccepted && response != models.RSVPTentative && response != models.RSVPDeclined {
+		return nil, &ValidationError{Field: "response", Message: "response must be accepted, tentative or declined"}
+	}
+
+	var updated *models.EventParticipant
+	err := s.tx.WithinTransaction(func(r repository.Repositories) error {
+		event, err := r.Events.FindByID(eventID)
+		if err != nil {
+			return notFound(err, "event", eventID)
+		}
+		if event.Status != models.EventStatusScheduled {
+			return &ConflictError{Message: fmt.Sprintf("event is %s; only scheduled events take RSVPs", event.Status)}
+		}
+		before, err := r.Participants.FindByEventAndUser(eventID, userID)
+		if err != nil {
+			return notFound(err, "participant", userID)
+		}
+		if err := r.Participants.UpdateRSVP(before.ID, response, time.Now()); err != nil {
+			return err
+		}
+		if updated, err = r.Participants.FindByEventAndUser(eventID, userID); err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, r.Audit, AuditUpdate, AggregateParticipant, before.ID, before, updated); err != nil {
+			return err
+		}
+		if err := recordDomainEvent(r.Outbox, ParticipantRSVP, AggregateParticipant, before.ID, updated); err != nil {
+			return err
+		}
+
+		if response == models.RSVPDeclined && updated.Required && event.AutoReschedule {
+			_, err = reopenEvent(ctx, r, event, EventReopened, fmt.Sprintf("required participant %d declined", userID))
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// RemoveParticipant takes a user off an event's invitee list along with the
+// availability and votes they submitted for it
+func (s *ParticipantService) RemoveParticipant(ctx context.Context, eventID, userID uint) error {
+	return s.tx.WithinTransaction(func(r repository.Repositories) error {
+		before, err := r.Participants.FindByEventAndUser(eventID, userID)
+		if err != nil {
+			return notFound(err, "participant", userID)
+		}
+		if err := r.Participants.Delete(before.ID); err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, r.Audit, AuditDelete, AggregateParticipant, before.ID, before, nil); err != nil {
+			return err
+		}
+		if err := recordDomainEvent(r.Outbox, ParticipantRemoved, AggregateParticipant, before.ID, map[string]uint{"id": before.ID}); err != nil {
+			return err
+		}
+
+		if _, err := replaceVotes(ctx, r, userID, eventID, nil); err != nil {
+			return err
+		}
+		existing, err := r.Availability.FindByUserAndEvent(userID, eventID)
+		if err != nil {
+			return err
+		}
+		_, err = syncAvailability(ctx, r, userID, eventID, "", existing, nil)
+		return err
+	})
+}
+
+// hasResponded reports whether the user submitted availability or votes for the event
+func hasResponded(r repository.Repositories, userID, eventID uint) (bool, error) {
+	availability, err := r.Availability.FindByUserAndEvent(userID, eventID)
+	if err != nil || len(availability) > 0 {
+		return len(availability) > 0, err
+	}
+	votes, err := r.Votes.FindByUserAndEvent(userID, eventID)
+	if err != nil {
+		return false, err
+	}
+	return len(votes) > 0, nil
+}
+
+// syncParticipation keeps a user's participant status in step with their
+// availability and votes for an event: responding invites them if needed and
+// marks them responded, and withdrawing every answer returns them to invited.
+// Declined invitees stay declined.
+func syncParticipation(ctx context.Context, r repository.Repositories, userID, eventID uint) error {
+	responded, err := hasResponded(r, userID, eventID)
+	if err != nil {
+		return err
+	}
+	before, err := r.Participants.FindByEventAndUser(eventID, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		if !responded {
+			return nil
+		}
+		participant := models.EventParticipant{EventID: eventID, UserID: userID, Status: models.ParticipantStatusResponded}
+		if err := r.Participants.Create(&participant); err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, r.Audit, AuditCreate, AggregateParticipant, participant.ID, nil, &participant); err != nil {
+			return err
+		}
+		return recordDomainEvent(r.Outbox, ParticipantInvited, AggregateParticipant, participant.ID, &participant)
+	}
+	if err != nil {
+		return err
+	}
+
+	status := models.ParticipantStatusInvited
+	if responded {
+		status = models.ParticipantStatusResponded
+	}
+	if before.Status == models.ParticipantStatusDeclined || before.Status == status {
+		return nil
+	}
+	if err := r.Participants.UpdateStatus(before.ID, status); err != nil {
+		return err
+	}
+	updated := *before
+	updated.Status = status
+	if err := recordAudit(ctx, r.Audit, AuditUpdate, AggregateParticipant, before.ID, before, &updated); err != nil {
+		return err
+	}
+	return recordDomainEvent(r.Outbox, ParticipantUpdated, AggregateParticipant, before.ID, &updated)
+}
+
+// eventInvitees splits an event's invitees who have not declined into those who
+// have responded and those who have not
+func eventInvitees(r repository.Repositories, eventID uint) (responders, nonResponders []models.User, err error) {
+	participants, err := r.Participants.FindByEvent(eventID)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, participant := range participants {
+		switch participant.Status {
+		case models.ParticipantStatusResponded:
+			responders = append(responders, *participant.User)
+		case models.ParticipantStatusInvited:
+			nonResponders = append(nonResponders, *participant.User)
+		}
+	}
+	return responders, nonResponders, nil
+}
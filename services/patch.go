@@ -0,0 +1,178 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/krushnna/meeting-scheduler/models"
+	"github.com/krushnna/meeting-scheduler/repository"
+	"github.com/krushnna/meeting-scheduler/utils"
+)
+
+// applyMergePatch applies an RFC 7396 merge patch to the JSON form of current
+// and decodes the result into patched
+func applyMergePatch(current interface{}, patch []byte, patched interface{}) error {
+	document, err := json.Marshal(current)
+	if err != nil {
+		return err
+	}
+	merged, err := utils.MergePatch(document, patch)
+	if err != nil {
+		return &ValidationError{Message: "invalid merge patch: " + err.Error()}
+	}
+	if err := json.Unmarshal(merged, patched); err != nil {
+		return &ValidationError{Message: "invalid merge patch: " + err.Error()}
+	}
+	return nil
+}
+
+// PatchEvent applies a merge patch to an event and returns the updated event.
+// Fields can be cleared explicitly, so the merged event is validated as a whole.
+// A non-zero version must match the event's current version.
+func (s *EventService) PatchEvent(ctx context.Context, id, version uint, patch []byte) (*models.Event, error) {
+	var updated *models.Event
+	err := s.tx.WithinTransaction(func(r repository.Repositories) error {
+		before, err := r.Events.FindByID(id)
+		if err != nil {
+			return notFound(err, "event", id)
+		}
+		if version, err = expectVersion(before.Version, version); err != nil {
+			return err
+		}
+
+		var patched models.Event
+		if err := applyMergePatch(before, patch, &patched); err != nil {
+			return err
+		}
+		patched.Model = before.Model
+		patched.Version = version
+		patched.TimeSlots = nil
+		patched.ScheduledStart, patched.ScheduledEnd = before.ScheduledStart, before.ScheduledEnd
+		patched.ReservedResourceID = before.ReservedResourceID
+		if err := validateEvent(&patched); err != nil {
+			return err
+		}
+		if patched.Status == "" {
+			patched.Status = models.EventStatusPolling
+		}
+		if err := validateStatusChange(before.Status, patched.Status); err != nil {
+			return err
+		}
+		if patched.AutoFinalize == "" {
+			patched.AutoFinalize = models.AutoFinalizeOff
+		}
+		if patched.RecommendationStrategy == "" {
+			patched.RecommendationStrategy = models.StrategyAvailability
+		}
+		keepAutoFinalizeOutcome(before, &patched)
+		if err := checkEventResource(r, &patched); err != nil {
+			return err
+		}
+
+		if err := r.Events.Save(&patched); err != nil {
+			return err
+		}
+		if autoFinalizeChanged(before, &patched) && before.AutoFinalizeOutcome != "" {
+			if err := r.Events.ClearAutoFinalizeOutcome(id); err != nil {
+				return err
+			}
+		}
+		if err := releaseSchedule(r, before, &patched); err != nil {
+			return err
+		}
+		if updated, err = r.Events.FindByID(id); err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, r.Audit, AuditUpdate, AggregateEvent, id, before, updated); err != nil {
+			return err
+		}
+		return recordDomainEvent(r.Outbox, EventUpdated, AggregateEvent, id, updated)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// PatchTimeSlot applies a merge patch to a time slot and returns the updated slot.
+// The owning event cannot be changed.
+func (s *TimeSlotService) PatchTimeSlot(ctx context.Context, id, version uint, patch []byte) (*models.TimeSlot, error) {
+	var updated *models.TimeSlot
+	err := s.tx.WithinTransaction(func(r repository.Repositories) error {
+		before, err := r.TimeSlots.FindByID(id)
+		if err != nil {
+			return notFound(err, "time slot", id)
+		}
+		if version, err = expectVersion(before.Version, version); err != nil {
+			return err
+		}
+
+		var patched models.TimeSlot
+		if err := applyMergePatch(before, patch, &patched); err != nil {
+			return err
+		}
+		patched.Model = before.Model
+		patched.EventID = before.EventID
+		patched.Version = version
+		if err := validateTimeRange(patched.StartTime, patched.EndTime); err != nil {
+			return err
+		}
+
+		if err := r.TimeSlots.Save(&patched); err != nil {
+			return err
+		}
+		if updated, err = r.TimeSlots.FindByID(id); err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, r.Audit, AuditUpdate, AggregateTimeSlot, id, before, updated); err != nil {
+			return err
+		}
+		if err := recordDomainEvent(r.Outbox, TimeSlotUpdated, AggregateTimeSlot, id, updated); err != nil {
+			return err
+		}
+		return refreshEventRuleAvailability(ctx, r, before.EventID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// PatchUser applies a merge patch to a user and returns the updated user
+func (s *UserService) PatchUser(ctx context.Context, id, version uint, patch []byte) (*models.User, error) {
+	var updated *models.User
+	err := s.tx.WithinTransaction(func(r repository.Repositories) error {
+		before, err := r.Users.FindByID(id)
+		if err != nil {
+			return notFound(err, "user", id)
+		}
+		if version, err = expectVersion(before.Version, version); err != nil {
+			return err
+		}
+
+		var patched models.User
+		if err := applyMergePatch(before, patch, &patched); err != nil {
+			return err
+		}
+		patched.Model = before.Model
+		patched.Version = version
+		if err := validateUser(&patched); err != nil {
+			return err
+		}
+
+		if err := r.Users.Save(&patched); err != nil {
+			return emailTaken(err)
+		}
+		if updated, err = r.Users.FindByID(id); err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, r.Audit, AuditUpdate, AggregateUser, id, before, updated); err != nil {
+			return err
+		}
+		return refreshOnTimezoneChange(ctx, r, before, updated)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/krushnna/meeting-scheduler/models"
+	"github.com/krushnna/meeting-scheduler/repository"
+)
+
+// hasQuorum reports whether the event sets any quorum
+func hasQuorum(event *models.Event) bool {
+	return event.Quorum > 0 || event.QuorumPercentage > 0 || event.QuorumAllRequired
+}
+
+// quorumShortfall explains why attendees fall short of the event's quorum, or
+// returns "" when they meet it
+func (in *recommendationInputs) quorumShortfall(attendees []models.User) string {
+	event := in.event
+	invitees := len(in.users) + len(in.nonResponders)
+	var reasons []string
+	if event.Quorum > 0 && len(attendees) < event.Quorum {
+		reasons = append(reasons, fmt.Sprintf("%d of the %d attendees needed can attend", len(attendees), event.Quorum))
+	}
+	if event.QuorumPercentage > 0 && len(attendees)*100 < event.QuorumPercentage*invitees {
+		reasons = append(reasons, fmt.Sprintf("%d of %d invitees can attend, under %d%%", len(attendees), invitees, event.QuorumPercentage))
+	}
+	if event.QuorumAllRequired {
+		attending := make(map[uint]bool, len(attendees))
+		for _, user := range attendees {
+			attending[user.ID] = true
+		}
+		var missing []string
+		for _, userID := range in.required {
+			if !attending[userID] {
+				missing = append(missing, fmt.Sprint(userID))
+			}
+		}
+		if len(missing) > 0 {
+			reasons = append(reasons, "required participants can't attend: "+strings.Join(missing, ", "))
+		}
+	}
+	return strings.Join(reasons, "; ")
+}
+
+// attendeesAt returns the invitees who can attend a meeting from start to end:
+// those available for all of it, or under the votes strategy those who voted
+// yes or maybe on a slot it fits in, in both cases clear of their commitments
+// with room for their buffers and daily cap
+func (in *recommendationInputs) attendeesAt(start, end time.Time) []models.User {
+	if in.event.RecommendationStrategy != models.StrategyVotes {
+		attendees, _ := in.attendance(start, end)
+		return attendees
+	}
+	fitting := make(map[uint]bool)
+	for _, slot := range in.slots {
+		if !start.Before(slot.StartTime) && !end.After(slot.EndTime) {
+			fitting[slot.ID] = true
+		}
+	}
+	voted := make(map[uint]bool)
+	for _, vote := range in.votes {
+		if fitting[vote.TimeSlotID] && (vote.Vote == models.VoteYes || vote.Vote == models.VoteMaybe) {
+			voted[vote.UserID] = true
+		}
+	}
+	var attendees []models.User
+	for _, user := range in.users {
+		if voted[user.ID] && withinLimits(user, in.location(user), in.busy[user.ID], start, end) {
+			attendees = append(attendees, user)
+		}
+	}
+	return attendees
+}
+
+// checkQuorum fails with a ConflictError when a meeting of the event starting at
+// start would fall short of its quorum
+func checkQuorum(r repository.Repositories, event *models.Event, start time.Time) error {
+	in, err := loadRecommendationInputs(r, event.ID)
+	if err != nil {
+		return err
+	}
+	end := start.Add(time.Duration(event.DurationMinutes) * time.Minute)
+	if shortfall := in.quorumShortfall(in.attendeesAt(start, end)); shortfall != "" {
+		return &ConflictError{Message: "the meeting doesn't meet the event's quorum: " + shortfall + "; set override_quorum to finalize anyway"}
+	}
+	return nil
+}
@@ -0,0 +1,208 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/krushnna/meeting-scheduler/models"
+	"github.com/krushnna/meeting-scheduler/repository"
+	"gorm.io/gorm"
+)
+
+// DefaultBookingWindow is how far ahead bookings are listed when no range is given
+const DefaultBookingWindow = 90 * 24 * time.Hour
+
+// ResourceService handles rooms, equipment and the bookings that block them
+type ResourceService struct {
+	repo repository.ResourceRepository
+	tx   repository.Transactor
+}
+
+func NewResourceService(repo repository.ResourceRepository, tx repository.Transactor) *ResourceService {
+	return &ResourceService{repo: repo, tx: tx}
+}
+
+func (s *ResourceService) CreateResource(ctx context.Context, resource *models.Resource) error {
+	if resource.Kind == "" {
+		resource.Kind = models.ResourceKindRoom
+	}
+	if err := validateResource(resource); err != nil {
+		return err
+	}
+	return s.tx.WithinTransaction(func(r repository.Repositories) error {
+		if err := r.Resources.Create(resource); err != nil {
+			return err
+		}
+		return recordAudit(ctx, r.Audit, AuditCreate, AggregateResource, resource.ID, nil, resource)
+	})
+}
+
+func (s *ResourceService) GetResource(id uint) (*models.Resource, error) {
+	resource, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, notFound(err, "resource", id)
+	}
+	return resource, nil
+}
+
+// ListResources returns one page of resources
+func (s *ResourceService) ListResources(page repository.PageRequest) (*repository.Page[models.Resource], error) {
+	return s.repo.FindPage(page)
+}
+
+// DeleteResource soft-deletes the resource together with its bookings. Events
+// that asked for it by ID can no longer be finalized until they name another.
+func (s *ResourceService) DeleteResource(ctx context.Context, id uint) error {
+	return s.tx.WithinTransaction(func(r repository.Repositories) error {
+		before, err := r.Resources.FindByID(id)
+		if err != nil {
+			return notFound(err, "resource", id)
+		}
+		if err := r.Resources.DeleteBookingsByResource(id); err != nil {
+			return err
+		}
+		if err := r.Resources.Delete(id); err != nil {
+			return err
+		}
+		return recordAudit(ctx, r.Audit, AuditDelete, AggregateResource, id, before, nil)
+	})
+}
+
+// CreateBooking blocks a resource for a time, e.g. for maintenance or a meeting
+// booked elsewhere. It fails with 409 when the time overlaps another booking.
+func (s *ResourceService) CreateBooking(ctx context.Context, booking *models.ResourceBooking) error {
+	if err := validateTimeRange(booking.StartTime, booking.EndTime); err != nil {
+		return err
+	}
+	// Only finalizing books a resource for an event.
+	booking.EventID = nil
+	return s.tx.WithinTransaction(func(r repository.Repositories) error {
+		if _, err := r.Resources.FindByID(booking.ResourceID); err != nil {
+			return notFound(err, "resource", booking.ResourceID)
+		}
+		existing, err := r.Resources.FindActiveBookings([]uint{booking.ResourceID}, booking.StartTime, booking.EndTime)
+		if err != nil {
+			return err
+		}
+		if len(existing) > 0 {
+			return &ConflictError{Message: fmt.Sprintf("resource %d is already booked from %s to %s", booking.ResourceID,
+				existing[0].StartTime.UTC().Format(time.RFC3339), existing[0].EndTime.UTC().Format(time.RFC3339))}
+		}
+		if err := r.Resources.CreateBooking(booking); err != nil {
+			return err
+		}
+		return recordAudit(ctx, r.Audit, AuditCreate, AggregateResourceBooking, booking.ID, nil, booking)
+	})
+}
+
+// GetBookings lists the bookings of a resource overlapping [from, to), including
+// those of scheduled events
+func (s *ResourceService) GetBookings(resourceID uint, from, to time.Time) ([]models.ResourceBooking, error) {
+	bookings := []models.ResourceBooking{}
+	err := s.tx.WithinTransaction(func(r repository.Repositories) error {
+		if _, err := r.Resources.FindByID(resourceID); err != nil {
+			return notFound(err, "resource", resourceID)
+		}
+		found, err := r.Resources.FindActiveBookings([]uint{resourceID}, from, to)
+		if err != nil {
+			return err
+		}
+		bookings = append(bookings, found...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return bookings, nil
+}
+
+// DeleteBooking frees the time a booking blocked. An event's booking is released
+// by rescheduling the event instead.
+func (s *ResourceService) DeleteBooking(ctx context.Context, resourceID, id uint) error {
+	return s.tx.WithinTransaction(func(r repository.Repositories) error {
+		before, err := r.Resources.FindBookingByID(id)
+		if err != nil {
+			return notFound(err, "resource booking", id)
+		}
+		if before.ResourceID != resourceID {
+			return &NotFoundError{Resource: "resource booking", ID: id}
+		}
+		if before.EventID != nil {
+			return &ConflictError{Message: fmt.Sprintf("booking %d holds the resource for event %d; reschedule the event to release it", id, *before.EventID)}
+		}
+		if err := r.Resources.DeleteBooking(id); err != nil {
+			return err
+		}
+		return recordAudit(ctx, r.Audit, AuditDelete, AggregateResourceBooking, id, before, nil)
+	})
+}
+
+// needsResource reports whether the event can only be held with a resource
+func needsResource(event *models.Event) bool {
+	return event.ResourceID != nil || event.MinCapacity > 0
+}
+
+// checkEventResource makes sure the resource an event names exists
+func checkEventResource(r repository.Repositories, event *models.Event) error {
+	if event.ResourceID == nil {
+		return nil
+	}
+	if _, err := r.Resources.FindByID(*event.ResourceID); errors.Is(err, gorm.ErrRecordNotFound) {
+		return &ValidationError{Field: "resource_id", Message: fmt.Sprintf("resource %d does not exist", *event.ResourceID)}
+	} else if err != nil {
+		return err
+	}
+	return nil
+}
+
+// freeResources returns the resources from suitable with no active booking
+// overlapping start to end, keeping their order
+func freeResources(suitable []models.Resource, bookings []models.ResourceBooking, start, end time.Time) []models.Resource {
+	var free []models.Resource
+	for _, resource := range suitable {
+		busy := false
+		for _, booking := range bookings {
+			if booking.ResourceID == resource.ID && start.Before(booking.EndTime) && end.After(booking.StartTime) {
+				busy = true
+				break
+			}
+		}
+		if !busy {
+			free = append(free, resource)
+		}
+	}
+	return free
+}
+
+// pickResource returns the smallest suitable resource free from start to end
+// for the event, or a ConflictError when there is none. With preferred set only
+// that resource will do.
+func pickResource(r repository.Repositories, event *models.Event, start, end time.Time, preferred *uint) (*models.Resource, error) {
+	suitable, err := r.Resources.FindSuitable(event.ResourceID, event.MinCapacity)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uint, 0, len(suitable))
+	for _, resource := range suitable {
+		ids = append(ids, resource.ID)
+	}
+	bookings, err := r.Resources.FindActiveBookings(ids, start, end)
+	if err != nil {
+		return nil, err
+	}
+	free := freeResources(suitable, bookings, start, end)
+	if preferred != nil {
+		for i := range free {
+			if free[i].ID == *preferred {
+				return &free[i], nil
+			}
+		}
+		return nil, &ConflictError{Message: fmt.Sprintf("resource %d is not free at that time", *preferred)}
+	}
+	if len(free) == 0 {
+		return nil, &ConflictError{Message: "no suitable resource is free at that time"}
+	}
+	return &free[0], nil
+}
@@ -0,0 +1,411 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/krushnna/meeting-scheduler/models"
+	"github.com/krushnna/meeting-scheduler/repository"
+)
+
+// DefaultConflictWindow is how far ahead conflicts are listed when no range is given
+const DefaultConflictWindow = 90 * 24 * time.Hour
+
+// maxCommitmentOccurrences bounds how many occurrences of one series are
+// expanded when collecting a user's commitments
+const maxCommitmentOccurrences = 1000
+
+// FinalizeEvent schedules a polling event to start at start. The meeting must fit
+// inside one of the event's time slots and must not overlap another commitment
+// of any invitee who has not declined. It must meet the event's quorum unless
+// overrideQuorum is set. A non-zero version must match the event's current version.
+func (s *EventService) FinalizeEvent(ctx context.Context, id, version uint, start time.Time, overrideQuorum bool) (*models.Event, error) {
+	var updated *models.Event
+	err := s.tx.WithinTransaction(func(r repository.Repositories) error {
+		before, err := r.Events.FindByID(id)
+		if err != nil {
+			return notFound(err, "event", id)
+		}
+		if _, err = expectVersion(before.Version, version); err != nil {
+			return err
+		}
+		if before.Status == models.EventStatusPolling && hasQuorum(before) && !overrideQuorum {
+			if err := checkQuorum(r, before, start); err != nil {
+				return err
+			}
+		}
+		event := *before
+		updated, err = scheduleEvent(ctx, r, before, &event, start, nil)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// scheduleEvent saves event, a copy of the polling event before, as scheduled to
+// start at start. It checks the time fits a slot, suits every invitee and, for
+// events that need one, that a suitable resource is free before writing
+// anything, so a failed attempt leaves the transaction usable. The resource
+// resourceID names is booked for the meeting, or the smallest free one when nil.
+func scheduleEvent(ctx context.Context, r repository.Repositories, before, event *models.Event, start time.Time, resourceID *uint) (*models.Event, error) {
+	if before.Status != models.EventStatusPolling {
+		return nil, &ConflictError{Message: fmt.Sprintf("event is %s; only polling events can be finalized", before.Status)}
+	}
+
+	end := start.Add(time.Duration(before.DurationMinutes) * time.Minute)
+	slots, err := r.TimeSlots.FindByEventID(before.ID)
+	if err != nil {
+		return nil, err
+	}
+	fits := false
+	for _, slot := range slots {
+		if !start.Before(slot.StartTime) && !end.After(slot.EndTime) {
+			fits = true
+			break
+		}
+	}
+	if !fits {
+		return nil, &ValidationError{Field: "start_time", Message: "the meeting must fit inside one of the event's time slots"}
+	}
+
+	responders, nonResponders, err := eventInvitees(r, before.ID)
+	if err != nil {
+		return nil, err
+	}
+	participants := append(responders, nonResponders...)
+	var clashes []string
+	for _, participant := range participants {
+		commitments, err := userCommitments(r, participant.ID, start, end, before.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, commitment := range commitments {
+			clashes = append(clashes, fmt.Sprintf("user %d is busy with %s", participant.ID, describeCommitment(commitment)))
+		}
+	}
+	if len(clashes) > 0 {
+		return nil, &ConflictError{Message: "the meeting conflicts with participants' commitments: " + strings.Join(clashes, "; ")}
+	}
+	var resource *models.Resource
+	if needsResource(before) {
+		if resource, err = pickResource(r, before, start, end, resourceID); err != nil {
+			return nil, err
+		}
+	}
+
+	event.Status = models.EventStatusScheduled
+	event.ScheduledStart = &start
+	event.ScheduledEnd = &end
+	event.ReservedResourceID = nil
+	if resource != nil {
+		event.ReservedResourceID = &resource.ID
+	}
+	if err := r.Events.Save(event); err != nil {
+		return nil, err
+	}
+	if resource != nil {
+		booking := models.ResourceBooking{ResourceID: resource.ID, EventID: &before.ID, StartTime: start, EndTime: end}
+		if err := r.Resources.CreateBooking(&booking); err != nil {
+			return nil, err
+		}
+		if err := recordAudit(ctx, r.Audit, AuditCreate, AggregateResourceBooking, booking.ID, nil, &booking); err != nil {
+			return nil, err
+		}
+	}
+	// RSVPs answer a particular time, so everyone answers the new one afresh.
+	if err := r.Participants.ResetRSVPs(before.ID); err != nil {
+		return nil, err
+	}
+	updated, err := r.Events.FindByID(before.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := recordAudit(ctx, r.Audit, AuditUpdate, AggregateEvent, before.ID, before, updated); err != nil {
+		return nil, err
+	}
+	if err := recordDomainEvent(r.Outbox, EventFinalized, AggregateEvent, before.ID, updated); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// RescheduleOptions controls how a scheduled event is reopened for polling
+type RescheduleOptions struct {
+	Reason               string
+	PreserveAvailability bool
+	TimeSlots            []models.TimeSlot
+}
+
+// RescheduleEvent moves a scheduled event back into polling, adding any new time
+// slots. Unless availability is preserved, it is cleared along with the votes and
+// every participant has to respond again. A non-zero version must match the event's current version.
+func (s *EventService) RescheduleEvent(ctx context.Context, id, version uint, options RescheduleOptions) (*models.Event, error) {
+	for i, slot := range options.TimeSlots {
+		if !slot.StartTime.Before(slot.EndTime) {
+			return nil, &ValidationError{Field: fmt.Sprintf("time_slots[%d].end_time", i), Message: "start time must be before end time"}
+		}
+	}
+
+	var updated *models.Event
+	err := s.tx.WithinTransaction(func(r repository.Repositories) error {
+		before, err := r.Events.FindByID(id)
+		if err != nil {
+			return notFound(err, "event", id)
+		}
+		if _, err = expectVersion(before.Version, version); err != nil {
+			return err
+		}
+		if before.Status != models.EventStatusScheduled {
+			return &ConflictError{Message: fmt.Sprintf("event is %s; only scheduled events can be rescheduled", before.Status)}
+		}
+
+		if !options.PreserveAvailability {
+			if err := clearResponses(ctx, r, id); err != nil {
+				return err
+			}
+		}
+		for i := range options.TimeSlots {
+			slot := models.TimeSlot{EventID: id, StartTime: options.TimeSlots[i].StartTime, EndTime: options.TimeSlots[i].EndTime}
+			if err := r.TimeSlots.Create(&slot); err != nil {
+				return err
+			}
+			if err := recordAudit(ctx, r.Audit, AuditCreate, AggregateTimeSlot, slot.ID, nil, &slot); err != nil {
+				return err
+			}
+			if err := recordDomainEvent(r.Outbox, TimeSlotCreated, AggregateTimeSlot, slot.ID, &slot); err != nil {
+				return err
+			}
+		}
+
+		if updated, err = reopenEvent(ctx, r, before, EventRescheduled, options.Reason); err != nil {
+			return err
+		}
+		updated.ScheduleHistory, err = r.Events.FindScheduleChanges(id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// clearResponses deletes everyone's availability and votes for the event and
+// moves responded participants back to invited, auditing each change
+func clearResponses(ctx context.Context, r repository.Repositories, eventID uint) error {
+	availability, err := r.Availability.FindByEvent(eventID)
+	if err != nil {
+		return err
+	}
+	for i := range availability {
+		before := &availability[i]
+		if err := r.Availability.Delete(before.ID, before.Version); err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, r.Audit, AuditDelete, AggregateAvailability, before.ID, before, nil); err != nil {
+			return err
+		}
+		if err := recordDomainEvent(r.Outbox, AvailabilityDeleted, AggregateAvailability, before.ID, map[string]uint{"id": before.ID}); err != nil {
+			return err
+		}
+	}
+
+	votes, err := r.Votes.FindByEvent(eventID)
+	if err != nil {
+		return err
+	}
+	for i := range votes {
+		before := &votes[i]
+		if err := r.Votes.Delete(before.ID); err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, r.Audit, AuditDelete, AggregateSlotVote, before.ID, before, nil); err != nil {
+			return err
+		}
+	}
+
+	participants, err := r.Participants.FindByEvent(eventID)
+	if err != nil {
+		return err
+	}
+	for i := range participants {
+		before := &participants[i]
+		if before.Status != models.ParticipantStatusResponded {
+			continue
+		}
+		if err := r.Participants.UpdateStatus(before.ID, models.ParticipantStatusInvited); err != nil {
+			return err
+		}
+		updated := *before
+		updated.Status = models.ParticipantStatusInvited
+		if err := recordAudit(ctx, r.Audit, AuditUpdate, AggregateParticipant, before.ID, before, &updated); err != nil {
+			return err
+		}
+		if err := recordDomainEvent(r.Outbox, ParticipantUpdated, AggregateParticipant, before.ID, &updated); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reopenEvent puts a scheduled event back into polling, clears its scheduled time
+// and any auto-finalize outcome, releases its resource, re-materializes invitees' availability rules and adds
+// the old time to the event's schedule history. The domain event of type
+// eventType lists the invitees to notify.
+func reopenEvent(ctx context.Context, r repository.Repositories, before *models.Event, eventType, reason string) (*models.Event, error) {
+	change := models.ScheduleChange{
+		EventID:        before.ID,
+		ScheduledStart: *before.ScheduledStart,
+		ScheduledEnd:   *before.ScheduledEnd,
+		Reason:         reason,
+	}
+	if err := r.Events.CreateScheduleChange(&change); err != nil {
+		return nil, err
+	}
+
+	event := *before
+	event.Status = models.EventStatusPolling
+	event.ScheduledStart, event.ScheduledEnd, event.ReservedResourceID = nil, nil, nil
+	// The worker's earlier decision no longer holds, so it may decide again.
+	event.AutoFinalizeOutcome, event.AutoFinalizeRationale, event.AutoFinalizeDecidedAt = "", "", nil
+	if err := r.Events.Save(&event); err != nil {
+		return nil, err
+	}
+	if err := r.Resources.DeleteBookingsByEvent(before.ID); err != nil {
+		return nil, err
+	}
+	// Rules changed while the event was scheduled left it alone, so catch up.
+	if err := refreshEventRuleAvailability(ctx, r, before.ID); err != nil {
+		return nil, err
+	}
+	updated, err := r.Events.FindByID(before.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := recordAudit(ctx, r.Audit, AuditUpdate, AggregateEvent, before.ID, before, updated); err != nil {
+		return nil, err
+	}
+
+	responders, nonResponders, err := eventInvitees(r, before.ID)
+	if err != nil {
+		return nil, err
+	}
+	participantIDs := []uint{}
+	for _, user := range append(responders, nonResponders...) {
+		participantIDs = append(participantIDs, user.ID)
+	}
+	payload := map[string]interface{}{"event": updated, "previous": change, "participant_ids": participantIDs}
+	if err := recordDomainEvent(r.Outbox, eventType, AggregateEvent, before.ID, payload); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// releaseSchedule clears the scheduled time and reserved resource of an event
+// that was scheduled and has been cancelled, freeing its bookings
+func releaseSchedule(r repository.Repositories, before, updated *models.Event) error {
+	if before.Status != models.EventStatusScheduled || updated.Status != models.EventStatusCancelled {
+		return nil
+	}
+	if err := r.Events.ClearSchedule(before.ID); err != nil {
+		return err
+	}
+	return r.Resources.DeleteBookingsByEvent(before.ID)
+}
+
+// GetConflicts lists the pairs of the user's commitments that overlap each
+// other and [from, to)
+func (s *UserService) GetConflicts(userID uint, from, to time.Time) ([]models.CommitmentConflict, error) {
+	conflicts := []models.CommitmentConflict{}
+	err := s.tx.WithinTransaction(func(r repository.Repositories) error {
+		if _, err := r.Users.FindByID(userID); err != nil {
+			return notFound(err, "user", userID)
+		}
+		commitments, err := userCommitments(r, userID, from, to, 0)
+		if err != nil {
+			return err
+		}
+		for i := range commitments {
+			for j := i + 1; j < len(commitments); j++ {
+				if !commitments[j].StartTime.Before(commitments[i].EndTime) {
+					break
+				}
+				conflicts = append(conflicts, models.CommitmentConflict{First: commitments[i], Second: commitments[j]})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return conflicts, nil
+}
+
+// userCommitments returns the user's scheduled events and series occurrences that
+// overlap [from, to), in start order. The event excludeEventID is left out so an
+// event never conflicts with itself.
+func userCommitments(r repository.Repositories, userID uint, from, to time.Time, excludeEventID uint) ([]models.Commitment, error) {
+	var commitments []models.Commitment
+
+	events, err := r.Events.FindScheduledByUser(userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	for _, event := range events {
+		if event.ID == excludeEventID {
+			continue
+		}
+		commitments = append(commitments, models.Commitment{
+			EventID:   event.ID,
+			Title:     event.Title,
+			StartTime: *event.ScheduledStart,
+			EndTime:   *event.ScheduledEnd,
+		})
+	}
+
+	seriesList, err := r.Series.FindByParticipant(userID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range seriesList {
+		series := &seriesList[i]
+		rule, dtstart, err := seriesRule(series)
+		if err != nil {
+			return nil, err
+		}
+		exceptions, err := r.Series.FindExceptionsBySeries(series.ID)
+		if err != nil {
+			return nil, err
+		}
+		duration := time.Duration(series.DurationMinutes) * time.Minute
+		starts := rule.Between(dtstart, from.Add(-duration), to, maxCommitmentOccurrences)
+		for _, occurrence := range expandSeries(series, starts, exceptions) {
+			if occurrence.Status == models.OccurrenceStatusCancelled || !occurrence.StartTime.Before(to) || !occurrence.EndTime.After(from) {
+				continue
+			}
+			commitments = append(commitments, models.Commitment{
+				SeriesID:  series.ID,
+				Title:     series.Title,
+				StartTime: occurrence.StartTime,
+				EndTime:   occurrence.EndTime,
+			})
+		}
+	}
+
+	sort.SliceStable(commitments, func(i, j int) bool { return commitments[i].StartTime.Before(commitments[j].StartTime) })
+	return commitments, nil
+}
+
+// describeCommitment names a commitment and its time for error messages
+func describeCommitment(commitment models.Commitment) string {
+	kind, id := "event", commitment.EventID
+	if commitment.SeriesID != 0 {
+		kind, id = "series", commitment.SeriesID
+	}
+	return fmt.Sprintf("%s %d %q from %s to %s", kind, id, commitment.Title,
+		commitment.StartTime.UTC().Format(time.RFC3339), commitment.EndTime.UTC().Format(time.RFC3339))
+}
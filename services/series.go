@@ -0,0 +1,417 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/krushnna/meeting-scheduler/models"
+	"github.com/krushnna/meeting-scheduler/repository"
+	"github.com/krushnna/meeting-scheduler/utils"
+	"gorm.io/gorm"
+)
+
+// Limits on how much of a series is expanded per request
+const (
+	DefaultOccurrenceLimit           = 50
+	MaxOccurrenceLimit               = 500
+	DefaultOccurrenceWindow          = 90 * 24 * time.Hour
+	DefaultRecommendationOccurrences = 4
+	MaxRecommendationOccurrences     = 52
+)
+
+// Candidate start times are tried every seriesCandidateStep from local midnight,
+// and only the best maxSeriesRecommendations are returned
+const (
+	seriesCandidateStep      = 15 * time.Minute
+	maxSeriesRecommendations = 10
+)
+
+// seriesHorizon bounds the search for the next occurrences of a series
+const seriesHorizon = 100 // years
+
+// EventSeriesService handles recurring event series, their exceptions and
+// recommendations for a weekly time
+type EventSeriesService struct {
+	repo repository.EventSeriesRepository
+	tx   repository.Transactor
+}
+
+func NewEventSeriesService(repo repository.EventSeriesRepository, tx repository.Transactor) *EventSeriesService {
+	return &EventSeriesService{repo: repo, tx: tx}
+}
+
+func (s *EventSeriesService) CreateSeries(ctx context.Context, series *models.EventSeries) error {
+	if err := validateSeries(series); err != nil {
+		return err
+	}
+	return s.tx.WithinTransaction(func(r repository.Repositories) error {
+		for _, userID := range series.ParticipantIDs {
+			if _, err := r.Users.FindByID(userID); errors.Is(err, gorm.ErrRecordNotFound) {
+				return &ValidationError{Field: "participant_ids", Message: fmt.Sprintf("participant %d does not exist", userID)}
+			} else if err != nil {
+				return err
+			}
+		}
+		if err := r.Series.Create(series); err != nil {
+			return err
+		}
+		return recordAudit(ctx, r.Audit, AuditCreate, AggregateEventSeries, series.ID, nil, series)
+	})
+}
+
+func (s *EventSeriesService) GetSeries(id uint) (*models.EventSeries, error) {
+	series, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, notFound(err, "event series", id)
+	}
+	return series, nil
+}
+
+// ListSeries returns one page of event series
+func (s *EventSeriesService) ListSeries(page repository.PageRequest) (*repository.Page[models.EventSeries], error) {
+	return s.repo.FindPage(page)
+}
+
+// DeleteSeries soft-deletes the series together with its exceptions
+func (s *EventSeriesService) DeleteSeries(ctx context.Context, id uint) error {
+	return s.tx.WithinTransaction(func(r repository.Repositories) error {
+		before, err := r.Series.FindByID(id)
+		if err != nil {
+			return notFound(err, "event series", id)
+		}
+		if err := r.Series.DeleteExceptionsBySeries(id); err != nil {
+			return err
+		}
+		if err := r.Series.Delete(id); err != nil {
+			return err
+		}
+		return recordAudit(ctx, r.Audit, AuditDelete, AggregateEventSeries, id, before, nil)
+	})
+}
+
+// GetOccurrences expands the occurrences of a series whose start under the rule
+// falls in [from, to), at most limit of them, with exceptions applied
+func (s *EventSeriesService) GetOccurrences(id uint, from, to time.Time, limit int) ([]models.Occurrence, error) {
+	occurrences := []models.Occurrence{}
+	err := s.tx.WithinTransaction(func(r repository.Repositories) error {
+		series, err := r.Series.FindByID(id)
+		if err != nil {
+			return notFound(err, "event series", id)
+		}
+		exceptions, err := r.Series.FindExceptionsBySeries(id)
+		if err != nil {
+			return err
+		}
+		rule, dtstart, err := seriesRule(series)
+		if err != nil {
+			return err
+		}
+		occurrences = append(occurrences, expandSeries(series, rule.Between(dtstart, from, to, limit), exceptions)...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return occurrences, nil
+}
+
+// CreateException moves or cancels one occurrence of a series. The original
+// start must be an occurrence under the rule without an exception already.
+func (s *EventSeriesService) CreateException(ctx context.Context, exception *models.SeriesException) error {
+	if exception.Cancelled == (exception.StartTime != nil) {
+		return &ValidationError{Field: "start_time", Message: "an exception either cancels the occurrence or gives its new start time"}
+	}
+	exception.OriginalStart = exception.OriginalStart.UTC()
+	if exception.StartTime != nil {
+		moved := exception.StartTime.UTC()
+		exception.StartTime = &moved
+	}
+	return s.tx.WithinTransaction(func(r repository.Repositories) error {
+		series, err := r.Series.FindByID(exception.SeriesID)
+		if err != nil {
+			return notFound(err, "event series", exception.SeriesID)
+		}
+		rule, dtstart, err := seriesRule(series)
+		if err != nil {
+			return err
+		}
+		starts := rule.Between(dtstart, exception.OriginalStart, exception.OriginalStart.Add(time.Second), 1)
+		if len(starts) == 0 || !starts[0].Equal(exception.OriginalStart) {
+			return &ValidationError{Field: "original_start", Message: "original start is not an occurrence of the series"}
+		}
+		existing, err := r.Series.FindExceptionsBySeries(exception.SeriesID)
+		if err != nil {
+			return err
+		}
+		for _, other := range existing {
+			if other.OriginalStart.Equal(exception.OriginalStart) {
+				return &ConflictError{Message: fmt.Sprintf("occurrence at %s already has exception %d", exception.OriginalStart.Format(time.RFC3339), other.ID)}
+			}
+		}
+		if err := r.Series.CreateException(exception); err != nil {
+			return err
+		}
+		return recordAudit(ctx, r.Audit, AuditCreate, AggregateSeriesException, exception.ID, nil, exception)
+	})
+}
+
+func (s *EventSeriesService) GetExceptions(seriesID uint) ([]models.SeriesException, error) {
+	var exceptions []models.SeriesException
+	err := s.tx.WithinTransaction(func(r repository.Repositories) error {
+		if _, err := r.Series.FindByID(seriesID); err != nil {
+			return notFound(err, "event series", seriesID)
+		}
+		var err error
+		exceptions, err = r.Series.FindExceptionsBySeries(seriesID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return exceptions, nil
+}
+
+// DeleteException restores the occurrence an exception moved or cancelled
+func (s *EventSeriesService) DeleteException(ctx context.Context, seriesID, id uint) error {
+	return s.tx.WithinTransaction(func(r repository.Repositories) error {
+		before, err := r.Series.FindExceptionByID(id)
+		if err != nil {
+			return notFound(err, "series exception", id)
+		}
+		if before.SeriesID != seriesID {
+			return &NotFoundError{Resource: "series exception", ID: id}
+		}
+		if err := r.Series.DeleteException(id); err != nil {
+			return err
+		}
+		return recordAudit(ctx, r.Audit, AuditDelete, AggregateSeriesException, id, before, nil)
+	})
+}
+
+// GetRecommendations ranks local start times for the series by how many of its
+// participants can attend each of the next count occurrences after from.
+// Participants' availability comes from their recurring availability rules;
+// cancelled occurrences are skipped.
+func (s *EventSeriesService) GetRecommendations(id uint, from time.Time, count int) ([]models.SeriesTimeRecommendation, error) {
+	recommendations := []models.SeriesTimeRecommendation{}
+	err := s.tx.WithinTransaction(func(r repository.Repositories) error {
+		series, err := r.Series.FindByID(id)
+		if err != nil {
+			return notFound(err, "event series", id)
+		}
+		exceptions, err := r.Series.FindExceptionsBySeries(id)
+		if err != nil {
+			return err
+		}
+		rule, dtstart, err := seriesRule(series)
+		if err != nil {
+			return err
+		}
+
+		// Occurrence dates in the series' timezone, with cancelled ones left out
+		var days []time.Time
+		starts := rule.Between(dtstart, from, from.AddDate(seriesHorizon, 0, 0), count+len(exceptions))
+		for _, occurrence := range expandSeries(series, starts, exceptions) {
+			if occurrence.Status == models.OccurrenceStatusCancelled {
+				continue
+			}
+			local := occurrence.OriginalStart.In(dtstart.Location())
+			days = append(days, time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, dtstart.Location()))
+			if len(days) == count {
+				break
+			}
+		}
+
+		available, err := participantAvailability(r, series.ParticipantIDs, days)
+		if err != nil {
+			return err
+		}
+		recommendations = rankSeriesTimes(series, days, available)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return recommendations, nil
+}
+
+// participantAvailability expands each live participant's availability rules over
+// the given local days. Participants deleted since joining the series are skipped.
+func participantAvailability(r repository.Repositories, userIDs []uint, days []time.Time) (map[uint][]interval, error) {
+	windows := make([]interval, 0, len(days))
+	for _, day := range days {
+		windows = append(windows, interval{Start: day, End: day.AddDate(0, 0, 1)})
+	}
+	windows = mergeIntervals(windows)
+
+	available := make(map[uint][]interval)
+	for _, userID := range userIDs {
+		user, err := r.Users.FindByID(userID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		location, err := time.LoadLocation(user.Timezone)
+		if err != nil {
+			return nil, &ValidationError{Field: "timezone", Message: fmt.Sprintf("user timezone %q is not a known time zone", user.Timezone)}
+		}
+		rules, err := r.Rules.FindRulesByUser(userID)
+		if err != nil {
+			return nil, err
+		}
+		exceptions, err := r.Rules.FindExceptionsByUser(userID)
+		if err != nil {
+			return nil, err
+		}
+		available[userID] = expandRules(rules, exceptions, location, windows)
+	}
+	return available, nil
+}
+
+// rankSeriesTimes scores every start time that fits the meeting within a local
+// day, keeping those at least one participant can make, best first. Ties keep
+// the earlier start time.
+func rankSeriesTimes(series *models.EventSeries, days []time.Time, available map[uint][]interval) []models.SeriesTimeRecommendation {
+	recommendations := []models.SeriesTimeRecommendation{}
+	if len(days) == 0 || len(available) == 0 {
+		return recommendations
+	}
+
+	userIDs := make([]uint, 0, len(available))
+	for userID := range available {
+		userIDs = append(userIDs, userID)
+	}
+	sort.Slice(userIDs, func(i, j int) bool { return userIDs[i] < userIDs[j] })
+
+	duration := time.Duration(series.DurationMinutes) * time.Minute
+	for offset := time.Duration(0); offset+duration <= 24*time.Hour; offset += seriesCandidateStep {
+		hour, minute := int(offset.Hours()), int(offset.Minutes())%60
+		recommendation := models.SeriesTimeRecommendation{
+			StartTime: fmt.Sprintf("%02d:%02d", hour, minute),
+			Timezone:  series.Timezone,
+		}
+		attending := 0
+		for _, day := range days {
+			start := time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, day.Location())
+			match := models.OccurrenceMatch{StartTime: start, EndTime: start.Add(duration), MatchingUserIDs: []uint{}, NonMatchingUserIDs: []uint{}}
+			for _, userID := range userIDs {
+				if coversInterval(available[userID], match.StartTime, match.EndTime) {
+					match.MatchingUserIDs = append(match.MatchingUserIDs, userID)
+				} else {
+					match.NonMatchingUserIDs = append(match.NonMatchingUserIDs, userID)
+				}
+			}
+			if len(match.NonMatchingUserIDs) == 0 {
+				recommendation.FullyAttendedOccurrences++
+			}
+			attending += len(match.MatchingUserIDs)
+			recommendation.Occurrences = append(recommendation.Occurrences, match)
+		}
+		if attending == 0 {
+			continue
+		}
+		recommendation.MatchingPercentage = float64(attending) / float64(len(days)*len(userIDs)) * 100
+		recommendations = append(recommendations, recommendation)
+	}
+
+	sort.SliceStable(recommendations, func(i, j int) bool {
+		if recommendations[i].MatchingPercentage != recommendations[j].MatchingPercentage {
+			return recommendations[i].MatchingPercentage > recommendations[j].MatchingPercentage
+		}
+		return recommendations[i].FullyAttendedOccurrences > recommendations[j].FullyAttendedOccurrences
+	})
+	if len(recommendations) > maxSeriesRecommendations {
+		recommendations = recommendations[:maxSeriesRecommendations]
+	}
+	return recommendations
+}
+
+// coversInterval reports whether one of the sorted, merged intervals contains [start, end)
+func coversInterval(intervals []interval, start, end time.Time) bool {
+	for _, iv := range intervals {
+		if !start.Before(iv.Start) && !end.After(iv.End) {
+			return true
+		}
+	}
+	return false
+}
+
+// expandSeries turns rule starts into occurrences, applying the exception
+// recorded for each start if there is one
+func expandSeries(series *models.EventSeries, starts []time.Time, exceptions []models.SeriesException) []models.Occurrence {
+	byStart := make(map[int64]models.SeriesException, len(exceptions))
+	for _, exception := range exceptions {
+		byStart[exception.OriginalStart.Unix()] = exception
+	}
+
+	duration := time.Duration(series.DurationMinutes) * time.Minute
+	occurrences := make([]models.Occurrence, 0, len(starts))
+	for _, start := range starts {
+		occurrence := models.Occurrence{
+			SeriesID:      series.ID,
+			OriginalStart: start,
+			StartTime:     start,
+			EndTime:       start.Add(duration),
+			Status:        models.OccurrenceStatusScheduled,
+		}
+		if exception, ok := byStart[start.Unix()]; ok {
+			occurrence.ExceptionID = exception.ID
+			switch {
+			case exception.Cancelled:
+				occurrence.Status = models.OccurrenceStatusCancelled
+			case exception.StartTime != nil:
+				occurrence.Status = models.OccurrenceStatusMoved
+				occurrence.StartTime = *exception.StartTime
+				occurrence.EndTime = exception.StartTime.Add(duration)
+			}
+		}
+		occurrences = append(occurrences, occurrence)
+	}
+	return occurrences
+}
+
+// seriesRule parses the series' rule and returns it with the series start in
+// the series' timezone, which occurrences inherit their wall-clock time from
+func seriesRule(series *models.EventSeries) (*utils.RRule, time.Time, error) {
+	location, err := time.LoadLocation(series.Timezone)
+	if err != nil {
+		return nil, time.Time{}, &ValidationError{Field: "timezone", Message: fmt.Sprintf("timezone %q is not a known time zone", series.Timezone)}
+	}
+	rule, err := utils.ParseRRule(series.RRule)
+	if err != nil {
+		return nil, time.Time{}, &ValidationError{Field: "rrule", Message: err.Error()}
+	}
+	return rule, series.StartTime.In(location), nil
+}
+
+func validateSeries(series *models.EventSeries) error {
+	if series.Title == "" {
+		return &ValidationError{Field: "title", Message: "series title is required"}
+	}
+	if series.OrganizerId == 0 {
+		return &ValidationError{Field: "organizer_id", Message: "series organizer is required"}
+	}
+	if series.DurationMinutes <= 0 {
+		return &ValidationError{Field: "duration_minutes", Message: "series duration must be positive"}
+	}
+	rule, dtstart, err := seriesRule(series)
+	if err != nil {
+		return err
+	}
+	if len(rule.Between(dtstart, dtstart, dtstart.AddDate(seriesHorizon, 0, 0), 1)) == 0 {
+		return &ValidationError{Field: "rrule", Message: "rule has no occurrences on or after the start time"}
+	}
+	seen := make(map[uint]bool, len(series.ParticipantIDs))
+	for _, userID := range series.ParticipantIDs {
+		if seen[userID] {
+			return &ValidationError{Field: "participant_ids", Message: fmt.Sprintf("participant %d is listed twice", userID)}
+		}
+		seen[userID] = true
+	}
+	return nil
+}
@@ -1,7 +1,8 @@
 package services
 
 import (
-	"errors"
+	"context"
+	"fmt"
 	"sort"
 	"time"
 
@@ -12,201 +13,909 @@ import (
 // EventService handles business logic for events
 type EventService struct {
 	repo repository.EventRepository
+	tx   repository.Transactor
 }
 
-func NewEventService(repo repository.EventRepository) *EventService {
-	return &EventService{repo: repo}
+func NewEventService(repo repository.EventRepository, tx repository.Transactor) *EventService {
+	return &EventService{repo: repo, tx: tx}
 }
 
-func (s *EventService) CreateEvent(event *models.Event) error {
-	if event.Title == "" {
-		return errors.New("event title is required")
+func (s *EventService) CreateEvent(ctx context.Context, event *models.Event) error {
+	if err := validateEvent(event); err != nil {
+		return err
 	}
-	if event.DurationMinutes <= 0 {
-		return errors.New("event duration must be positive")
+	if err := validateStatusChange("", event.Status); err != nil {
+		return err
 	}
-	return s.repo.Create(event)
+	if event.Status == "" {
+		event.Status = models.EventStatusPolling
+	}
+	if event.AutoFinalize == "" {
+		event.AutoFinalize = models.AutoFinalizeOff
+	}
+	if event.RecommendationStrategy == "" {
+		event.RecommendationStrategy = models.StrategyAvailability
+	}
+	event.AutoFinalizeOutcome, event.AutoFinalizeRationale, event.AutoFinalizeDecidedAt = "", "", nil
+	event.ReservedResourceID = nil
+	return s.tx.WithinTransaction(func(r repository.Repositories) error {
+		if err := checkEventResource(r, event); err != nil {
+			return err
+		}
+		if err := r.Events.Create(event); err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, r.Audit, AuditCreate, AggregateEvent, event.ID, nil, event); err != nil {
+			return err
+		}
+		return recordDomainEvent(r.Outbox, EventCreated, AggregateEvent, event.ID, event)
+	})
 }
 
+// GetEvent returns an event with its participants, their RSVPs and the times
+// the event was previously scheduled for
 func (s *EventService) GetEvent(id uint) (*models.Event, error) {
-	return s.repo.FindByID(id)
+	var event *models.Event
+	err := s.tx.WithinTransaction(func(r repository.Repositories) error {
+		found, err := r.Events.FindByID(id)
+		if err != nil {
+			return notFound(err, "event", id)
+		}
+		if found.Participants, err = r.Participants.FindByEvent(id); err != nil {
+			return err
+		}
+		if found.ScheduleHistory, err = r.Events.FindScheduleChanges(id); err != nil {
+			return err
+		}
+		event = found
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return event, nil
 }
-func (s *EventService) GetAllEventsWithPagination(limit, offset int) ([]models.Event, error) {
-	return s.repo.FindAllWithPagination(limit, offset)
+
+// ListEvents returns one page of the events matching filter
+func (s *EventService) ListEvents(filter repository.EventFilter, page repository.PageRequest) (*repository.Page[models.Event], error) {
+	return s.repo.FindPage(filter, page)
 }
 
 func (s *EventService) GetAllEvents() ([]models.Event, error) {
 	return s.repo.FindAll()
 }
 
-func (s *EventService) UpdateEvent(id uint, event *models.Event) error {
-	if event.Title == "" {
-		return errors.New("event title is required")
+func (s *EventService) UpdateEvent(ctx context.Context, id uint, event *models.Event) error {
+	if err := validateEvent(event); err != nil {
+		return err
 	}
-	if event.DurationMinutes <= 0 {
-		return errors.New("event duration must be positive")
-	}
-	return s.repo.Update(id, event)
+	return s.tx.WithinTransaction(func(r repository.Repositories) error {
+		before, err := r.Events.FindByID(id)
+		if err != nil {
+			return notFound(err, "event", id)
+		}
+		if event.Version, err = expectVersion(before.Version, event.Version); err != nil {
+			return err
+		}
+		if err := validateStatusChange(before.Status, event.Status); err != nil {
+			return err
+		}
+		// The scheduled time and reserved resource are only set by finalizing.
+		event.ScheduledStart, event.ScheduledEnd, event.ReservedResourceID = nil, nil, nil
+		keepAutoFinalizeOutcome(before, event)
+		if err := checkEventResource(r, event); err != nil {
+			return err
+		}
+		if err := r.Events.Update(id, event); err != nil {
+			return err
+		}
+		if autoFinalizeChanged(before, event) && before.AutoFinalizeOutcome != "" {
+			if err := r.Events.ClearAutoFinalizeOutcome(id); err != nil {
+				return err
+			}
+		}
+		if err := releaseSchedule(r, before, event); err != nil {
+			return err
+		}
+		updated, err := r.Events.FindByID(id)
+		if err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, r.Audit, AuditUpdate, AggregateEvent, id, before, updated); err != nil {
+			return err
+		}
+		return recordDomainEvent(r.Outbox, EventUpdated, AggregateEvent, id, updated)
+	})
 }
 
-func (s *EventService) DeleteEvent(id uint) error {
-	return s.repo.Delete(id)
+// DeleteEvent soft-deletes the event together with its time slots, availability,
+// participants and votes.
+// A non-zero version must match the event's current version.
+func (s *EventService) DeleteEvent(ctx context.Context, id, version uint) error {
+	return s.tx.WithinTransaction(func(r repository.Repositories) error {
+		before, err := r.Events.FindByID(id)
+		if err != nil {
+			return notFound(err, "event", id)
+		}
+		if version, err = expectVersion(before.Version, version); err != nil {
+			return err
+		}
+		slots, err := r.TimeSlots.FindByEventID(id)
+		if err != nil {
+			return err
+		}
+		availability, err := r.Availability.FindByEvent(id)
+		if err != nil {
+			return err
+		}
+		participants, err := r.Participants.FindByEvent(id)
+		if err != nil {
+			return err
+		}
+		votes, err := r.Votes.FindByEvent(id)
+		if err != nil {
+			return err
+		}
+		if err := r.Events.Delete(id, version); err != nil {
+			return err
+		}
+		if err := r.TimeSlots.DeleteByEventID(id); err != nil {
+			return err
+		}
+		if err := r.Availability.DeleteByEventID(id); err != nil {
+			return err
+		}
+		if err := r.Participants.DeleteByEventID(id); err != nil {
+			return err
+		}
+		if err := r.Votes.DeleteByEventID(id); err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, r.Audit, AuditDelete, AggregateEvent, id, before, nil); err != nil {
+			return err
+		}
+		for i := range slots {
+			if err := recordAudit(ctx, r.Audit, AuditDelete, AggregateTimeSlot, slots[i].ID, &slots[i], nil); err != nil {
+				return err
+			}
+		}
+		if err := auditCascadedDeletes(ctx, r, availability, participants, votes); err != nil {
+			return err
+		}
+		return recordDomainEvent(r.Outbox, EventDeleted, AggregateEvent, id, map[string]uint{"id": id})
+	})
 }
 
 // TimeSlotService handles business logic for time slots
 type TimeSlotService struct {
 	repo repository.TimeSlotRepository
+	tx   repository.Transactor
 }
 
-func NewTimeSlotService(repo repository.TimeSlotRepository) *TimeSlotService {
-	return &TimeSlotService{repo: repo}
+func NewTimeSlotService(repo repository.TimeSlotRepository, tx repository.Transactor) *TimeSlotService {
+	return &TimeSlotService{repo: repo, tx: tx}
 }
 
-func (s *TimeSlotService) CreateTimeSlot(timeSlot *models.TimeSlot) error {
-	if timeSlot.StartTime.After(timeSlot.EndTime) || timeSlot.StartTime.Equal(timeSlot.EndTime) {
-		return errors.New("start time must be before end time")
+func (s *TimeSlotService) CreateTimeSlot(ctx context.Context, timeSlot *models.TimeSlot) error {
+	if err := validateTimeRange(timeSlot.StartTime, timeSlot.EndTime); err != nil {
+		return err
 	}
-	return s.repo.Create(timeSlot)
+	return s.tx.WithinTransaction(func(r repository.Repositories) error {
+		if _, err := r.Events.FindByID(timeSlot.EventID); err != nil {
+			return notFound(err, "event", timeSlot.EventID)
+		}
+		if err := r.TimeSlots.Create(timeSlot); err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, r.Audit, AuditCreate, AggregateTimeSlot, timeSlot.ID, nil, timeSlot); err != nil {
+			return err
+		}
+		if err := recordDomainEvent(r.Outbox, TimeSlotCreated, AggregateTimeSlot, timeSlot.ID, timeSlot); err != nil {
+			return err
+		}
+		return refreshEventRuleAvailability(ctx, r, timeSlot.EventID)
+	})
 }
 
 func (s *TimeSlotService) GetTimeSlot(id uint) (*models.TimeSlot, error) {
-	return s.repo.FindByID(id)
+	timeSlot, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, notFound(err, "time slot", id)
+	}
+	return timeSlot, nil
 }
 
 func (s *TimeSlotService) GetTimeSlotsByEvent(eventID uint) ([]models.TimeSlot, error) {
 	return s.repo.FindByEventID(eventID)
 }
 
-func (s *TimeSlotService) UpdateTimeSlot(id uint, timeSlot *models.TimeSlot) error {
-	if timeSlot.StartTime.After(timeSlot.EndTime) || timeSlot.StartTime.Equal(timeSlot.EndTime) {
-		return errors.New("start time must be before end time")
+// ListTimeSlots returns one page of an event's time slots
+func (s *TimeSlotService) ListTimeSlots(eventID uint, page repository.PageRequest) (*repository.Page[models.TimeSlot], error) {
+	return s.repo.FindPageByEventID(eventID, page)
+}
+
+func (s *TimeSlotService) UpdateTimeSlot(ctx context.Context, id uint, timeSlot *models.TimeSlot) error {
+	if err := validateTimeRange(timeSlot.StartTime, timeSlot.EndTime); err != nil {
+		return err
 	}
-	return s.repo.Update(id, timeSlot)
+	return s.tx.WithinTransaction(func(r repository.Repositories) error {
+		before, err := r.TimeSlots.FindByID(id)
+		if err != nil {
+			return notFound(err, "time slot", id)
+		}
+		if timeSlot.Version, err = expectVersion(before.Version, timeSlot.Version); err != nil {
+			return err
+		}
+		if err := r.TimeSlots.Update(id, timeSlot); err != nil {
+			return err
+		}
+		updated, err := r.TimeSlots.FindByID(id)
+		if err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, r.Audit, AuditUpdate, AggregateTimeSlot, id, before, updated); err != nil {
+			return err
+		}
+		if err := recordDomainEvent(r.Outbox, TimeSlotUpdated, AggregateTimeSlot, id, updated); err != nil {
+			return err
+		}
+		if updated.EventID != before.EventID {
+			if err := refreshEventRuleAvailability(ctx, r, before.EventID); err != nil {
+				return err
+			}
+		}
+		return refreshEventRuleAvailability(ctx, r, updated.EventID)
+	})
 }
 
-func (s *TimeSlotService) DeleteTimeSlot(id uint) error {
-	return s.repo.Delete(id)
+func (s *TimeSlotService) DeleteTimeSlot(ctx context.Context, id, version uint) error {
+	return s.tx.WithinTransaction(func(r repository.Repositories) error {
+		before, err := r.TimeSlots.FindByID(id)
+		if err != nil {
+			return notFound(err, "time slot", id)
+		}
+		if version, err = expectVersion(before.Version, version); err != nil {
+			return err
+		}
+		if err := r.TimeSlots.Delete(id, version); err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, r.Audit, AuditDelete, AggregateTimeSlot, id, before, nil); err != nil {
+			return err
+		}
+		if err := recordDomainEvent(r.Outbox, TimeSlotDeleted, AggregateTimeSlot, id, map[string]uint{"id": id}); err != nil {
+			return err
+		}
+		return refreshEventRuleAvailability(ctx, r, before.EventID)
+	})
 }
 
 // UserService handles business logic for users
 type UserService struct {
 	repo repository.UserRepository
+	tx   repository.Transactor
 }
 
-func NewUserService(repo repository.UserRepository) *UserService {
-	return &UserService{repo: repo}
+func NewUserService(repo repository.UserRepository, tx repository.Transactor) *UserService {
+	return &UserService{repo: repo, tx: tx}
 }
 
-func (s *UserService) CreateUser(user *models.User) error {
-	return s.repo.Create(user)
+func (s *UserService) CreateUser(ctx context.Context, user *models.User) error {
+	if err := validateUser(user); err != nil {
+		return err
+	}
+	return s.tx.WithinTransaction(func(r repository.Repositories) error {
+		if err := r.Users.Create(user); err != nil {
+			return emailTaken(err)
+		}
+		return recordAudit(ctx, r.Audit, AuditCreate, AggregateUser, user.ID, nil, user)
+	})
 }
 
 func (s *UserService) GetUser(id uint) (*models.User, error) {
-	return s.repo.FindByID(id)
+	user, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, notFound(err, "user", id)
+	}
+	return user, nil
 }
 
 func (s *UserService) GetAllUsers() ([]models.User, error) {
 	return s.repo.FindAll()
 }
 
-func (s *UserService) UpdateUser(id uint, user *models.User) error {
-	return s.repo.Update(id, user)
+// ListUsers returns one page of the users matching filter
+func (s *UserService) ListUsers(filter repository.UserFilter, page repository.PageRequest) (*repository.Page[models.User], error) {
+	return s.repo.FindPage(filter, page)
+}
+
+func (s *UserService) UpdateUser(ctx context.Context, id uint, user *models.User) error {
+	if err := validateUser(user); err != nil {
+		return err
+	}
+	return s.tx.WithinTransaction(func(r repository.Repositories) error {
+		before, err := r.Users.FindByID(id)
+		if err != nil {
+			return notFound(err, "user", id)
+		}
+		if user.Version, err = expectVersion(before.Version, user.Version); err != nil {
+			return err
+		}
+		if err := r.Users.Update(id, user); err != nil {
+			return emailTaken(err)
+		}
+		updated, err := r.Users.FindByID(id)
+		if err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, r.Audit, AuditUpdate, AggregateUser, id, before, updated); err != nil {
+			return err
+		}
+		return refreshOnTimezoneChange(ctx, r, before, updated)
+	})
+}
+
+// refreshOnTimezoneChange re-materializes a user's rules when their timezone
+// changes, since rule times are wall-clock times in it
+func refreshOnTimezoneChange(ctx context.Context, r repository.Repositories, before, updated *models.User) error {
+	if before.Timezone == updated.Timezone {
+		return nil
+	}
+	return refreshRuleAvailability(ctx, r, updated.ID)
+}
+
+// DeleteUser soft-deletes the user together with their availability, invitations
+// and votes.
+// A non-zero version must match the user's current version.
+func (s *UserService) DeleteUser(ctx context.Context, id, version uint) error {
+	return s.tx.WithinTransaction(func(r repository.Repositories) error {
+		before, err := r.Users.FindByID(id)
+		if err != nil {
+			return notFound(err, "user", id)
+		}
+		if version, err = expectVersion(before.Version, version); err != nil {
+			return err
+		}
+		availability, err := r.Availability.FindByUser(id)
+		if err != nil {
+			return err
+		}
+		participants, err := r.Participants.FindByUser(id)
+		if err != nil {
+			return err
+		}
+		votes, err := r.Votes.FindByUser(id)
+		if err != nil {
+			return err
+		}
+		if err := r.Users.Delete(id, version); err != nil {
+			return err
+		}
+		if err := r.Availability.DeleteByUserID(id); err != nil {
+			return err
+		}
+		if err := r.Participants.DeleteByUserID(id); err != nil {
+			return err
+		}
+		if err := r.Votes.DeleteByUserID(id); err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, r.Audit, AuditDelete, AggregateUser, id, before, nil); err != nil {
+			return err
+		}
+		return auditCascadedDeletes(ctx, r, availability, participants, votes)
+	})
 }
 
-func (s *UserService) DeleteUser(id uint) error {
-	return s.repo.Delete(id)
+// auditCascadedDeletes records the availability, participants and votes deleted
+// along with their event or user
+func auditCascadedDeletes(ctx context.Context, r repository.Repositories, availability []models.UserAvailability, participants []models.EventParticipant, votes []models.SlotVote) error {
+	for i := range availability {
+		if err := recordAudit(ctx, r.Audit, AuditDelete, AggregateAvailability, availability[i].ID, &availability[i], nil); err != nil {
+			return err
+		}
+	}
+	for i := range participants {
+		participant := participants[i]
+		participant.User = nil
+		if err := recordAudit(ctx, r.Audit, AuditDelete, AggregateParticipant, participant.ID, &participant, nil); err != nil {
+			return err
+		}
+	}
+	for i := range votes {
+		vote := votes[i]
+		vote.User = nil
+		if err := recordAudit(ctx, r.Audit, AuditDelete, AggregateSlotVote, vote.ID, &vote, nil); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // AvailabilityService handles business logic for user availability
 type AvailabilityService struct {
 	repo repository.UserAvailabilityRepository
+	tx   repository.Transactor
 }
 
-func NewAvailabilityService(repo repository.UserAvailabilityRepository) *AvailabilityService {
-	return &AvailabilityService{repo: repo}
+func NewAvailabilityService(repo repository.UserAvailabilityRepository, tx repository.Transactor) *AvailabilityService {
+	return &AvailabilityService{repo: repo, tx: tx}
 }
 
-func (s *AvailabilityService) CreateAvailability(availability *models.UserAvailability) error {
-	if availability.StartTime.After(availability.EndTime) || availability.StartTime.Equal(availability.EndTime) {
-		return errors.New("start time must be before end time")
+func (s *AvailabilityService) CreateAvailability(ctx context.Context, availability *models.UserAvailability) error {
+	if err := validateTimeRange(availability.StartTime, availability.EndTime); err != nil {
+		return err
 	}
-	return s.repo.Create(availability)
+	return s.tx.WithinTransaction(func(r repository.Repositories) error {
+		if _, err := r.Users.FindByID(availability.UserID); err != nil {
+			return notFound(err, "user", availability.UserID)
+		}
+		if _, err := r.Events.FindByID(availability.EventID); err != nil {
+			return notFound(err, "event", availability.EventID)
+		}
+		availability.Source = models.AvailabilitySourceManual
+		if err := r.Availability.Create(availability); err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, r.Audit, AuditCreate, AggregateAvailability, availability.ID, nil, availability); err != nil {
+			return err
+		}
+		if err := recordDomainEvent(r.Outbox, AvailabilityCreated, AggregateAvailability, availability.ID, availability); err != nil {
+			return err
+		}
+		return syncParticipation(ctx, r, availability.UserID, availability.EventID)
+	})
 }
 
 func (s *AvailabilityService) GetUserAvailability(userID, eventID uint) ([]models.UserAvailability, error) {
 	return s.repo.FindByUserAndEvent(userID, eventID)
 }
 
-func (s *AvailabilityService) UpdateAvailability(id uint, availability *models.UserAvailability) error {
-	if availability.StartTime.After(availability.EndTime) || availability.StartTime.Equal(availability.EndTime) {
-		return errors.New("start time must be before end time")
+// ListUserAvailability returns one page of a user's availability for an event
+func (s *AvailabilityService) ListUserAvailability(userID, eventID uint, page repository.PageRequest) (*repository.Page[models.UserAvailability], error) {
+	return s.repo.FindPageByUserAndEvent(userID, eventID, page)
+}
+
+// GetEventAvailability returns everyone's availability for an event, grouped by user
+func (s *AvailabilityService) GetEventAvailability(eventID uint) ([]models.UserAvailabilityGroup, error) {
+	groups := []models.UserAvailabilityGroup{}
+	err := s.tx.WithinTransaction(func(r repository.Repositories) error {
+		if _, err := r.Events.FindByID(eventID); err != nil {
+			return notFound(err, "event", eventID)
+		}
+		users, err := r.Availability.FindAllUsersByEvent(eventID)
+		if err != nil {
+			return err
+		}
+		availabilities, err := r.Availability.FindByEvent(eventID)
+		if err != nil {
+			return err
+		}
+
+		byUser := make(map[uint][]models.UserAvailability)
+		for _, availability := range availabilities {
+			byUser[availability.UserID] = append(byUser[availability.UserID], availability)
+		}
+		sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+		for _, user := range users {
+			groups = append(groups, models.UserAvailabilityGroup{User: user, Availability: byUser[user.ID]})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	return s.repo.Update(id, availability)
+	return groups, nil
 }
 
-func (s *AvailabilityService) DeleteAvailability(id uint) error {
-	return s.repo.Delete(id)
+// ReplaceAvailability swaps all of a user's availability for an event for the given
+// windows in one transaction. Overlapping and adjacent windows are merged; the
+// normalized records are returned in start order.
+func (s *AvailabilityService) ReplaceAvailability(ctx context.Context, userID, eventID uint, windows []models.UserAvailability) ([]models.UserAvailability, error) {
+	if err := validateWindows(windows); err != nil {
+		return nil, err
+	}
+
+	var stored []models.UserAvailability
+	err := s.tx.WithinTransaction(func(r repository.Repositories) error {
+		existing, err := findAvailabilityForUpdate(r, userID, eventID)
+		if err != nil {
+			return err
+		}
+		stored, err = syncAvailability(ctx, r, userID, eventID, models.AvailabilitySourceManual, existing, mergeIntervals(availabilityIntervals(windows)))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stored, nil
+}
+
+// SubmitAvailability adds a batch of windows to a user's availability for an event.
+// The batch is validated as a whole and merged with the stored availability, so
+// overlapping and adjacent ranges collapse into one record; the normalized
+// records are returned in start order.
+func (s *AvailabilityService) SubmitAvailability(ctx context.Context, userID, eventID uint, windows []models.UserAvailability) ([]models.UserAvailability, error) {
+	if len(windows) == 0 {
+		return nil, &ValidationError{Message: "at least one availability window is required"}
+	}
+	if err := validateWindows(windows); err != nil {
+		return nil, err
+	}
+
+	var stored []models.UserAvailability
+	err := s.tx.WithinTransaction(func(r repository.Repositories) error {
+		existing, err := findAvailabilityForUpdate(r, userID, eventID)
+		if err != nil {
+			return err
+		}
+		intervals := append(availabilityIntervals(existing), availabilityIntervals(windows)...)
+		stored, err = syncAvailability(ctx, r, userID, eventID, models.AvailabilitySourceManual, existing, mergeIntervals(intervals))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stored, nil
+}
+
+func validateWindows(windows []models.UserAvailability) error {
+	for i, window := range windows {
+		if !window.StartTime.Before(window.EndTime) {
+			return &ValidationError{Field: fmt.Sprintf("[%d].end_time", i), Message: "start time must be before end time"}
+		}
+	}
+	return nil
+}
+
+// findAvailabilityForUpdate checks the user and event exist and returns the
+// user's current availability for the event
+func findAvailabilityForUpdate(r repository.Repositories, userID, eventID uint) ([]models.UserAvailability, error) {
+	if _, err := r.Users.FindByID(userID); err != nil {
+		return nil, notFound(err, "user", userID)
+	}
+	if _, err := r.Events.FindByID(eventID); err != nil {
+		return nil, notFound(err, "event", eventID)
+	}
+	return r.Availability.FindByUserAndEvent(userID, eventID)
+}
+
+// syncAvailability makes the stored records match intervals: records from source that
+// already equal an interval are kept, the rest are deleted and the missing ones created
+func syncAvailability(ctx context.Context, r repository.Repositories, userID, eventID uint, source string, existing []models.UserAvailability, intervals []interval) ([]models.UserAvailability, error) {
+	result := make([]models.UserAvailability, 0, len(intervals))
+	kept := make(map[uint]bool)
+	for _, iv := range intervals {
+		var match *models.UserAvailability
+		for i := range existing {
+			if !kept[existing[i].ID] && existing[i].Source == source && existing[i].StartTime.Equal(iv.Start) && existing[i].EndTime.Equal(iv.End) {
+				match = &existing[i]
+				break
+			}
+		}
+		if match != nil {
+			kept[match.ID] = true
+			result = append(result, *match)
+			continue
+		}
+
+		availability := models.UserAvailability{
+			UserID:    userID,
+			EventID:   eventID,
+			StartTime: iv.Start,
+			EndTime:   iv.End,
+			Source:    source,
+		}
+		if err := r.Availability.Create(&availability); err != nil {
+			return nil, err
+		}
+		if err := recordAudit(ctx, r.Audit, AuditCreate, AggregateAvailability, availability.ID, nil, &availability); err != nil {
+			return nil, err
+		}
+		if err := recordDomainEvent(r.Outbox, AvailabilityCreated, AggregateAvailability, availability.ID, &availability); err != nil {
+			return nil, err
+		}
+		result = append(result, availability)
+	}
+
+	for i := range existing {
+		before := &existing[i]
+		if kept[before.ID] {
+			continue
+		}
+		if err := r.Availability.Delete(before.ID, before.Version); err != nil {
+			return nil, err
+		}
+		if err := recordAudit(ctx, r.Audit, AuditDelete, AggregateAvailability, before.ID, before, nil); err != nil {
+			return nil, err
+		}
+		if err := recordDomainEvent(r.Outbox, AvailabilityDeleted, AggregateAvailability, before.ID, map[string]uint{"id": before.ID}); err != nil {
+			return nil, err
+		}
+	}
+	if err := syncParticipation(ctx, r, userID, eventID); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *AvailabilityService) UpdateAvailability(ctx context.Context, id uint, availability *models.UserAvailability) error {
+	if err := validateTimeRange(availability.StartTime, availability.EndTime); err != nil {
+		return err
+	}
+	return s.tx.WithinTransaction(func(r repository.Repositories) error {
+		before, err := r.Availability.FindByID(id)
+		if err != nil {
+			return notFound(err, "availability", id)
+		}
+		if before.UserID != availability.UserID || before.EventID != availability.EventID {
+			return &NotFoundError{Resource: "availability", ID: id}
+		}
+		if availability.Version, err = expectVersion(before.Version, availability.Version); err != nil {
+			return err
+		}
+		// Editing a materialized record turns it into the user's own answer.
+		availability.Source = models.AvailabilitySourceManual
+		if err := r.Availability.Update(id, availability); err != nil {
+			return err
+		}
+		updated, err := r.Availability.FindByID(id)
+		if err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, r.Audit, AuditUpdate, AggregateAvailability, id, before, updated); err != nil {
+			return err
+		}
+		return recordDomainEvent(r.Outbox, AvailabilityUpdated, AggregateAvailability, id, updated)
+	})
+}
+
+// DeleteAvailability deletes one of a user's availability records for an event.
+// A non-zero version must match the record's current version.
+func (s *AvailabilityService) DeleteAvailability(ctx context.Context, userID, eventID, id, version uint) error {
+	return s.tx.WithinTransaction(func(r repository.Repositories) error {
+		before, err := r.Availability.FindByID(id)
+		if err != nil {
+			return notFound(err, "availability", id)
+		}
+		if before.UserID != userID || before.EventID != eventID {
+			return &NotFoundError{Resource: "availability", ID: id}
+		}
+		if version, err = expectVersion(before.Version, version); err != nil {
+			return err
+		}
+		if err := r.Availability.Delete(id, version); err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, r.Audit, AuditDelete, AggregateAvailability, id, before, nil); err != nil {
+			return err
+		}
+		if err := recordDomainEvent(r.Outbox, AvailabilityDeleted, AggregateAvailability, id, map[string]uint{"id": id}); err != nil {
+			return err
+		}
+		return syncParticipation(ctx, r, userID, eventID)
+	})
 }
 
 // RecommendationService handles business logic for generating time slot recommendations
 type RecommendationService struct {
-	eventRepo        repository.EventRepository
-	timeSlotRepo     repository.TimeSlotRepository
-	availabilityRepo repository.UserAvailabilityRepository
+	tx repository.Transactor
 }
 
-func NewRecommendationService(
-	eventRepo repository.EventRepository,
-	timeSlotRepo repository.TimeSlotRepository,
-	availabilityRepo repository.UserAvailabilityRepository,
-) *RecommendationService {
-	return &RecommendationService{
-		eventRepo:        eventRepo,
-		timeSlotRepo:     timeSlotRepo,
-		availabilityRepo: availabilityRepo,
-	}
+func NewRecommendationService(tx repository.Transactor) *RecommendationService {
+	return &RecommendationService{tx: tx}
 }
 
-func (s *RecommendationService) GetRecommendations(eventID uint) ([]models.TimeSlotRecommendation, error) {
-	// Get the event to retrieve duration
-	event, err := s.eventRepo.FindByID(eventID)
+// recommendationInputs is what ranking an event's time slots is based on
+type recommendationInputs struct {
+	event *models.Event
+	slots []models.TimeSlot
+	// Invitees who responded are matched against their availability; those who
+	// have not responded yet can't match but still count towards the percentage
+	users, nonResponders []models.User
+	availability         map[uint][]models.UserAvailability
+	votes                []models.SlotVote
+	// Other scheduled events and series occurrences are busy time for every
+	// invitee; they are loaded a day beyond the slots for buffers and daily caps
+	busy map[uint][]models.Commitment
+	// required lists the IDs of the required participants, declined or not, for
+	// events whose quorum needs all of them
+	required []uint
+	// locations holds the timezones daily caps are counted in, by user ID
+	locations map[uint]*time.Location
+	// Resources the event could be held with and their bookings over the slots,
+	// for events that need one
+	needsResource bool
+	resources     []models.Resource
+	bookings      []models.ResourceBooking
+	// With explain set every candidate start is explained, including against the
+	// responders' working hours, their weekly availability rules
+	explain      bool
+	workingHours map[uint][]interval
+}
+
+// loadInputs fetches the event, its time slots and everything invitees told us
+// about them in a transaction of its own
+func (s *RecommendationService) loadInputs(eventID uint) (*recommendationInputs, error) {
+	var in *recommendationInputs
+	err := s.tx.WithinTransaction(func(r repository.Repositories) error {
+		var err error
+		in, err = loadRecommendationInputs(r, eventID)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
+	return in, nil
+}
 
-	durationMinutes := event.DurationMinutes
+// loadRecommendationInputs fetches the event, its time slots and everything
+// invitees told us about them
+func loadRecommendationInputs(r repository.Repositories, eventID uint) (*recommendationInputs, error) {
+	// Get the event to retrieve duration
+	event, err := r.Events.FindByID(eventID)
+	if err != nil {
+		return nil, notFound(err, "event", eventID)
+	}
+	in := &recommendationInputs{event: event}
 
 	// Get all time slots for the event
-	timeSlots, err := s.timeSlotRepo.FindByEventID(eventID)
-	if err != nil {
+	if in.slots, err = r.TimeSlots.FindByEventID(eventID); err != nil {
 		return nil, err
 	}
 
 	// Fetch all availabilities for this event in one query (bulk fetch)
-	allAvailabilities, err := s.availabilityRepo.FindByEvent(eventID)
+	allAvailabilities, err := r.Availability.FindByEvent(eventID)
 	if err != nil {
 		return nil, err
 	}
+	// Build a map of userID -> slice of availabilities for quick lookup
+	in.availability = make(map[uint][]models.UserAvailability)
+	for _, avail := range allAvailabilities {
+		in.availability[avail.UserID] = append(in.availability[avail.UserID], avail)
+	}
 
-	// Get all users who have provided availability for this event
-	users, err := s.availabilityRepo.FindAllUsersByEvent(eventID)
-	if err != nil {
+	if in.users, in.nonResponders, err = eventInvitees(r, eventID); err != nil {
 		return nil, err
 	}
+	if event.RecommendationStrategy == models.StrategyVotes {
+		if in.votes, err = r.Votes.FindByEvent(eventID); err != nil {
+			return nil, err
+		}
+	}
+	if event.QuorumAllRequired {
+		participants, err := r.Participants.FindByEvent(eventID)
+		if err != nil {
+			return nil, err
+		}
+		for _, participant := range participants {
+			if participant.Required {
+				in.required = append(in.required, participant.UserID)
+			}
+		}
+	}
+	if in.needsResource = needsResource(event); in.needsResource && len(in.slots) > 0 {
+		if in.resources, err = r.Resources.FindSuitable(event.ResourceID, event.MinCapacity); err != nil {
+			return nil, err
+		}
+		ids := make([]uint, 0, len(in.resources))
+		for _, resource := range in.resources {
+			ids = append(ids, resource.ID)
+		}
+		from, to := slotSpan(in.slots)
+		if in.bookings, err = r.Resources.FindActiveBookings(ids, from, to); err != nil {
+			return nil, err
+		}
+	}
 
-	// Build a map of userID -> slice of availabilities for quick lookup
-	availabilityMap := make(map[uint][]models.UserAvailability)
-	for _, avail := range allAvailabilities {
-		availabilityMap[avail.UserID] = append(availabilityMap[avail.UserID], avail)
+	invitees := append(append([]models.User{}, in.users...), in.nonResponders...)
+	if in.busy, err = participantCommitments(r, eventID, invitees, in.slots); err != nil {
+		return nil, err
+	}
+	return in, nil
+}
+
+// location returns the timezone the user's daily cap is counted in, UTC when
+// theirs is unknown
+func (in *recommendationInputs) location(user models.User) *time.Location {
+	if location, ok := in.locations[user.ID]; ok {
+		return location
+	}
+	location, err := time.LoadLocation(user.Timezone)
+	if err != nil {
+		location = time.UTC
+	}
+	if in.locations == nil {
+		in.locations = make(map[uint]*time.Location)
 	}
+	in.locations[user.ID] = location
+	return location
+}
+
+// freeResources returns the resources the event could be held with that are
+// free from start to end
+func (in *recommendationInputs) freeResources(start, end time.Time) []models.Resource {
+	return freeResources(in.resources, in.bookings, start, end)
+}
+
+// clashes reports whether any invitee has a commitment overlapping start to end.
+// Finalizing refuses such a start, so it is never recommended.
+func (in *recommendationInputs) clashes(start, end time.Time) bool {
+	for _, commitments := range in.busy {
+		for _, commitment := range commitments {
+			if start.Before(commitment.EndTime) && end.After(commitment.StartTime) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// attendance splits the responders into those available for the whole meeting
+// from startTime to endTime, clear of their commitments with room for their
+// buffers and daily cap, and those who are not
+func (in *recommendationInputs) attendance(startTime, endTime time.Time) (matchingUsers, nonMatchingUsers []models.User) {
+	// Check each user's availabilities from the pre-fetched map
+	for _, user := range in.users {
+		available := false
+		for _, avail := range in.availability[user.ID] {
+			if !startTime.Before(avail.StartTime) && !endTime.After(avail.EndTime) {
+				available = true
+				break
+			}
+		}
+		if available && !withinLimits(user, in.location(user), in.busy[user.ID], startTime, endTime) {
+			available = false
+		}
+		if available {
+			matchingUsers = append(matchingUsers, user)
+		} else {
+			nonMatchingUsers = append(nonMatchingUsers, user)
+		}
+	}
+	return matchingUsers, nonMatchingUsers
+}
+
+// GetRecommendations ranks the event's time slots by invitees' availability, or
+// by their votes when the event uses the votes strategy
+func (s *RecommendationService) GetRecommendations(eventID uint) ([]models.TimeSlotRecommendation, error) {
+	return s.recommend(eventID, false)
+}
+
+// ExplainRecommendations ranks the event's time slots like GetRecommendations
+// and explains, for every candidate start of each slot, which invitees can't
+// attend and why. Slots nobody can attend are included, without start options,
+// so they can be explained too.
+func (s *RecommendationService) ExplainRecommendations(eventID uint) ([]models.TimeSlotRecommendation, error) {
+	return s.recommend(eventID, true)
+}
+
+func (s *RecommendationService) recommend(eventID uint, explain bool) ([]models.TimeSlotRecommendation, error) {
+	in, err := s.loadInputs(eventID)
+	if err != nil {
+		return nil, err
+	}
+	if in.explain = explain; explain {
+		if err := s.tx.WithinTransaction(in.loadWorkingHours); err != nil {
+			return nil, err
+		}
+	}
+	if in.event.RecommendationStrategy == models.StrategyVotes {
+		return voteRecommendations(in), nil
+	}
+
+	durationMinutes := in.event.DurationMinutes
+	users, nonResponders, busyMap := in.users, in.nonResponders, in.busy
 
 	var recommendations []models.TimeSlotRecommendation
 
 	// For each time slot, calculate which users can attend
-	for _, slot := range timeSlots {
+	for _, slot := range in.slots {
 		var bestMatchingUsers []models.User
 		var bestNonMatchingUsers []models.User
 		var startOptions []time.Time
+		var explanations []models.StartExplanation
+		bestMeetsQuorum := false
 
 		// Check if the slot duration is sufficient for the meeting
 		slotDuration := slot.EndTime.Sub(slot.StartTime).Minutes()
@@ -221,60 +930,120 @@ func (s *RecommendationService) GetRecommendations(eventID uint) ([]models.TimeS
 		// Iterate through possible start times at 15-minute intervals
 		for startTime := slot.StartTime; !startTime.After(maxStartTime); startTime = startTime.Add(15 * time.Minute) {
 			endTime := startTime.Add(time.Duration(durationMinutes) * time.Minute)
-			var matchingUsers []models.User
-			var nonMatchingUsers []models.User
-
-			// Check each user's availabilities from the pre-fetched map
-			for _, user := range users {
-				availabilities := availabilityMap[user.ID]
-				available := false
-				for _, avail := range availabilities {
-					if !startTime.Before(avail.StartTime) && !endTime.After(avail.EndTime) {
-						available = true
-						break
-					}
-				}
-				if available {
-					matchingUsers = append(matchingUsers, user)
-				} else {
-					nonMatchingUsers = append(nonMatchingUsers, user)
-				}
+			matchingUsers, nonMatchingUsers := in.attendance(startTime, endTime)
+			// A start without a suitable resource free can't be booked
+			noResource := in.needsResource && len(in.freeResources(startTime, endTime)) == 0
+			if in.explain {
+				explanations = append(explanations, in.explainStart(startTime, endTime, matchingUsers, noResource))
 			}
+			if noResource || in.clashes(startTime, endTime) {
+				continue
+			}
+			meetsQuorum := in.quorumShortfall(matchingUsers) == ""
 
-			// Update best option if current matching count is better
-			if len(matchingUsers) > len(bestMatchingUsers) {
+			// Update best option if it meets quorum where the best doesn't, or
+			// has a better matching count
+			if (meetsQuorum && !bestMeetsQuorum) || (meetsQuorum == bestMeetsQuorum && len(matchingUsers) > len(bestMatchingUsers)) {
 				bestMatchingUsers = matchingUsers
 				bestNonMatchingUsers = nonMatchingUsers
 				startOptions = []time.Time{startTime}
-			} else if len(matchingUsers) == len(bestMatchingUsers) && len(matchingUsers) > 0 {
+				bestMeetsQuorum = meetsQuorum
+			} else if meetsQuorum == bestMeetsQuorum && len(matchingUsers) == len(bestMatchingUsers) && len(matchingUsers) > 0 {
 				// If equally good, record additional start option
 				startOptions = append(startOptions, startTime)
 			}
 		}
 
-		// Skip slot if no valid start time is found
+		// Skip slot if no valid start time is found, unless it is to be explained
 		if len(bestMatchingUsers) == 0 {
+			if in.explain {
+				recommendations = append(recommendations, models.TimeSlotRecommendation{
+					TimeSlot:      slot,
+					NonResponders: nonResponders,
+					EventDuration: durationMinutes,
+					QuorumMet:     in.quorumShortfall(nil) == "",
+					Explanations:  explanations,
+				})
+			}
 			continue
 		}
 
 		// Calculate matching percentage for this slot
-		matchingPercentage := float64(len(bestMatchingUsers)) / float64(len(users)) * 100
+		matchingPercentage := float64(len(bestMatchingUsers)) / float64(len(users)+len(nonResponders)) * 100
+
+		// Flag participants' commitments that overlap the slot
+		var conflicts []models.ParticipantConflict
+		for _, user := range users {
+			for _, commitment := range busyMap[user.ID] {
+				if slot.StartTime.Before(commitment.EndTime) && slot.EndTime.After(commitment.StartTime) {
+					conflicts = append(conflicts, models.ParticipantConflict{UserID: user.ID, Commitment: commitment})
+				}
+			}
+		}
+
+		var resources []models.Resource
+		if in.needsResource {
+			resources = in.freeResources(startOptions[0], startOptions[0].Add(time.Duration(durationMinutes)*time.Minute))
+		}
 
 		// Append the recommendation for this time slot
 		recommendations = append(recommendations, models.TimeSlotRecommendation{
 			TimeSlot:           slot,
 			MatchingUsers:      bestMatchingUsers,
 			NonMatchingUsers:   bestNonMatchingUsers,
+			NonResponders:      nonResponders,
 			MatchingPercentage: matchingPercentage,
 			EventDuration:      durationMinutes,
 			StartOptions:       startOptions,
+			Conflicts:          conflicts,
+			Resources:          resources,
+			QuorumMet:          bestMeetsQuorum,
+			Explanations:       explanations,
 		})
 	}
 
-	// Sort recommendations by matching percentage (highest first)
+	// Sort recommendations meeting quorum first, then by matching percentage (highest first)
 	sort.Slice(recommendations, func(i, j int) bool {
+		if recommendations[i].QuorumMet != recommendations[j].QuorumMet {
+			return recommendations[i].QuorumMet
+		}
 		return recommendations[i].MatchingPercentage > recommendations[j].MatchingPercentage
 	})
 
 	return recommendations, nil
 }
+
+// participantCommitments returns each user's commitments, other than the event
+// itself, that overlap the span of the event's time slots widened by a day on
+// either side
+func participantCommitments(r repository.Repositories, eventID uint, users []models.User, slots []models.TimeSlot) (map[uint][]models.Commitment, error) {
+	busy := make(map[uint][]models.Commitment)
+	if len(slots) == 0 {
+		return busy, nil
+	}
+	from, to := slotSpan(slots)
+	from, to = from.Add(-limitsWindow), to.Add(limitsWindow)
+
+	for _, user := range users {
+		commitments, err := userCommitments(r, user.ID, from, to, eventID)
+		if err != nil {
+			return nil, err
+		}
+		busy[user.ID] = commitments
+	}
+	return busy, nil
+}
+
+// slotSpan returns the earliest start and latest end of a non-empty list of slots
+func slotSpan(slots []models.TimeSlot) (from, to time.Time) {
+	from, to = slots[0].StartTime, slots[0].EndTime
+	for _, slot := range slots[1:] {
+		if slot.StartTime.Before(from) {
+			from = slot.StartTime
+		}
+		if slot.EndTime.After(to) {
+			to = slot.EndTime
+		}
+	}
+	return from, to
+}
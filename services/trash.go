@@ -0,0 +1,152 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/krushnna/meeting-scheduler/models"
+	"github.com/krushnna/meeting-scheduler/repository"
+	"gorm.io/gorm"
+)
+
+// TrashService handles listing, restoring and purging soft-deleted records
+type TrashService struct {
+	repo      repository.TrashRepository
+	tx        repository.Transactor
+	retention time.Duration
+}
+
+func NewTrashService(repo repository.TrashRepository, tx repository.Transactor, retention time.Duration) *TrashService {
+	return &TrashService{repo: repo, tx: tx, retention: retention}
+}
+
+// GetDeletedEvents returns one page of soft-deleted events
+func (s *TrashService) GetDeletedEvents(page repository.PageRequest) (*repository.Page[models.Event], error) {
+	return s.repo.FindDeletedEvents(page)
+}
+
+// GetDeletedUsers returns one page of soft-deleted users
+func (s *TrashService) GetDeletedUsers(page repository.PageRequest) (*repository.Page[models.User], error) {
+	return s.repo.FindDeletedUsers(page)
+}
+
+// RestoreEvent brings back a deleted event with the time slots and availability
+// that were deleted along with it
+func (s *TrashService) RestoreEvent(ctx context.Context, id uint) (*models.Event, error) {
+	var restored *models.Event
+	err := s.tx.WithinTransaction(func(r repository.Repositories) error {
+		event, err := r.Trash.FindDeletedEventByID(id)
+		if err != nil {
+			return notFound(err, "deleted event", id)
+		}
+		if err := r.Trash.RestoreEvent(id, event.DeletedAt.Time); err != nil {
+			return err
+		}
+		if restored, err = r.Events.FindByID(id); err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, r.Audit, AuditRestore, AggregateEvent, id, event, restored); err != nil {
+			return err
+		}
+		return recordDomainEvent(r.Outbox, EventRestored, AggregateEvent, id, restored)
+	})
+	return restored, err
+}
+
+// RestoreUser brings back a deleted user with their availability, provided no
+// live user has claimed the same email in the meantime
+func (s *TrashService) RestoreUser(ctx context.Context, id uint) (*models.User, error) {
+	var restored *models.User
+	err := s.tx.WithinTransaction(func(r repository.Repositories) error {
+		user, err := r.Trash.FindDeletedUserByID(id)
+		if err != nil {
+			return notFound(err, "deleted user", id)
+		}
+		if _, err := r.Users.FindByEmail(user.Email); err == nil {
+			return ErrEmailInUse
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if err := r.Trash.RestoreUser(id, user.DeletedAt.Time); err != nil {
+			return err
+		}
+		if restored, err = r.Users.FindByID(id); err != nil {
+			return err
+		}
+		return recordAudit(ctx, r.Audit, AuditRestore, AggregateUser, id, user, restored)
+	})
+	return restored, err
+}
+
+// PurgeEvent permanently removes a deleted event
+func (s *TrashService) PurgeEvent(ctx context.Context, id uint) error {
+	return s.tx.WithinTransaction(func(r repository.Repositories) error {
+		event, err := r.Trash.FindDeletedEventByID(id)
+		if err != nil {
+			return notFound(err, "deleted event", id)
+		}
+		if err := r.Trash.PurgeEvent(id); err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, r.Audit, AuditPurge, AggregateEvent, id, event, nil); err != nil {
+			return err
+		}
+		return recordDomainEvent(r.Outbox, EventPurged, AggregateEvent, id, map[string]uint{"id": id})
+	})
+}
+
+// PurgeUser permanently removes a deleted user
+func (s *TrashService) PurgeUser(ctx context.Context, id uint) error {
+	return s.tx.WithinTransaction(func(r repository.Repositories) error {
+		user, err := r.Trash.FindDeletedUserByID(id)
+		if err != nil {
+			return notFound(err, "deleted user", id)
+		}
+		if err := r.Trash.PurgeUser(id); err != nil {
+			return err
+		}
+		return recordAudit(ctx, r.Audit, AuditPurge, AggregateUser, id, user, nil)
+	})
+}
+
+// PurgeExpired permanently removes everything deleted longer ago than the
+// retention window, auditing each event and user it removes
+func (s *TrashService) PurgeExpired(ctx context.Context) (int64, error) {
+	cutoff := time.Now().Add(-s.retention)
+	var purged int64
+	err := s.tx.WithinTransaction(func(r repository.Repositories) error {
+		events, err := r.Trash.FindEventsDeletedBefore(cutoff)
+		if err != nil {
+			return err
+		}
+		users, err := r.Trash.FindUsersDeletedBefore(cutoff)
+		if err != nil {
+			return err
+		}
+		if purged, err = r.Trash.PurgeDeletedBefore(cutoff); err != nil {
+			return err
+		}
+		for i := range events {
+			event := &events[i]
+			if err := recordAudit(ctx, r.Audit, AuditPurge, AggregateEvent, event.ID, event, nil); err != nil {
+				return err
+			}
+			if err := recordDomainEvent(r.Outbox, EventPurged, AggregateEvent, event.ID, map[string]uint{"id": event.ID}); err != nil {
+				return err
+			}
+		}
+		for i := range users {
+			if err := recordAudit(ctx, r.Audit, AuditPurge, AggregateUser, users[i].ID, &users[i], nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return purged, err
+}
+
+// Retention returns how long deleted records are kept before being purged
+func (s *TrashService) Retention() time.Duration {
+	return s.retention
+}
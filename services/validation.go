@@ -0,0 +1,116 @@
+package services
+
+import (
+	"fmt"
+	"net/mail"
+	"time"
+
+	"github.com/krushnna/meeting-scheduler/models"
+	"github.com/krushnna/meeting-scheduler/repository"
+)
+
+func validateEvent(event *models.Event) error {
+	if event.Title == "" {
+		return &ValidationError{Field: "title", Message: "event title is required"}
+	}
+	if event.OrganizerId == 0 {
+		return &ValidationError{Field: "organizer_id", Message: "event organizer is required"}
+	}
+	if event.DurationMinutes <= 0 {
+		return &ValidationError{Field: "duration_minutes", Message: "event duration must be positive"}
+	}
+	switch event.AutoFinalize {
+	case "", models.AutoFinalizeOff, models.AutoFinalizeWhenResponded:
+	case models.AutoFinalizeAtDeadline:
+		if event.ResponseDeadline == nil {
+			return &ValidationError{Field: "response_deadline", Message: "a response deadline is required to auto-finalize at the deadline"}
+		}
+	default:
+		return &ValidationError{Field: "auto_finalize", Message: "auto_finalize must be off, deadline or responded"}
+	}
+	if event.MinCapacity < 0 {
+		return &ValidationError{Field: "min_capacity", Message: "min_capacity can't be negative"}
+	}
+	if event.Quorum < 0 {
+		return &ValidationError{Field: "quorum", Message: "quorum can't be negative"}
+	}
+	if event.QuorumPercentage < 0 || event.QuorumPercentage > 100 {
+		return &ValidationError{Field: "quorum_percentage", Message: "quorum_percentage must be between 0 and 100"}
+	}
+	if event.Quorum > 0 && event.QuorumPercentage > 0 {
+		return &ValidationError{Field: "quorum_percentage", Message: "set either quorum or quorum_percentage, not both"}
+	}
+	switch event.RecommendationStrategy {
+	case "", models.StrategyAvailability, models.StrategyVotes:
+	default:
+		return &ValidationError{Field: "recommendation_strategy", Message: "recommendation_strategy must be availability or votes"}
+	}
+	return nil
+}
+
+// validateStatusChange allows clients to reopen or cancel an event; an event
+// only becomes scheduled by being finalized and goes back to polling from
+// scheduled by being rescheduled
+func validateStatusChange(current, next string) error {
+	if next == "" || next == current {
+		return nil
+	}
+	if next != models.EventStatusPolling && next != models.EventStatusCancelled {
+		return &ValidationError{Field: "status", Message: "status must be polling or cancelled"}
+	}
+	if current == models.EventStatusScheduled && next == models.EventStatusPolling {
+		return &ConflictError{Message: "event is scheduled; reschedule it to reopen polling"}
+	}
+	return nil
+}
+
+func validateTimeRange(start, end time.Time) error {
+	if start.After(end) || start.Equal(end) {
+		return &ValidationError{Field: "end_time", Message: "start time must be before end time"}
+	}
+	return nil
+}
+
+func validateResource(resource *models.Resource) error {
+	if resource.Name == "" {
+		return &ValidationError{Field: "name", Message: "resource name is required"}
+	}
+	if resource.Kind != models.ResourceKindRoom && resource.Kind != models.ResourceKindEquipment {
+		return &ValidationError{Field: "kind", Message: "kind must be room or equipment"}
+	}
+	if resource.Capacity < 0 {
+		return &ValidationError{Field: "capacity", Message: "capacity can't be negative"}
+	}
+	return nil
+}
+
+func validateUser(user *models.User) error {
+	if user.Name == "" {
+		return &ValidationError{Field: "name", Message: "user name is required"}
+	}
+	if _, err := mail.ParseAddress(user.Email); err != nil {
+		return &ValidationError{Field: "email", Message: "user email must be a valid address"}
+	}
+	if user.Timezone == "" {
+		return &ValidationError{Field: "timezone", Message: "user timezone is required"}
+	}
+	if user.BufferBeforeMinutes < 0 || user.BufferBeforeMinutes > MaxBufferMinutes {
+		return &ValidationError{Field: "buffer_before_minutes", Message: fmt.Sprintf("buffer_before_minutes must be between 0 and %d", MaxBufferMinutes)}
+	}
+	if user.BufferAfterMinutes < 0 || user.BufferAfterMinutes > MaxBufferMinutes {
+		return &ValidationError{Field: "buffer_after_minutes", Message: fmt.Sprintf("buffer_after_minutes must be between 0 and %d", MaxBufferMinutes)}
+	}
+	if user.MaxDailyMeetingMinutes < 0 || user.MaxDailyMeetingMinutes > 24*60 {
+		return &ValidationError{Field: "max_daily_meeting_minutes", Message: "max_daily_meeting_minutes must be between 0 and 1440"}
+	}
+	return nil
+}
+
+// expectVersion returns the version a write must match: the caller's expected
+// version when one was given, otherwise the version that was just read
+func expectVersion(current, expected uint) (uint, error) {
+	if expected != 0 && expected != current {
+		return 0, repository.ErrStaleVersion
+	}
+	return current, nil
+}
@@ -0,0 +1,312 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/krushnna/meeting-scheduler/models"
+	"github.com/krushnna/meeting-scheduler/repository"
+)
+
+// VoteService handles business logic for votes on an event's time slots
+type VoteService struct {
+	repo repository.SlotVoteRepository
+	tx   repository.Transactor
+}
+
+func NewVoteService(repo repository.SlotVoteRepository, tx repository.Transactor) *VoteService {
+	return &VoteService{repo: repo, tx: tx}
+}
+
+// SubmitVotes replaces a user's votes on an event's time slots with ballot. Slots
+// left out of the ballot lose the user's vote, so an empty ballot withdraws every
+// vote. The stored votes are returned in slot order.
+func (s *VoteService) SubmitVotes(ctx context.Context, userID, eventID uint, ballot []models.SlotVote) ([]models.SlotVote, error) {
+	seen := make(map[uint]bool)
+	for i, vote := range ballot {
+		if vote.Vote != models.VoteYes && vote.Vote != models.VoteMaybe && vote.Vote != models.VoteNo {
+			return nil, &ValidationError{Field: fmt.Sprintf("[%d].vote", i), Message: "vote must be yes, maybe or no"}
+		}
+		if seen[vote.TimeSlotID] {
+			return nil, &ValidationError{Field: fmt.Sprintf("[%d].time_slot_id", i), Message: "each time slot can only be voted on once"}
+		}
+		seen[vote.TimeSlotID] = true
+	}
+
+	var stored []models.SlotVote
+	err := s.tx.WithinTransaction(func(r repository.Repositories) error {
+		if _, err := r.Users.FindByID(userID); err != nil {
+			return notFound(err, "user", userID)
+		}
+		if _, err := r.Events.FindByID(eventID); err != nil {
+			return notFound(err, "event", eventID)
+		}
+		slots, err := r.TimeSlots.FindByEventID(eventID)
+		if err != nil {
+			return err
+		}
+		eventSlots := make(map[uint]bool, len(slots))
+		for _, slot := range slots {
+			eventSlots[slot.ID] = true
+		}
+		for _, vote := range ballot {
+			if !eventSlots[vote.TimeSlotID] {
+				return &NotFoundError{Resource: "time slot", ID: vote.TimeSlotID}
+			}
+		}
+
+		if stored, err = replaceVotes(ctx, r, userID, eventID, ballot); err != nil {
+			return err
+		}
+		payload := map[string]interface{}{"user_id": userID, "votes": stored}
+		if err := recordDomainEvent(r.Outbox, VotesSubmitted, AggregateEvent, eventID, payload); err != nil {
+			return err
+		}
+		return syncParticipation(ctx, r, userID, eventID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stored, nil
+}
+
+// GetUserVotes returns a user's votes on an event in slot order
+func (s *VoteService) GetUserVotes(userID, eventID uint) ([]models.SlotVote, error) {
+	votes := []models.SlotVote{}
+	err := s.tx.WithinTransaction(func(r repository.Repositories) error {
+		if _, err := r.Users.FindByID(userID); err != nil {
+			return notFound(err, "user", userID)
+		}
+		if _, err := r.Events.FindByID(eventID); err != nil {
+			return notFound(err, "event", eventID)
+		}
+		found, err := r.Votes.FindByUserAndEvent(userID, eventID)
+		if err != nil {
+			return err
+		}
+		votes = append(votes, found...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return votes, nil
+}
+
+// GetResults breaks down the votes on each of an event's time slots, in start order
+func (s *VoteService) GetResults(eventID uint) ([]models.SlotVoteResult, error) {
+	results := []models.SlotVoteResult{}
+	err := s.tx.WithinTransaction(func(r repository.Repositories) error {
+		if _, err := r.Events.FindByID(eventID); err != nil {
+			return notFound(err, "event", eventID)
+		}
+		slots, err := r.TimeSlots.FindByEventID(eventID)
+		if err != nil {
+			return err
+		}
+		votes, err := r.Votes.FindByEvent(eventID)
+		if err != nil {
+			return err
+		}
+
+		bySlot := make(map[uint][]models.SlotVote)
+		for _, vote := range votes {
+			bySlot[vote.TimeSlotID] = append(bySlot[vote.TimeSlotID], vote)
+		}
+		sort.Slice(slots, func(i, j int) bool { return slots[i].StartTime.Before(slots[j].StartTime) })
+		for _, slot := range slots {
+			result := models.SlotVoteResult{TimeSlot: slot, Yes: []models.User{}, Maybe: []models.User{}, No: []models.User{}}
+			for _, vote := range bySlot[slot.ID] {
+				switch vote.Vote {
+				case models.VoteYes:
+					result.Yes = append(result.Yes, *vote.User)
+				case models.VoteMaybe:
+					result.Maybe = append(result.Maybe, *vote.User)
+				case models.VoteNo:
+					result.No = append(result.No, *vote.User)
+				}
+			}
+			result.Score = voteScore(len(result.Yes), len(result.Maybe))
+			results = append(results, result)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// voteScore counts a yes as one and a maybe as half
+func voteScore(yes, maybe int) float64 {
+	return float64(yes) + float64(maybe)/2
+}
+
+// replaceVotes makes the user's stored votes on the event match ballot: votes on
+// the same slot are changed in place, the rest are deleted and the missing ones
+// created
+func replaceVotes(ctx context.Context, r repository.Repositories, userID, eventID uint, ballot []models.SlotVote) ([]models.SlotVote, error) {
+	existing, err := r.Votes.FindByUserAndEvent(userID, eventID)
+	if err != nil {
+		return nil, err
+	}
+	bySlot := make(map[uint]*models.SlotVote, len(existing))
+	for i := range existing {
+		bySlot[existing[i].TimeSlotID] = &existing[i]
+	}
+
+	stored := make([]models.SlotVote, 0, len(ballot))
+	for _, vote := range ballot {
+		before, ok := bySlot[vote.TimeSlotID]
+		if !ok {
+			created := models.SlotVote{EventID: eventID, TimeSlotID: vote.TimeSlotID, UserID: userID, Vote: vote.Vote}
+			if err := r.Votes.Create(&created); err != nil {
+				return nil, err
+			}
+			if err := recordAudit(ctx, r.Audit, AuditCreate, AggregateSlotVote, created.ID, nil, &created); err != nil {
+				return nil, err
+			}
+			stored = append(stored, created)
+			continue
+		}
+		delete(bySlot, vote.TimeSlotID)
+		updated := *before
+		if before.Vote != vote.Vote {
+			if err := r.Votes.UpdateVote(before.ID, vote.Vote); err != nil {
+				return nil, err
+			}
+			updated.Vote = vote.Vote
+			if err := recordAudit(ctx, r.Audit, AuditUpdate, AggregateSlotVote, before.ID, before, &updated); err != nil {
+				return nil, err
+			}
+		}
+		stored = append(stored, updated)
+	}
+
+	for _, before := range bySlot {
+		if err := r.Votes.Delete(before.ID); err != nil {
+			return nil, err
+		}
+		if err := recordAudit(ctx, r.Audit, AuditDelete, AggregateSlotVote, before.ID, before, nil); err != nil {
+			return nil, err
+		}
+	}
+	sort.Slice(stored, func(i, j int) bool { return stored[i].TimeSlotID < stored[j].TimeSlotID })
+	return stored, nil
+}
+
+// voteRecommendations ranks the event's time slots by the invitees' votes. Yes and
+// maybe voters count as matching, a maybe at half weight in the percentage,
+// provided the meeting fits around their commitments, buffers and daily cap;
+// responders who voted no or not at all don't match. Slots nobody voted for are
+// left out, as are slots whose start has no suitable resource free for events
+// that need one or clashes with an invitee's commitments, unless the
+// recommendations are explained. Each slot offers its
+// own start as the only start option.
+func voteRecommendations(in *recommendationInputs) []models.TimeSlotRecommendation {
+	event, users, nonResponders, busyMap := in.event, in.users, in.nonResponders, in.busy
+	ballots := make(map[uint]map[uint]string)
+	for _, vote := range in.votes {
+		if ballots[vote.TimeSlotID] == nil {
+			ballots[vote.TimeSlotID] = make(map[uint]string)
+		}
+		ballots[vote.TimeSlotID][vote.UserID] = vote.Vote
+	}
+
+	var recommendations []models.TimeSlotRecommendation
+	for _, slot := range in.slots {
+		if slot.EndTime.Sub(slot.StartTime) < time.Duration(event.DurationMinutes)*time.Minute {
+			continue
+		}
+		end := slot.StartTime.Add(time.Duration(event.DurationMinutes) * time.Minute)
+		var resources []models.Resource
+		if in.needsResource {
+			resources = in.freeResources(slot.StartTime, end)
+		}
+
+		var matchingUsers, nonMatchingUsers []models.User
+		tally := models.VoteTally{}
+		yes, maybe := 0, 0
+		for _, user := range users {
+			vote := ballots[slot.ID][user.ID]
+			switch vote {
+			case models.VoteYes:
+				tally.Yes++
+			case models.VoteMaybe:
+				tally.Maybe++
+			case models.VoteNo:
+				tally.No++
+			}
+			// A voter who would be double-booked or over their limits can't come
+			// however they voted.
+			if (vote == models.VoteYes || vote == models.VoteMaybe) && withinLimits(user, in.location(user), busyMap[user.ID], slot.StartTime, end) {
+				if vote == models.VoteYes {
+					yes++
+				} else {
+					maybe++
+				}
+				matchingUsers = append(matchingUsers, user)
+			} else {
+				nonMatchingUsers = append(nonMatchingUsers, user)
+			}
+		}
+		var explanations []models.StartExplanation
+		noResource := in.needsResource && len(resources) == 0
+		if in.explain {
+			explanations = []models.StartExplanation{in.explainStart(slot.StartTime, end, matchingUsers, noResource)}
+		}
+		if len(matchingUsers) == 0 || noResource || in.clashes(slot.StartTime, end) {
+			// Slots that can't be recommended are still listed when explaining.
+			if in.explain {
+				recommendations = append(recommendations, models.TimeSlotRecommendation{
+					TimeSlot:      slot,
+					NonResponders: nonResponders,
+					EventDuration: event.DurationMinutes,
+					Votes:         &tally,
+					QuorumMet:     in.quorumShortfall(nil) == "",
+					Explanations:  explanations,
+				})
+			}
+			continue
+		}
+
+		var conflicts []models.ParticipantConflict
+		for _, user := range users {
+			for _, commitment := range busyMap[user.ID] {
+				if slot.StartTime.Before(commitment.EndTime) && slot.EndTime.After(commitment.StartTime) {
+					conflicts = append(conflicts, models.ParticipantConflict{UserID: user.ID, Commitment: commitment})
+				}
+			}
+		}
+
+		votes := tally
+		recommendations = append(recommendations, models.TimeSlotRecommendation{
+			TimeSlot:           slot,
+			MatchingUsers:      matchingUsers,
+			NonMatchingUsers:   nonMatchingUsers,
+			NonResponders:      nonResponders,
+			MatchingPercentage: voteScore(yes, maybe) / float64(len(users)+len(nonResponders)) * 100,
+			EventDuration:      event.DurationMinutes,
+			StartOptions:       []time.Time{slot.StartTime},
+			Conflicts:          conflicts,
+			Votes:              &votes,
+			Resources:          resources,
+			QuorumMet:          in.quorumShortfall(matchingUsers) == "",
+			Explanations:       explanations,
+		})
+	}
+
+	// Slots meeting quorum come first and more yes votes break ties between equal scores.
+	sort.SliceStable(recommendations, func(i, j int) bool {
+		if recommendations[i].QuorumMet != recommendations[j].QuorumMet {
+			return recommendations[i].QuorumMet
+		}
+		if recommendations[i].MatchingPercentage != recommendations[j].MatchingPercentage {
+			return recommendations[i].MatchingPercentage > recommendations[j].MatchingPercentage
+		}
+		return recommendations[i].Votes.Yes > recommendations[j].Votes.Yes
+	})
+	return recommendations
+}
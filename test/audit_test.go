@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/krushnna/meeting-scheduler/models"
+)
+
+// TestAuditTrail verifies mutations are recorded with actor, request ID and diff.
+func TestAuditTrail(t *testing.T) {
+	router, db := setupTestRouter()
+
+	event := createTestEvent(router, "Audited Event", 30)
+
+	updateJSON, _ := json.Marshal(map[string]interface{}{
+		"title":            "Audited Event",
+		"organizer_id":     1,
+		"duration_minutes": 45,
+	})
+	req, _ := http.NewRequest("PUT", fmt.Sprintf("/api/v1/events/%d", event.ID), bytes.NewBuffer(updateJSON))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-User-ID", "organizer-7")
+	req.Header.Set("X-Request-ID", "req-123")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	if resp.Header().Get("X-Request-ID") != "req-123" {
+		t.Errorf("Expected request ID to be echoed, got %q", resp.Header().Get("X-Request-ID"))
+	}
+
+	req, _ = http.NewRequest("GET", fmt.Sprintf("/api/v1/audit?entity_type=event&entity_id=%d&action=update", event.ID), nil)
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	if resp.Code != http.StatusOK {
+		t.Fatalf("Expected 200 on audit query, got %d", resp.Code)
+	}
+
+	var entries struct {
+		Data  []models.AuditLog `json:"data"`
+		Total int64             `json:"total"`
+	}
+	json.Unmarshal(resp.Body.Bytes(), &entries)
+	if len(entries.Data) != 1 || entries.Total != 1 {
+		t.Fatalf("Expected 1 update entry, got %s", resp.Body.String())
+	}
+	entry := entries.Data[0]
+	if entry.Actor != "organizer-7" || entry.RequestID != "req-123" {
+		t.Errorf("Unexpected attribution: actor %q, request %q", entry.Actor, entry.RequestID)
+	}
+	change, ok := entry.Changes["duration_minutes"]
+	if !ok || change.From != float64(30) || change.To != float64(45) {
+		t.Errorf("Expected duration change 30 -> 45, got %+v", entry.Changes)
+	}
+	if _, ok := entry.Changes["title"]; ok {
+		t.Error("Unchanged fields should not appear in the diff")
+	}
+
+	// The whole trail pages newest first, following the next link.
+	req, _ = http.NewRequest("GET", "/api/v1/audit?limit=1", nil)
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	var first struct {
+		Data  []models.AuditLog `json:"data"`
+		Total int64             `json:"total"`
+		Links struct {
+			Next string `json:"next"`
+		} `json:"links"`
+	}
+	json.Unmarshal(resp.Body.Bytes(), &first)
+	if len(first.Data) != 1 || first.Total < 2 || first.Links.Next == "" {
+		t.Fatalf("Expected a first page of one entry with a next link, got %s", resp.Body.String())
+	}
+	req, _ = http.NewRequest("GET", first.Links.Next, nil)
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	var second struct {
+		Data []models.AuditLog `json:"data"`
+	}
+	json.Unmarshal(resp.Body.Bytes(), &second)
+	if len(second.Data) != 1 || second.Data[0].ID >= first.Data[0].ID {
+		t.Errorf("Expected an older entry on the next page, got %s", resp.Body.String())
+	}
+
+	// The trail cannot be rewritten.
+	if err := db.Delete(&models.AuditLog{}, entry.ID).Error; err == nil {
+		t.Error("Expected audit log deletion to be rejected")
+	}
+}
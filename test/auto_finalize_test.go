@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/krushnna/meeting-scheduler/models"
+	"github.com/krushnna/meeting-scheduler/repository"
+	"github.com/krushnna/meeting-scheduler/services"
+	"github.com/krushnna/meeting-scheduler/utils"
+	"gorm.io/gorm"
+)
+
+func newAutoFinalizer(db *gorm.DB) *services.AutoFinalizer {
+	eventRepo := repository.NewEventRepository(db)
+	transactor := repository.NewTransactor(db)
+	recommendations := services.NewRecommendationService(transactor)
+	return services.NewAutoFinalizer(eventRepo, recommendations, transactor, time.Minute, utils.GetLogger())
+}
+
+func createAutoFinalizeEvent(router *gin.Engine, title, policy string, deadline *time.Time) models.Event {
+	payload := map[string]interface{}{
+		"title":            title,
+		"organizer_id":     1,
+		"duration_minutes": 60,
+		"auto_finalize":    policy,
+	}
+	if deadline != nil {
+		payload["response_deadline"] = deadline
+	}
+	var event models.Event
+	json.Unmarshal(postJSON(router, "/api/v1/events", payload).Body.Bytes(), &event)
+	return event
+}
+
+// TestAutoFinalize runs the worker over events with each policy, including one
+// where no option is viable.
+func TestAutoFinalize(t *testing.T) {
+	router, db := setupTestRouter()
+	finalizer := newAutoFinalizer(db)
+
+	alice := createTestUser(router, "alice@test.com")
+	bob := createTestUser(router, "bob@test.com")
+	deadline := time.Date(2026, 11, 1, 12, 0, 0, 0, time.UTC)
+	before, after := deadline.Add(-time.Hour), deadline.Add(time.Hour)
+	start := time.Date(2026, 11, 16, 9, 0, 0, 0, time.UTC)
+
+	if resp := postJSON(router, "/api/v1/events", map[string]interface{}{
+		"title": "No deadline", "organizer_id": 1, "duration_minutes": 60, "auto_finalize": "deadline",
+	}); resp.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected 422 for a deadline policy without a deadline, got %d", resp.Code)
+	}
+
+	atDeadline := createAutoFinalizeEvent(router, "Planning", models.AutoFinalizeAtDeadline, &deadline)
+	createTestTimeSlot(router, atDeadline.ID, start, start.Add(2*time.Hour))
+	createAvailability(router, alice.ID, atDeadline.ID, start, start.Add(time.Hour))
+	createAvailability(router, bob.ID, atDeadline.ID, start.Add(time.Hour), start.Add(2*time.Hour))
+	createAvailability(router, alice.ID, atDeadline.ID, start.Add(time.Hour), start.Add(2*time.Hour))
+
+	whenResponded := createAutoFinalizeEvent(router, "Sync", models.AutoFinalizeWhenResponded, nil)
+	createTestTimeSlot(router, whenResponded.ID, start, start.Add(time.Hour))
+	postJSON(router, fmt.Sprintf("/api/v1/events/%d/participants", whenResponded.ID), map[string]interface{}{"user_id": bob.ID, "required": true})
+	createAvailability(router, alice.ID, whenResponded.ID, start, start.Add(time.Hour))
+
+	unviable := createAutoFinalizeEvent(router, "Offsite", models.AutoFinalizeAtDeadline, &deadline)
+	createTestTimeSlot(router, unviable.ID, start, start.Add(time.Hour))
+	postJSON(router, fmt.Sprintf("/api/v1/events/%d/participants", unviable.ID), map[string]uint{"user_id": alice.ID})
+
+	// Before the deadline only fully responded events are due, and Bob has not answered.
+	if decided, err := finalizer.ApplyDue(context.Background(), before); err != nil || decided != 0 {
+		t.Fatalf("Expected nothing decided before the deadline, got %d (%v)", decided, err)
+	}
+	createAvailability(router, bob.ID, whenResponded.ID, start, start.Add(time.Hour))
+	if decided, err := finalizer.ApplyDue(context.Background(), before); err != nil || decided != 1 {
+		t.Fatalf("Expected the fully responded event decided, got %d (%v)", decided, err)
+	}
+	if event := getEvent(router, whenResponded.ID); event.Status != models.EventStatusScheduled || !strings.Contains(event.AutoFinalizeRationale, "every required participant responded") {
+		t.Errorf("Expected Sync scheduled once Bob responded, got %+v", event)
+	}
+
+	if decided, err := finalizer.ApplyDue(context.Background(), after); err != nil || decided != 2 {
+		t.Fatalf("Expected two events decided after the deadline, got %d (%v)", decided, err)
+	}
+	planning := getEvent(router, atDeadline.ID)
+	if planning.Status != models.EventStatusScheduled || !planning.ScheduledStart.Equal(start.Add(time.Hour)) {
+		t.Errorf("Expected Planning scheduled at 10:00 when both can attend, got %+v", planning)
+	}
+	if planning.AutoFinalizeOutcome != models.AutoFinalizeOutcomeFinalized || !strings.Contains(planning.AutoFinalizeRationale, "2 of 2 invitees") {
+		t.Errorf("Expected a finalized outcome with its rationale, got %q: %q", planning.AutoFinalizeOutcome, planning.AutoFinalizeRationale)
+	}
+
+	offsite := getEvent(router, unviable.ID)
+	if offsite.Status != models.EventStatusPolling || offsite.AutoFinalizeOutcome != models.AutoFinalizeOutcomeNoOption || offsite.AutoFinalizeRationale == "" {
+		t.Errorf("Expected Offsite left in polling with no viable option, got %+v", offsite)
+	}
+	if decided, _ := finalizer.ApplyDue(context.Background(), after); decided != 0 {
+		t.Errorf("Expected decided events to be left alone, got %d decided", decided)
+	}
+
+	// Rescheduling forgets the worker's decision, so it finalizes Planning again.
+	resp := postJSON(router, fmt.Sprintf("/api/v1/events/%d/reschedule", atDeadline.ID), map[string]interface{}{"preserve_availability": true})
+	if resp.Code != http.StatusOK {
+		t.Fatalf("Expected 200 rescheduling, got %d: %s", resp.Code, resp.Body.String())
+	}
+	if planning := getEvent(router, atDeadline.ID); planning.AutoFinalizeOutcome != "" || planning.AutoFinalizeRationale != "" || planning.AutoFinalizeDecidedAt != nil {
+		t.Errorf("Expected the outcome cleared by rescheduling, got %+v", planning)
+	}
+	if decided, err := finalizer.ApplyDue(context.Background(), after); err != nil || decided != 1 {
+		t.Fatalf("Expected the rescheduled event decided again, got %d (%v)", decided, err)
+	}
+	if planning := getEvent(router, atDeadline.ID); planning.Status != models.EventStatusScheduled || planning.AutoFinalizeOutcome != models.AutoFinalizeOutcomeFinalized {
+		t.Errorf("Expected Planning finalized again, got %+v", planning)
+	}
+
+	// A new deadline lets the worker consider the event again.
+	later := deadline.AddDate(0, 0, 7)
+	patchJSON(router, fmt.Sprintf("/api/v1/events/%d", unviable.ID), fmt.Sprintf(`{"response_deadline": %q}`, later.Format(time.RFC3339)))
+	if offsite := getEvent(router, unviable.ID); offsite.AutoFinalizeOutcome != "" {
+		t.Errorf("Expected the outcome cleared by a new deadline, got %q", offsite.AutoFinalizeOutcome)
+	}
+}
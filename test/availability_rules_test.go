@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/krushnna/meeting-scheduler/models"
+)
+
+func postJSON(router *gin.Engine, path string, payload interface{}) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(payload)
+	req, _ := http.NewRequest("POST", path, bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	return resp
+}
+
+// TestAvailabilityRules materializes a weekly rule across the end of US daylight
+// saving time when the user is invited, and keeps it current as things change.
+func TestAvailabilityRules(t *testing.T) {
+	router, _ := setupTestRouter()
+
+	resp := postJSON(router, "/api/v1/users", map[string]string{"name": "Rita", "email": "rita@test.com", "timezone": "America/New_York"})
+	var user models.User
+	json.Unmarshal(resp.Body.Bytes(), &user)
+	event := createTestEvent(router, "Weekly Sync", 60)
+
+	// Two Mondays either side of the switch from EDT to EST on 1 November 2026.
+	before := time.Date(2026, 10, 26, 0, 0, 0, 0, time.UTC)
+	after := time.Date(2026, 11, 2, 0, 0, 0, 0, time.UTC)
+	createTestTimeSlot(router, event.ID, before, before.Add(24*time.Hour))
+	createTestTimeSlot(router, event.ID, after, after.Add(24*time.Hour))
+
+	rulesPath := fmt.Sprintf("/api/v1/users/%d/availability-rules", user.ID)
+	if resp := postJSON(router, rulesPath, map[string]interface{}{"weekday": 1, "start_time": "09:00", "end_time": "12:00"}); resp.Code != http.StatusCreated {
+		t.Fatalf("Expected 201 creating a rule, got %d: %s", resp.Code, resp.Body.String())
+	}
+	if resp := postJSON(router, rulesPath, map[string]interface{}{"weekday": 1, "start_time": "12:00", "end_time": "09:00"}); resp.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected 422 for a rule ending before it starts, got %d", resp.Code)
+	}
+	if resp := postJSON(router, rulesPath, map[string]interface{}{"weekday": 7, "start_time": "09:00", "end_time": "12:00"}); resp.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected 422 for an invalid weekday, got %d", resp.Code)
+	}
+
+	// Inviting the user fills in their availability from the rule with no further call.
+	resp = postJSON(router, fmt.Sprintf("/api/v1/events/%d/participants", event.ID), map[string]uint{"user_id": user.ID})
+	var participant models.EventParticipant
+	json.Unmarshal(resp.Body.Bytes(), &participant)
+	if resp.Code != http.StatusCreated || participant.Status != models.ParticipantStatusResponded {
+		t.Fatalf("Expected 201 inviting the user as responded, got %d: %s", resp.Code, resp.Body.String())
+	}
+	availabilityPath := fmt.Sprintf("/api/v1/users/%d/events/%d/availability?sort=start_time", user.ID, event.ID)
+	availability := getAvailabilityPage(router, availabilityPath)
+	expected := []struct{ start, end time.Time }{
+		{time.Date(2026, 10, 26, 13, 0, 0, 0, time.UTC), time.Date(2026, 10, 26, 16, 0, 0, 0, time.UTC)},
+		{time.Date(2026, 11, 2, 14, 0, 0, 0, time.UTC), time.Date(2026, 11, 2, 17, 0, 0, 0, time.UTC)},
+	}
+	if len(availability) != len(expected) {
+		t.Fatalf("Expected %d materialized windows, got %+v", len(expected), availability)
+	}
+	for i, window := range expected {
+		if !availability[i].StartTime.Equal(window.start) || !availability[i].EndTime.Equal(window.end) || availability[i].Source != models.AvailabilitySourceRule {
+			t.Errorf("Window %d: expected %v - %v from a rule, got %+v", i, window.start, window.end, availability[i])
+		}
+	}
+
+	// An exception carves the hour out of the second Monday and is applied right away.
+	exceptionsPath := fmt.Sprintf("/api/v1/users/%d/availability-exceptions", user.ID)
+	if resp := postJSON(router, exceptionsPath, map[string]string{"date": "2026-11-02", "start_time": "10:00", "end_time": "11:00"}); resp.Code != http.StatusCreated {
+		t.Fatalf("Expected 201 creating an exception, got %d: %s", resp.Code, resp.Body.String())
+	}
+	availability = getAvailabilityPage(router, availabilityPath)
+	if len(availability) != 3 || !availability[1].EndTime.Equal(time.Date(2026, 11, 2, 15, 0, 0, 0, time.UTC)) || !availability[2].StartTime.Equal(time.Date(2026, 11, 2, 16, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the exception to split the second Monday, got %+v", availability)
+	}
+
+	// A new slot and a move to the west coast are both picked up.
+	third := after.AddDate(0, 0, 7)
+	createTestTimeSlot(router, event.ID, third, third.Add(24*time.Hour))
+	if resp := patchJSON(router, fmt.Sprintf("/api/v1/users/%d", user.ID), `{"timezone": "America/Los_Angeles"}`); resp.Code != http.StatusOK {
+		t.Fatalf("Expected 200 changing timezone, got %d: %s", resp.Code, resp.Body.String())
+	}
+	availability = getAvailabilityPage(router, availabilityPath)
+	if len(availability) != 4 || !availability[0].StartTime.Equal(time.Date(2026, 10, 26, 16, 0, 0, 0, time.UTC)) || !availability[3].StartTime.Equal(time.Date(2026, 11, 9, 17, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected Pacific windows over all three Mondays, got %+v", availability)
+	}
+
+	// Availability entered by hand is never overwritten by rules.
+	other := createTestEvent(router, "Manual Event", 30)
+	createTestTimeSlot(router, other.ID, before, before.Add(24*time.Hour))
+	createAvailability(router, user.ID, other.ID, before.Add(18*time.Hour), before.Add(19*time.Hour))
+	postJSON(router, fmt.Sprintf("/api/v1/events/%d/participants", other.ID), map[string]uint{"user_id": user.ID})
+	availability = getAvailabilityPage(router, fmt.Sprintf("/api/v1/users/%d/events/%d/availability", user.ID, other.ID))
+	if len(availability) != 1 || availability[0].Source != models.AvailabilitySourceManual {
+		t.Errorf("Expected only the manual window, got %+v", availability)
+	}
+}
+
+// getAvailabilityPage fetches one page of a user's availability for an event
+func getAvailabilityPage(router *gin.Engine, path string) []models.UserAvailability {
+	req, _ := http.NewRequest("GET", path, nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	var page struct {
+		Data []models.UserAvailability `json:"data"`
+	}
+	json.Unmarshal(resp.Body.Bytes(), &page)
+	return page.Data
+}
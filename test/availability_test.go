@@ -0,0 +1,179 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/krushnna/meeting-scheduler/models"
+)
+
+func replaceAvailability(router *gin.Engine, userID, eventID uint, windows []map[string]string) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(windows)
+	req, _ := http.NewRequest("PUT", fmt.Sprintf("/api/v1/users/%d/events/%d/availability", userID, eventID), bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	return resp
+}
+
+// TestAvailabilityManagement covers bulk replacement, per-record update and delete,
+// and the organizer's grouped view of an event.
+func TestAvailabilityManagement(t *testing.T) {
+	router, _ := setupTestRouter()
+
+	event := createTestEvent(router, "Availability Event", 60)
+	alice := createTestUser(router, "alice@test.com")
+	bob := createTestUser(router, "bob@test.com")
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Hour).UTC()
+
+	createAvailability(router, alice.ID, event.ID, start, start.Add(time.Hour))
+	resp := replaceAvailability(router, alice.ID, event.ID, []map[string]string{
+		{"start_time": start.Add(2 * time.Hour).Format(time.RFC3339), "end_time": start.Add(3 * time.Hour).Format(time.RFC3339)},
+		{"start_time": start.Add(4 * time.Hour).Format(time.RFC3339), "end_time": start.Add(5 * time.Hour).Format(time.RFC3339)},
+	})
+	if resp.Code != http.StatusOK {
+		t.Fatalf("Expected 200 on replace, got %d: %s", resp.Code, resp.Body.String())
+	}
+	var replaced []models.UserAvailability
+	json.Unmarshal(resp.Body.Bytes(), &replaced)
+	if len(replaced) != 2 {
+		t.Fatalf("Expected 2 availability records after replace, got %d", len(replaced))
+	}
+	createAvailability(router, bob.ID, event.ID, start, start.Add(time.Hour))
+
+	resp = replaceAvailability(router, alice.ID, event.ID, []map[string]string{
+		{"start_time": start.Format(time.RFC3339), "end_time": start.Format(time.RFC3339)},
+	})
+	if resp.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected 422 for an empty window, got %d", resp.Code)
+	}
+
+	req, _ := http.NewRequest("GET", fmt.Sprintf("/api/v1/events/%d/availability", event.ID), nil)
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	var groups []models.UserAvailabilityGroup
+	json.Unmarshal(resp.Body.Bytes(), &groups)
+	if len(groups) != 2 || groups[0].User.ID != alice.ID || len(groups[0].Availability) != 2 || len(groups[1].Availability) != 1 {
+		t.Fatalf("Unexpected grouped availability: %s", resp.Body.String())
+	}
+
+	// Records can only be changed through their owner's path.
+	path := fmt.Sprintf("/api/v1/users/%d/events/%d/availability/%d", bob.ID, event.ID, replaced[0].ID)
+	req, _ = http.NewRequest("DELETE", path, nil)
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	if resp.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 deleting another user's availability, got %d", resp.Code)
+	}
+
+	path = fmt.Sprintf("/api/v1/users/%d/events/%d/availability/%d", alice.ID, event.ID, replaced[0].ID)
+	update, _ := json.Marshal(map[string]string{
+		"start_time": start.Add(6 * time.Hour).Format(time.RFC3339),
+		"end_time":   start.Add(7 * time.Hour).Format(time.RFC3339),
+	})
+	req, _ = http.NewRequest("PUT", path, bytes.NewBuffer(update))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"1"`)
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	if resp.Code != http.StatusOK || resp.Header().Get("ETag") != `"2"` {
+		t.Errorf("Expected 200 with ETag \"2\" on update, got %d %q", resp.Code, resp.Header().Get("ETag"))
+	}
+
+	req, _ = http.NewRequest("DELETE", path, nil)
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	if resp.Code != http.StatusOK {
+		t.Errorf("Expected 200 on delete, got %d", resp.Code)
+	}
+
+	if resp = replaceAvailability(router, alice.ID, event.ID, []map[string]string{}); resp.Code != http.StatusOK {
+		t.Errorf("Expected 200 clearing availability, got %d", resp.Code)
+	}
+	req, _ = http.NewRequest("GET", fmt.Sprintf("/api/v1/events/%d/availability", event.ID), nil)
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	json.Unmarshal(resp.Body.Bytes(), &groups)
+	if len(groups) != 1 || groups[0].User.ID != bob.ID {
+		t.Errorf("Expected only bob's availability to remain, got %s", resp.Body.String())
+	}
+
+	req, _ = http.NewRequest("GET", "/api/v1/events/9999/availability", nil)
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	if resp.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for a missing event, got %d", resp.Code)
+	}
+}
+
+// TestAvailabilityBatch verifies a batch is merged with stored availability into disjoint ranges.
+func TestAvailabilityBatch(t *testing.T) {
+	router, _ := setupTestRouter()
+
+	event := createTestEvent(router, "Batch Event", 30)
+	user := createTestUser(router, "batch@test.com")
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Hour).UTC()
+	at := func(minutes int) string { return start.Add(time.Duration(minutes) * time.Minute).Format(time.RFC3339) }
+	path := fmt.Sprintf("/api/v1/users/%d/events/%d/availability/batch", user.ID, event.ID)
+
+	createAvailability(router, user.ID, event.ID, start, start.Add(time.Hour))
+	submit := func(windows []map[string]string) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(windows)
+		req, _ := http.NewRequest("POST", path, bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		return resp
+	}
+
+	// Overlaps the stored hour, touches it, and adds a separate range.
+	resp := submit([]map[string]string{
+		{"start_time": at(30), "end_time": at(90)},
+		{"start_time": at(90), "end_time": at(120)},
+		{"start_time": at(240), "end_time": at(300)},
+		{"start_time": at(250), "end_time": at(260)},
+	})
+	if resp.Code != http.StatusOK {
+		t.Fatalf("Expected 200 on batch submit, got %d: %s", resp.Code, resp.Body.String())
+	}
+	var normalized []models.UserAvailability
+	json.Unmarshal(resp.Body.Bytes(), &normalized)
+	if len(normalized) != 2 {
+		t.Fatalf("Expected 2 merged ranges, got %s", resp.Body.String())
+	}
+	if !normalized[0].StartTime.Equal(start) || !normalized[0].EndTime.Equal(start.Add(2*time.Hour)) {
+		t.Errorf("Unexpected first range %v - %v", normalized[0].StartTime, normalized[0].EndTime)
+	}
+	if !normalized[1].StartTime.Equal(start.Add(4*time.Hour)) || !normalized[1].EndTime.Equal(start.Add(5*time.Hour)) {
+		t.Errorf("Unexpected second range %v - %v", normalized[1].StartTime, normalized[1].EndTime)
+	}
+
+	// One invalid window rejects the whole batch.
+	resp = submit([]map[string]string{
+		{"start_time": at(600), "end_time": at(660)},
+		{"start_time": at(700), "end_time": at(650)},
+	})
+	if resp.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected 422 for an invalid window, got %d", resp.Code)
+	}
+	req, _ := http.NewRequest("GET", fmt.Sprintf("/api/v1/users/%d/events/%d/availability", user.ID, event.ID), nil)
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	var page struct {
+		Total int64 `json:"total"`
+	}
+	json.Unmarshal(resp.Body.Bytes(), &page)
+	if page.Total != 2 {
+		t.Errorf("Expected the rejected batch to leave 2 records, got %d", page.Total)
+	}
+
+	if resp = submit([]map[string]string{}); resp.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected 422 for an empty batch, got %d", resp.Code)
+	}
+}
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/krushnna/meeting-scheduler/models"
+)
+
+// TestBatchSchedule plans an interview loop where the candidate takes part in
+// every event, one interview must follow another after a break and two must be
+// on the same day.
+func TestBatchSchedule(t *testing.T) {
+	router, _ := setupTestRouter()
+
+	candidate := createTestUser(router, "candidate@test.com")
+	first := createTestUser(router, "first.interviewer@test.com")
+	second := createTestUser(router, "second.interviewer@test.com")
+	third := createTestUser(router, "third.interviewer@test.com")
+	day := time.Date(2026, 11, 16, 9, 0, 0, 0, time.UTC)
+
+	// Each interviewer is free for one stretch; the candidate all morning.
+	screening := createTestEvent(router, "Screening", 60)
+	createTestTimeSlot(router, screening.ID, day, day.Add(4*time.Hour))
+	createAvailability(router, candidate.ID, screening.ID, day, day.Add(4*time.Hour))
+	createAvailability(router, first.ID, screening.ID, day.Add(time.Hour), day.Add(2*time.Hour))
+
+	technical := createTestEvent(router, "Technical", 60)
+	createTestTimeSlot(router, technical.ID, day, day.Add(4*time.Hour))
+	createAvailability(router, candidate.ID, technical.ID, day, day.Add(4*time.Hour))
+	createAvailability(router, second.ID, technical.ID, day, day.Add(4*time.Hour))
+
+	culture := createTestEvent(router, "Culture", 60)
+	createTestTimeSlot(router, culture.ID, day, day.Add(4*time.Hour))
+	createAvailability(router, candidate.ID, culture.ID, day, day.Add(4*time.Hour))
+	createAvailability(router, third.ID, culture.ID, day, day.Add(time.Hour))
+
+	request := map[string]interface{}{
+		"event_ids": []uint{screening.ID, technical.ID, culture.ID},
+		"constraints": []map[string]interface{}{
+			{"type": "order", "first": screening.ID, "second": technical.ID, "min_gap_minutes": 30},
+			{"type": "same_day", "first": screening.ID, "second": culture.ID},
+		},
+	}
+	resp := postJSON(router, "/api/v1/events/batch-schedule", request)
+	if resp.Code != http.StatusOK {
+		t.Fatalf("Expected 200 planning, got %d: %s", resp.Code, resp.Body.String())
+	}
+	var schedule models.BatchSchedule
+	json.Unmarshal(resp.Body.Bytes(), &schedule)
+	if schedule.TotalAttendance != 6 || schedule.Applied || len(schedule.Assignments) != 3 {
+		t.Fatalf("Expected everyone at all three interviews, unapplied, got %s", resp.Body.String())
+	}
+	starts := map[uint]time.Time{}
+	for _, assignment := range schedule.Assignments {
+		starts[assignment.EventID] = assignment.StartTime
+	}
+	if !starts[culture.ID].Equal(day) || !starts[screening.ID].Equal(day.Add(time.Hour)) {
+		t.Errorf("Expected culture at 9:00 and screening at 10:00, got %v", starts)
+	}
+	if starts[technical.ID].Before(day.Add(150 * time.Minute)) {
+		t.Errorf("Expected technical 30 minutes after screening ends, got %v", starts[technical.ID])
+	}
+
+	// Contradictory orders can't be satisfied.
+	contradictory := map[string]interface{}{
+		"event_ids": []uint{screening.ID, technical.ID},
+		"constraints": []map[string]interface{}{
+			{"type": "order", "first": screening.ID, "second": technical.ID},
+			{"type": "order", "first": technical.ID, "second": screening.ID},
+		},
+	}
+	if resp := postJSON(router, "/api/v1/events/batch-schedule", contradictory); resp.Code != http.StatusConflict {
+		t.Errorf("Expected 409 for contradictory constraints, got %d: %s", resp.Code, resp.Body.String())
+	}
+	outside := map[string]interface{}{
+		"event_ids":   []uint{screening.ID},
+		"constraints": []map[string]interface{}{{"type": "order", "first": screening.ID, "second": culture.ID}},
+	}
+	if resp := postJSON(router, "/api/v1/events/batch-schedule", outside); resp.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected 422 for a constraint on an event outside the batch, got %d", resp.Code)
+	}
+
+	// Applying finalizes every event at the planned time.
+	request["apply"] = true
+	resp = postJSON(router, "/api/v1/events/batch-schedule", request)
+	if resp.Code != http.StatusOK {
+		t.Fatalf("Expected 200 applying, got %d: %s", resp.Code, resp.Body.String())
+	}
+	schedule = models.BatchSchedule{}
+	json.Unmarshal(resp.Body.Bytes(), &schedule)
+	if !schedule.Applied || len(schedule.Events) != 3 {
+		t.Fatalf("Expected three finalized events, got %s", resp.Body.String())
+	}
+	for _, event := range schedule.Events {
+		if event.Status != models.EventStatusScheduled || event.ScheduledStart == nil || !event.ScheduledStart.Equal(starts[event.ID]) {
+			t.Errorf("Expected event %d scheduled at %v, got %+v", event.ID, starts[event.ID], event)
+		}
+	}
+	if resp := postJSON(router, "/api/v1/events/batch-schedule", request); resp.Code != http.StatusConflict {
+		t.Errorf("Expected 409 planning scheduled events, got %d", resp.Code)
+	}
+}
+
+// TestBatchScheduleAssignsResources plans three meetings that each need a room
+// when only two rooms exist, so no more than two can overlap.
+func TestBatchScheduleAssignsResources(t *testing.T) {
+	router, _ := setupTestRouter()
+
+	createTestResource(router, "Aspen", "room", 4)
+	createTestResource(router, "Birch", "room", 4)
+	day := time.Date(2026, 11, 16, 9, 0, 0, 0, time.UTC)
+	var eventIDs []uint
+	for _, title := range []string{"Design", "Budget", "Hiring"} {
+		resp := postJSON(router, "/api/v1/events", map[string]interface{}{
+			"title": title, "organizer_id": 1, "duration_minutes": 60, "min_capacity": 2,
+		})
+		var event models.Event
+		json.Unmarshal(resp.Body.Bytes(), &event)
+		user := createTestUser(router, title+".rooms@test.com")
+		createTestTimeSlot(router, event.ID, day, day.Add(2*time.Hour))
+		createAvailability(router, user.ID, event.ID, day, day.Add(2*time.Hour))
+		eventIDs = append(eventIDs, event.ID)
+	}
+
+	request := map[string]interface{}{"event_ids": eventIDs, "apply": true}
+	resp := postJSON(router, "/api/v1/events/batch-schedule", request)
+	if resp.Code != http.StatusOK {
+		t.Fatalf("Expected 200 applying, got %d: %s", resp.Code, resp.Body.String())
+	}
+	var schedule models.BatchSchedule
+	json.Unmarshal(resp.Body.Bytes(), &schedule)
+	if !schedule.Applied || schedule.TotalAttendance != 3 {
+		t.Fatalf("Expected all three meetings finalized, got %s", resp.Body.String())
+	}
+	for i, x := range schedule.Assignments {
+		if x.ResourceID == nil || *schedule.Events[i].ReservedResourceID != *x.ResourceID {
+			t.Errorf("Expected event %d to reserve its assigned room, got %+v", x.EventID, schedule.Events[i])
+			continue
+		}
+		for _, y := range schedule.Assignments[i+1:] {
+			overlap := x.StartTime.Before(y.EndTime) && y.StartTime.Before(x.EndTime)
+			if overlap && y.ResourceID != nil && *x.ResourceID == *y.ResourceID {
+				t.Errorf("Expected overlapping events %d and %d in different rooms, got %s", x.EventID, y.EventID, resp.Body.String())
+			}
+		}
+	}
+}
+
+// TestBatchScheduleRespectsLimits plans two interviews for a candidate who keeps
+// a buffer between meetings, then two for one who caps their meetings per day.
+func TestBatchScheduleRespectsLimits(t *testing.T) {
+	router, _ := setupTestRouter()
+
+	candidate := createTestUser(router, "buffered.candidate@test.com")
+	first := createTestUser(router, "early.interviewer@test.com")
+	second := createTestUser(router, "late.interviewer@test.com")
+	if resp := patchJSON(router, fmt.Sprintf("/api/v1/users/%d", candidate.ID), `{"buffer_after_minutes": 30}`); resp.Code != http.StatusOK {
+		t.Fatalf("Expected 200 setting the buffer, got %d: %s", resp.Code, resp.Body.String())
+	}
+	day := time.Date(2026, 11, 16, 9, 0, 0, 0, time.UTC)
+
+	// Back to back at 9:00 and 10:00 would suit both interviewers.
+	screening := createTestEvent(router, "Buffered screening", 60)
+	createTestTimeSlot(router, screening.ID, day, day.Add(4*time.Hour))
+	createAvailability(router, candidate.ID, screening.ID, day, day.Add(4*time.Hour))
+	createAvailability(router, first.ID, screening.ID, day, day.Add(time.Hour))
+	technical := createTestEvent(router, "Buffered technical", 60)
+	createTestTimeSlot(router, technical.ID, day, day.Add(4*time.Hour))
+	createAvailability(router, candidate.ID, technical.ID, day, day.Add(4*time.Hour))
+	createAvailability(router, second.ID, technical.ID, day.Add(time.Hour), day.Add(2*time.Hour))
+
+	resp := postJSON(router, "/api/v1/events/batch-schedule", map[string]interface{}{"event_ids": []uint{screening.ID, technical.ID}})
+	if resp.Code != http.StatusOK {
+		t.Fatalf("Expected 200 planning, got %d: %s", resp.Code, resp.Body.String())
+	}
+	var schedule models.BatchSchedule
+	json.Unmarshal(resp.Body.Bytes(), &schedule)
+	x, y := schedule.Assignments[0], schedule.Assignments[1]
+	if x.EndTime.Add(30*time.Minute).After(y.StartTime) && y.EndTime.Add(30*time.Minute).After(x.StartTime) {
+		t.Errorf("Expected the interviews 30 minutes apart, got %s", resp.Body.String())
+	}
+
+	// Two hours fit the slots on either day, but only one fits the cap.
+	capped := createTestUser(router, "capped.candidate@test.com")
+	if resp := patchJSON(router, fmt.Sprintf("/api/v1/users/%d", capped.ID), `{"max_daily_meeting_minutes": 60}`); resp.Code != http.StatusOK {
+		t.Fatalf("Expected 200 setting the cap, got %d: %s", resp.Code, resp.Body.String())
+	}
+	var eventIDs []uint
+	for _, title := range []string{"Capped screening", "Capped technical"} {
+		event := createTestEvent(router, title, 60)
+		createTestTimeSlot(router, event.ID, day, day.Add(2*time.Hour))
+		createTestTimeSlot(router, event.ID, day.AddDate(0, 0, 1), day.AddDate(0, 0, 1).Add(2*time.Hour))
+		createAvailability(router, capped.ID, event.ID, day, day.AddDate(0, 0, 1).Add(2*time.Hour))
+		eventIDs = append(eventIDs, event.ID)
+	}
+	resp = postJSON(router, "/api/v1/events/batch-schedule", map[string]interface{}{"event_ids": eventIDs})
+	if resp.Code != http.StatusOK {
+		t.Fatalf("Expected 200 planning, got %d: %s", resp.Code, resp.Body.String())
+	}
+	schedule = models.BatchSchedule{}
+	json.Unmarshal(resp.Body.Bytes(), &schedule)
+	if schedule.TotalAttendance != 2 || schedule.Assignments[0].StartTime.YearDay() == schedule.Assignments[1].StartTime.YearDay() {
+		t.Errorf("Expected both interviews attended on different days, got %s", resp.Body.String())
+	}
+}
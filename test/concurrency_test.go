@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestOptimisticConcurrency verifies ETags track versions and stale If-Match headers are rejected.
+func TestOptimisticConcurrency(t *testing.T) {
+	router, _ := setupTestRouter()
+
+	event := createTestEvent(router, "Versioned Event", 30)
+	path := fmt.Sprintf("/api/v1/events/%d", event.ID)
+
+	req, _ := http.NewRequest("GET", path, nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	etag := resp.Header().Get("ETag")
+	if etag != `"1"` {
+		t.Fatalf("Expected ETag \"1\" on a new event, got %q", etag)
+	}
+
+	update := func(ifMatch string) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(map[string]interface{}{
+			"title":            "Versioned Event",
+			"organizer_id":     1,
+			"duration_minutes": 60,
+		})
+		req, _ := http.NewRequest("PUT", path, bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("If-Match", ifMatch)
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		return resp
+	}
+
+	resp = update(etag)
+	if resp.Code != http.StatusOK {
+		t.Fatalf("Expected 200 with a current If-Match, got %d: %s", resp.Code, resp.Body.String())
+	}
+	if resp.Header().Get("ETag") != `"2"` {
+		t.Errorf("Expected ETag \"2\" after update, got %q", resp.Header().Get("ETag"))
+	}
+
+	// A second writer still holding the first version loses.
+	if resp = update(etag); resp.Code != http.StatusPreconditionFailed {
+		t.Errorf("Expected 412 with a stale If-Match, got %d", resp.Code)
+	}
+	resp = patchJSON(router, path, `{"description": "stale"}`)
+	if resp.Code != http.StatusOK {
+		t.Errorf("Expected 200 patching without If-Match, got %d", resp.Code)
+	}
+
+	req, _ = http.NewRequest("DELETE", path, nil)
+	req.Header.Set("If-Match", `"2"`)
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	if resp.Code != http.StatusPreconditionFailed {
+		t.Errorf("Expected 412 deleting with a stale If-Match, got %d", resp.Code)
+	}
+
+	req, _ = http.NewRequest("DELETE", path, nil)
+	req.Header.Set("If-Match", `"3"`)
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	if resp.Code != http.StatusOK {
+		t.Errorf("Expected 200 deleting with the current If-Match, got %d", resp.Code)
+	}
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/krushnna/meeting-scheduler/models"
+)
+
+func finalizeEvent(router *gin.Engine, eventID uint, start time.Time) *httptest.ResponseRecorder {
+	return postJSON(router, fmt.Sprintf("/api/v1/events/%d/finalize", eventID), map[string]time.Time{"start_time": start})
+}
+
+// TestScheduleConflicts finalizes overlapping events for one participant and
+// checks that recommendations and the conflicts listing treat them as busy.
+func TestScheduleConflicts(t *testing.T) {
+	router, _ := setupTestRouter()
+
+	user := createTestUser(router, "busy@test.com")
+	day := time.Date(2026, 11, 3, 0, 0, 0, 0, time.UTC)
+	slotStart, slotEnd := day.Add(10*time.Hour), day.Add(12*time.Hour)
+
+	planning := createTestEvent(router, "Planning", 60)
+	review := createTestEvent(router, "Review", 60)
+	for _, event := range []models.Event{planning, review} {
+		createTestTimeSlot(router, event.ID, slotStart, slotEnd)
+		createAvailability(router, user.ID, event.ID, slotStart, slotEnd)
+	}
+
+	resp := finalizeEvent(router, planning.ID, slotStart)
+	if resp.Code != http.StatusOK {
+		t.Fatalf("Expected 200 finalizing, got %d: %s", resp.Code, resp.Body.String())
+	}
+	var finalized models.Event
+	json.Unmarshal(resp.Body.Bytes(), &finalized)
+	if finalized.Status != models.EventStatusScheduled || finalized.ScheduledEnd == nil || !finalized.ScheduledEnd.Equal(day.Add(11*time.Hour)) {
+		t.Errorf("Expected a scheduled event ending at 11:00, got %s", resp.Body.String())
+	}
+	if resp := finalizeEvent(router, planning.ID, slotStart); resp.Code != http.StatusConflict {
+		t.Errorf("Expected 409 finalizing a scheduled event, got %d", resp.Code)
+	}
+	if resp := finalizeEvent(router, review.ID, slotEnd); resp.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected 422 for a start outside the time slots, got %d", resp.Code)
+	}
+
+	// Planning now blocks 10:00-11:00, so only 11:00 works for Review.
+	req, _ := http.NewRequest("GET", fmt.Sprintf("/api/v1/events/%d/recommendations", review.ID), nil)
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	var recommendations []models.TimeSlotRecommendation
+	json.Unmarshal(resp.Body.Bytes(), &recommendations)
+	if len(recommendations) != 1 {
+		t.Fatalf("Expected one recommendation, got %s", resp.Body.String())
+	}
+	best := recommendations[0]
+	if len(best.StartOptions) != 1 || !best.StartOptions[0].Equal(day.Add(11*time.Hour)) {
+		t.Errorf("Expected 11:00 as the only start option, got %v", best.StartOptions)
+	}
+	if len(best.Conflicts) != 1 || best.Conflicts[0].UserID != user.ID || best.Conflicts[0].Commitment.EventID != planning.ID {
+		t.Errorf("Expected Planning flagged as a conflict, got %+v", best.Conflicts)
+	}
+
+	if resp := finalizeEvent(router, review.ID, day.Add(10*time.Hour+30*time.Minute)); resp.Code != http.StatusConflict {
+		t.Errorf("Expected 409 finalizing over another commitment, got %d: %s", resp.Code, resp.Body.String())
+	}
+	if resp := finalizeEvent(router, review.ID, day.Add(11*time.Hour)); resp.Code != http.StatusOK {
+		t.Errorf("Expected 200 finalizing after the other commitment, got %d: %s", resp.Code, resp.Body.String())
+	}
+
+	// A series occurrence at 10:30 overlaps Planning but only touches Review.
+	postJSON(router, "/api/v1/series", map[string]interface{}{
+		"title":            "Coffee",
+		"organizer_id":     user.ID,
+		"duration_minutes": 30,
+		"start_time":       day.Add(10*time.Hour + 30*time.Minute),
+		"timezone":         "UTC",
+		"rrule":            "FREQ=DAILY;COUNT=1",
+		"participant_ids":  []uint{user.ID},
+	})
+	req, _ = http.NewRequest("GET", fmt.Sprintf("/api/v1/users/%d/conflicts?from=2026-11-03T00:00:00Z&to=2026-11-04T00:00:00Z", user.ID), nil)
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	if resp.Code != http.StatusOK {
+		t.Fatalf("Expected 200 listing conflicts, got %d: %s", resp.Code, resp.Body.String())
+	}
+	var conflicts []models.CommitmentConflict
+	json.Unmarshal(resp.Body.Bytes(), &conflicts)
+	if len(conflicts) != 1 || conflicts[0].First.EventID != planning.ID || conflicts[0].Second.SeriesID == 0 {
+		t.Errorf("Expected Planning to conflict with the series, got %s", resp.Body.String())
+	}
+
+	// An invitee who hasn't answered still blocks the times they are busy, so
+	// every start recommended can be finalized.
+	other := createTestUser(router, "free@test.com")
+	sync := createTestEvent(router, "Sync", 60)
+	createTestTimeSlot(router, sync.ID, slotStart, slotEnd.Add(time.Hour))
+	createAvailability(router, other.ID, sync.ID, slotStart, slotEnd.Add(time.Hour))
+	postJSON(router, fmt.Sprintf("/api/v1/events/%d/participants", sync.ID), map[string]uint{"user_id": user.ID})
+	recommendations = getRecommendations(router, sync.ID)
+	if len(recommendations) != 1 || len(recommendations[0].StartOptions) != 1 || !recommendations[0].StartOptions[0].Equal(slotEnd) {
+		t.Fatalf("Expected only 12:00 once the invitee is free, got %+v", recommendations)
+	}
+	if resp := finalizeEvent(router, sync.ID, slotStart); resp.Code != http.StatusConflict {
+		t.Errorf("Expected 409 finalizing while the invitee is busy, got %d", resp.Code)
+	}
+	if resp := finalizeEvent(router, sync.ID, slotEnd); resp.Code != http.StatusOK {
+		t.Errorf("Expected 200 finalizing the recommended start, got %d: %s", resp.Code, resp.Body.String())
+	}
+}
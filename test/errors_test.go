@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/krushnna/meeting-scheduler/middleware"
+)
+
+// TestProblemResponses verifies failures are reported as problem+json with accurate statuses.
+func TestProblemResponses(t *testing.T) {
+	router, _ := setupTestRouter()
+
+	send := func(method, path string, payload interface{}) (*httptest.ResponseRecorder, middleware.Problem) {
+		body, _ := json.Marshal(payload)
+		req, _ := http.NewRequest(method, path, bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		var problem middleware.Problem
+		json.Unmarshal(resp.Body.Bytes(), &problem)
+		return resp, problem
+	}
+
+	resp, problem := send("GET", "/api/v1/events/4242", nil)
+	if resp.Code != http.StatusNotFound || problem.Status != http.StatusNotFound {
+		t.Errorf("Expected 404 problem for a missing event, got %d", resp.Code)
+	}
+	if resp.Header().Get("Content-Type") != middleware.ProblemContentType {
+		t.Errorf("Expected problem content type, got %q", resp.Header().Get("Content-Type"))
+	}
+	if problem.Instance != "/api/v1/events/4242" || problem.Detail != "event 4242 not found" {
+		t.Errorf("Unexpected problem: %+v", problem)
+	}
+
+	resp, problem = send("POST", "/api/v1/users", map[string]interface{}{"name": "No Email", "timezone": "UTC"})
+	if resp.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("Expected 422 for a missing email, got %d", resp.Code)
+	}
+	if len(problem.Errors) != 1 || problem.Errors[0].Field != "email" {
+		t.Errorf("Expected a field error for email, got %+v", problem.Errors)
+	}
+
+	createTestUser(router, "taken@test.com")
+	resp, _ = send("POST", "/api/v1/users", map[string]interface{}{"name": "Copy", "email": "taken@test.com", "timezone": "UTC"})
+	if resp.Code != http.StatusConflict {
+		t.Errorf("Expected 409 for a duplicate email, got %d", resp.Code)
+	}
+
+	start := time.Now().Add(time.Hour)
+	resp, _ = send("POST", "/api/v1/events/4242/timeslots", map[string]interface{}{
+		"start_time": start.Format(time.RFC3339),
+		"end_time":   start.Add(time.Hour).Format(time.RFC3339),
+	})
+	if resp.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 adding a time slot to a missing event, got %d", resp.Code)
+	}
+
+	resp, problem = send("POST", "/api/v1/events", "not an object")
+	if resp.Code != http.StatusBadRequest || problem.Title != "Bad Request" {
+		t.Errorf("Expected 400 for malformed JSON, got %d", resp.Code)
+	}
+}
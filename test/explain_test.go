@@ -0,0 +1,103 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/krushnna/meeting-scheduler/models"
+)
+
+// TestExplainRecommendations asks why invitees can't make a start and checks
+// each kind of reason is given, including for a slot nobody can attend.
+func TestExplainRecommendations(t *testing.T) {
+	router, _ := setupTestRouter()
+
+	alice := createTestUser(router, "alice.explain@test.com")
+	bob := createTestUser(router, "bob.explain@test.com")
+	carol := createTestUser(router, "carol.explain@test.com")
+	dave := createTestUser(router, "dave.explain@test.com")
+	erin := createTestUser(router, "erin.explain@test.com")
+	monday := time.Date(2026, 11, 23, 9, 0, 0, 0, time.UTC)
+	tuesday, wednesday := monday.AddDate(0, 0, 1), monday.AddDate(0, 0, 2)
+
+	// Dave is already in a standup for the first half hour.
+	standup := createTestEvent(router, "Standup", 30)
+	createTestTimeSlot(router, standup.ID, monday, monday.Add(30*time.Minute))
+	createAvailability(router, dave.ID, standup.ID, monday, monday.Add(30*time.Minute))
+	if resp := finalizeEvent(router, standup.ID, monday); resp.Code != http.StatusOK {
+		t.Fatalf("Expected 200 finalizing, got %d: %s", resp.Code, resp.Body.String())
+	}
+
+	event := createTestEvent(router, "Planning", 60)
+	mondaySlot := createTestTimeSlot(router, event.ID, monday, monday.Add(time.Hour))
+	createTestTimeSlot(router, event.ID, tuesday, tuesday.Add(time.Hour))
+	wednesdaySlot := createTestTimeSlot(router, event.ID, wednesday, wednesday.Add(time.Hour))
+	createAvailability(router, alice.ID, event.ID, monday, monday.Add(time.Hour))
+	createAvailability(router, bob.ID, event.ID, monday.Add(30*time.Minute), monday.Add(time.Hour))
+	createAvailability(router, dave.ID, event.ID, monday, monday.Add(time.Hour))
+	// Carol only works Monday afternoons but can make Tuesday.
+	postJSON(router, fmt.Sprintf("/api/v1/users/%d/availability-rules", carol.ID), map[string]interface{}{"weekday": 1, "start_time": "13:00", "end_time": "17:00"})
+	createAvailability(router, carol.ID, event.ID, tuesday, tuesday.Add(time.Hour))
+	postJSON(router, fmt.Sprintf("/api/v1/events/%d/participants", event.ID), map[string]uint{"user_id": erin.ID})
+
+	// Dave's standup rules Monday out, leaving Carol's Tuesday.
+	if recommendations := getRecommendations(router, event.ID); len(recommendations) != 1 || recommendations[0].Explanations != nil {
+		t.Fatalf("Expected one unexplained recommendation, got %+v", recommendations)
+	}
+
+	req, _ := http.NewRequest("GET", fmt.Sprintf("/api/v1/events/%d/recommendations?explain=true", event.ID), nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	if resp.Code != http.StatusOK {
+		t.Fatalf("Expected 200 explaining, got %d: %s", resp.Code, resp.Body.String())
+	}
+	var recommendations []models.TimeSlotRecommendation
+	json.Unmarshal(resp.Body.Bytes(), &recommendations)
+	bySlot := map[uint]models.TimeSlotRecommendation{}
+	for _, recommendation := range recommendations {
+		bySlot[recommendation.TimeSlot.ID] = recommendation
+	}
+	if len(recommendations) != 3 {
+		t.Fatalf("Expected all three slots explained, got %s", resp.Body.String())
+	}
+
+	explanations := bySlot[mondaySlot.ID].Explanations
+	if len(explanations) != 1 || !explanations[0].StartTime.Equal(monday) || explanations[0].Attendees != 1 || !explanations[0].Conflicted {
+		t.Fatalf("Expected Monday 9:00 explained with Alice attending and a conflict, got %+v", explanations)
+	}
+	reasons := map[uint][]models.ExclusionReason{}
+	for _, excluded := range explanations[0].Excluded {
+		reasons[excluded.User.ID] = excluded.Reasons
+	}
+	if _, ok := reasons[alice.ID]; ok || len(reasons) != 4 {
+		t.Errorf("Expected everyone but Alice excluded, got %+v", reasons)
+	}
+	if r := reasons[bob.ID]; len(r) != 1 || r[0].Reason != models.ExclusionPartialOverlap || r[0].OverlapMinutes != 30 {
+		t.Errorf("Expected Bob to overlap by 30 minutes, got %+v", r)
+	}
+	if r := reasons[carol.ID]; len(r) != 2 || r[0].Reason != models.ExclusionNoAvailability || r[1].Reason != models.ExclusionOutsideWorkingHours {
+		t.Errorf("Expected Carol without availability and outside working hours, got %+v", r)
+	}
+	if r := reasons[dave.ID]; len(r) != 1 || r[0].Reason != models.ExclusionConflict || r[0].Commitment == nil || r[0].Commitment.EventID != standup.ID {
+		t.Errorf("Expected Dave in conflict with the standup, got %+v", r)
+	}
+	if r := reasons[erin.ID]; len(r) != 1 || r[0].Reason != models.ExclusionNotResponded {
+		t.Errorf("Expected Erin not to have responded, got %+v", r)
+	}
+
+	// Nobody can make Wednesday, but it is still explained.
+	if wednesdayRecommendation, ok := bySlot[wednesdaySlot.ID]; !ok || len(wednesdayRecommendation.StartOptions) != 0 || wednesdayRecommendation.Explanations[0].Attendees != 0 {
+		t.Errorf("Expected Wednesday explained without start options, got %+v", wednesdayRecommendation)
+	}
+
+	req, _ = http.NewRequest("GET", fmt.Sprintf("/api/v1/events/%d/recommendations?explain=maybe", event.ID), nil)
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	if resp.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an invalid explain value, got %d", resp.Code)
+	}
+}
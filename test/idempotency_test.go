@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/krushnna/meeting-scheduler/models"
+)
+
+// TestIdempotentCreate verifies retried creates are replayed instead of duplicated.
+func TestIdempotentCreate(t *testing.T) {
+	router, db := setupTestRouter()
+
+	post := func(key string, payload map[string]interface{}) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(payload)
+		req, _ := http.NewRequest("POST", "/api/v1/events", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Idempotency-Key", key)
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		return resp
+	}
+	payload := map[string]interface{}{"title": "Retried Event", "organizer_id": 1, "duration_minutes": 30}
+
+	first := post("create-event-1", payload)
+	if first.Code != http.StatusCreated {
+		t.Fatalf("Expected 201 on first create, got %d", first.Code)
+	}
+	retry := post("create-event-1", payload)
+	if retry.Code != http.StatusCreated || retry.Body.String() != first.Body.String() {
+		t.Errorf("Expected retry to replay the first response, got %d: %s", retry.Code, retry.Body.String())
+	}
+	if retry.Header().Get("Idempotent-Replayed") != "true" {
+		t.Error("Expected replayed response to be marked")
+	}
+
+	var count int64
+	db.Model(&models.Event{}).Count(&count)
+	if count != 1 {
+		t.Errorf("Expected a single event, got %d", count)
+	}
+
+	payload["title"] = "Different Event"
+	if resp := post("create-event-1", payload); resp.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected 422 reusing a key for a different payload, got %d", resp.Code)
+	}
+
+	// Rejected requests are stored too, so a retry gets the same answer.
+	invalid := map[string]interface{}{"title": "", "organizer_id": 1, "duration_minutes": 30}
+	if resp := post("create-event-2", invalid); resp.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected 422 for an invalid event, got %d", resp.Code)
+	}
+	if resp := post("create-event-2", invalid); resp.Code != http.StatusUnprocessableEntity || resp.Header().Get("Idempotent-Replayed") != "true" {
+		t.Errorf("Expected replayed 422, got %d", resp.Code)
+	}
+}
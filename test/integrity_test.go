@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/krushnna/meeting-scheduler/models"
+)
+
+// TestDeleteEventCascades verifies deleting an event removes its slots and availability.
+func TestDeleteEventCascades(t *testing.T) {
+	router, db := setupTestRouter()
+
+	event := createTestEvent(router, "Cascade Event", 60)
+	user := createTestUser(router, "cascade@test.com")
+	start := time.Now().Add(24 * time.Hour)
+	createTestTimeSlot(router, event.ID, start, start.Add(2*time.Hour))
+	createAvailability(router, user.ID, event.ID, start, start.Add(time.Hour))
+
+	req, _ := http.NewRequest("DELETE", fmt.Sprintf("/api/v1/events/%d", event.ID), nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	if resp.Code != http.StatusOK {
+		t.Fatalf("Expected 200 on delete event, got %d", resp.Code)
+	}
+
+	var slots, availability int64
+	db.Model(&models.TimeSlot{}).Where("event_id = ?", event.ID).Count(&slots)
+	db.Model(&models.UserAvailability{}).Where("event_id = ?", event.ID).Count(&availability)
+	if slots != 0 || availability != 0 {
+		t.Errorf("Expected no live children, got %d slots and %d availability", slots, availability)
+	}
+
+	// Children share the event's deletion timestamp.
+	var deleted models.Event
+	db.Unscoped().First(&deleted, event.ID)
+	var stamped int64
+	db.Unscoped().Model(&models.TimeSlot{}).Where("event_id = ? AND deleted_at = ?", event.ID, deleted.DeletedAt).Count(&stamped)
+	if stamped != 1 {
+		t.Errorf("Expected time slot to carry the event's deletion time")
+	}
+}
+
+// TestDatabaseConstraints verifies foreign keys and check constraints are enforced.
+func TestDatabaseConstraints(t *testing.T) {
+	router, db := setupTestRouter()
+	event := createTestEvent(router, "Constraint Event", 30)
+	start := time.Now()
+
+	orphan := models.UserAvailability{UserID: 9999, EventID: event.ID, StartTime: start, EndTime: start.Add(time.Hour)}
+	if err := db.Create(&orphan).Error; err == nil {
+		t.Error("Expected foreign key violation for unknown user")
+	}
+
+	inverted := models.TimeSlot{EventID: event.ID, StartTime: start, EndTime: start.Add(-time.Hour)}
+	if err := db.Create(&inverted).Error; err == nil {
+		t.Error("Expected check constraint violation for inverted time slot")
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 
+	"github.com/krushnna/meeting-scheduler/migrations"
 	"github.com/krushnna/meeting-scheduler/models"
 	"github.com/krushnna/meeting-scheduler/routers"
 	"github.com/krushnna/meeting-scheduler/utils"
@@ -26,24 +27,26 @@ func init() {
 	utils.InitLogger()
 }
 
-// setupTestRouter creates an in-memory DB, auto-migrates models,
+// setupTestRouter creates an in-memory DB, applies migrations,
 // and returns a test router.
 func setupTestRouter() (*gin.Engine, *gorm.DB) {
 	// Use in-memory SQLite for testing.
-	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	db, err := gorm.Open(sqlite.Open(":memory:?_foreign_keys=on"), &gorm.Config{TranslateError: true})
 	if err != nil {
 		panic("failed to connect test database")
 	}
 
-	// Auto-migrate all models.
-	err = db.AutoMigrate(
-		&models.Event{},
-		&models.TimeSlot{},
-		&models.User{},
-		&models.UserAvailability{},
-	)
+	// Every connection to :memory: is a separate database, so keep one.
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+
+	// Apply the versioned migrations.
+	migrator, err := migrations.New(db)
 	if err != nil {
-		panic("failed to migrate test database")
+		panic("failed to load migrations: " + err.Error())
+	}
+	if _, err := migrator.Up(); err != nil {
+		panic("failed to migrate test database: " + err.Error())
 	}
 
 	logger := utils.GetLogger()
@@ -366,3 +369,32 @@ func createAvailability(router *gin.Engine, userID, eventID uint, start, end tim
 	req.Header.Set("Content-Type", "application/json")
 	router.ServeHTTP(httptest.NewRecorder(), req)
 }
+
+func createTestEvent(router *gin.Engine, title string, durationMinutes int) models.Event {
+	eventJSON, _ := json.Marshal(map[string]interface{}{
+		"title":            title,
+		"organizer_id":     1,
+		"duration_minutes": durationMinutes,
+	})
+	req, _ := http.NewRequest("POST", "/api/v1/events", bytes.NewBuffer(eventJSON))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	var event models.Event
+	json.Unmarshal(resp.Body.Bytes(), &event)
+	return event
+}
+
+func createTestTimeSlot(router *gin.Engine, eventID uint, start, end time.Time) models.TimeSlot {
+	slotJSON, _ := json.Marshal(map[string]interface{}{
+		"start_time": start.Format(time.RFC3339),
+		"end_time":   end.Format(time.RFC3339),
+	})
+	req, _ := http.NewRequest("POST", fmt.Sprintf("/api/v1/events/%d/timeslots", eventID), bytes.NewBuffer(slotJSON))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	var slot models.TimeSlot
+	json.Unmarshal(resp.Body.Bytes(), &slot)
+	return slot
+}
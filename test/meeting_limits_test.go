@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// TestMeetingLimits gives a user buffers around meetings and a daily cap and
+// checks recommendations respect them against a finalized event.
+func TestMeetingLimits(t *testing.T) {
+	router, _ := setupTestRouter()
+
+	user := createTestUser(router, "limited@test.com")
+	userPath := fmt.Sprintf("/api/v1/users/%d", user.ID)
+	day := time.Date(2026, 11, 18, 9, 0, 0, 0, time.UTC)
+
+	earlier := createTestEvent(router, "Earlier", 60)
+	createTestTimeSlot(router, earlier.ID, day, day.Add(time.Hour))
+	createAvailability(router, user.ID, earlier.ID, day, day.Add(time.Hour))
+	if resp := finalizeEvent(router, earlier.ID, day); resp.Code != http.StatusOK {
+		t.Fatalf("Expected 200 finalizing, got %d: %s", resp.Code, resp.Body.String())
+	}
+
+	event := createTestEvent(router, "Next", 60)
+	createTestTimeSlot(router, event.ID, day, day.Add(3*time.Hour))
+	createAvailability(router, user.ID, event.ID, day, day.Add(3*time.Hour))
+
+	recommendations := getRecommendations(router, event.ID)
+	if len(recommendations) != 1 || !recommendations[0].StartOptions[0].Equal(day.Add(time.Hour)) {
+		t.Fatalf("Expected the meeting right after the finalized one, got %+v", recommendations)
+	}
+
+	if resp := patchJSON(router, userPath, `{"buffer_before_minutes": -5}`); resp.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected 422 for a negative buffer, got %d", resp.Code)
+	}
+	if resp := patchJSON(router, userPath, `{"buffer_before_minutes": 15, "buffer_after_minutes": 15}`); resp.Code != http.StatusOK {
+		t.Fatalf("Expected 200 setting buffers, got %d: %s", resp.Code, resp.Body.String())
+	}
+	recommendations = getRecommendations(router, event.ID)
+	if len(recommendations) != 1 {
+		t.Fatalf("Expected one recommendation, got %+v", recommendations)
+	}
+	options := recommendations[0].StartOptions
+	if !options[0].Equal(day.Add(75*time.Minute)) || !options[len(options)-1].Equal(day.Add(2*time.Hour)) {
+		t.Errorf("Expected starts from 10:15 to 11:00, got %v", options)
+	}
+
+	// Two hours a day fit both meetings; ninety minutes don't.
+	if resp := patchJSON(router, userPath, `{"max_daily_meeting_minutes": 120}`); resp.Code != http.StatusOK {
+		t.Fatalf("Expected 200 setting a cap, got %d: %s", resp.Code, resp.Body.String())
+	}
+	if recommendations := getRecommendations(router, event.ID); len(recommendations) != 1 {
+		t.Errorf("Expected the meeting to fit a two hour cap, got %+v", recommendations)
+	}
+	patchJSON(router, userPath, `{"max_daily_meeting_minutes": 90}`)
+	if recommendations := getRecommendations(router, event.ID); len(recommendations) != 0 {
+		t.Errorf("Expected nothing offered over the cap, got %+v", recommendations)
+	}
+}
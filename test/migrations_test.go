@@ -0,0 +1,107 @@
+package main
+
+import (
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/krushnna/meeting-scheduler/migrations"
+)
+
+// TestMigrationsUpDown applies all migrations, rolls them back and reapplies them.
+func TestMigrationsUpDown(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:?_foreign_keys=on"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+
+	migrator, err := migrations.New(db)
+	if err != nil {
+		t.Fatalf("Failed to load migrations: %v", err)
+	}
+
+	applied, err := migrator.Up()
+	if err != nil {
+		t.Fatalf("Up failed: %v", err)
+	}
+	if len(applied) == 0 {
+		t.Fatal("Expected migrations to be applied")
+	}
+	if again, _ := migrator.Up(); len(again) != 0 {
+		t.Errorf("Expected second Up to be a no-op, applied %d", len(again))
+	}
+	if !db.Migrator().HasTable("events") {
+		t.Error("Expected events table to exist")
+	}
+
+	statuses, err := migrator.Status()
+	if err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+	for _, s := range statuses {
+		if s.AppliedAt == nil {
+			t.Errorf("Expected migration %d to be applied", s.Version)
+		}
+	}
+
+	reverted, err := migrator.Down(len(applied))
+	if err != nil {
+		t.Fatalf("Down failed: %v", err)
+	}
+	if len(reverted) != len(applied) {
+		t.Errorf("Expected %d reverted migrations, got %d", len(applied), len(reverted))
+	}
+	if db.Migrator().HasTable("events") {
+		t.Error("Expected events table to be dropped")
+	}
+
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("Re-applying migrations failed: %v", err)
+	}
+}
+
+// TestSeriesParticipantsMigration moves JSON participant IDs into the join
+// table and back again.
+func TestSeriesParticipantsMigration(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:?_foreign_keys=on"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+
+	migrator, err := migrations.New(db)
+	if err != nil {
+		t.Fatalf("Failed to load migrations: %v", err)
+	}
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("Up failed: %v", err)
+	}
+	if _, err := migrator.Down(1); err != nil {
+		t.Fatalf("Down failed: %v", err)
+	}
+
+	db.Exec("INSERT INTO users (id, name, email) VALUES (1, 'Ann', 'ann@test.com'), (2, 'Ben', 'ben@test.com')")
+	db.Exec(`INSERT INTO event_series (id, title, organizer_id, duration_minutes, start_time, timezone, rrule, participant_ids)
+		VALUES (1, 'Standup', 1, 15, '2026-10-19 09:30:00', 'UTC', 'FREQ=DAILY', '[2,1]'), (2, 'Retro', 1, 60, '2026-10-19 15:00:00', 'UTC', 'FREQ=WEEKLY', NULL)`)
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("Up failed: %v", err)
+	}
+	var count int64
+	db.Table("series_participants").Where("series_id = 1").Count(&count)
+	if count != 2 {
+		t.Errorf("Expected two participants moved into series_participants, got %d", count)
+	}
+
+	if _, err := migrator.Down(1); err != nil {
+		t.Fatalf("Down failed: %v", err)
+	}
+	var participantIDs []string
+	db.Table("event_series").Order("id").Pluck("COALESCE(participant_ids, '')", &participantIDs)
+	if len(participantIDs) != 2 || participantIDs[0] != "[1,2]" || participantIDs[1] != "" {
+		t.Errorf("Expected participant IDs restored as JSON, got %v", participantIDs)
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/krushnna/meeting-scheduler/models"
+	"github.com/krushnna/meeting-scheduler/repository"
+	"github.com/krushnna/meeting-scheduler/services"
+	"github.com/krushnna/meeting-scheduler/utils"
+)
+
+// recordingNotifier keeps every event it is handed, optionally failing first.
+type recordingNotifier struct {
+	failures int
+	events   []models.OutboxEvent
+}
+
+func (n *recordingNotifier) Notify(ctx context.Context, event models.OutboxEvent) error {
+	if n.failures > 0 {
+		n.failures--
+		return context.DeadlineExceeded
+	}
+	n.events = append(n.events, event)
+	return nil
+}
+
+// TestOutboxDispatch verifies mutations write outbox rows that are delivered once.
+func TestOutboxDispatch(t *testing.T) {
+	router, db := setupTestRouter()
+
+	eventJSON, _ := json.Marshal(map[string]interface{}{
+		"title":            "Outbox Event",
+		"organizer_id":     1,
+		"duration_minutes": 30,
+	})
+	req, _ := http.NewRequest("POST", "/api/v1/events", bytes.NewBuffer(eventJSON))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	var event models.Event
+	json.Unmarshal(resp.Body.Bytes(), &event)
+
+	req, _ = http.NewRequest("DELETE", "/api/v1/events/"+strconv.Itoa(int(event.ID)), nil)
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	var pending int64
+	db.Model(&models.OutboxEvent{}).Where("status = ?", models.OutboxStatusPending).Count(&pending)
+	if pending != 2 {
+		t.Fatalf("Expected 2 pending outbox events, got %d", pending)
+	}
+
+	notifier := &recordingNotifier{failures: 1}
+	dispatcher := services.NewOutboxDispatcher(repository.NewOutboxRepository(db), []services.Notifier{notifier}, time.Millisecond, utils.GetLogger())
+
+	// The first delivery fails and is rescheduled, the second succeeds.
+	dispatched, err := dispatcher.DispatchPending(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected dispatch error: %v", err)
+	}
+	if dispatched != 1 {
+		t.Errorf("Expected 1 dispatched event on first pass, got %d", dispatched)
+	}
+
+	time.Sleep(5 * time.Millisecond)
+	if _, err := dispatcher.DispatchPending(context.Background()); err != nil {
+		t.Fatalf("Unexpected dispatch error: %v", err)
+	}
+	if _, err := dispatcher.DispatchPending(context.Background()); err != nil {
+		t.Fatalf("Unexpected dispatch error: %v", err)
+	}
+
+	if len(notifier.events) != 2 {
+		t.Fatalf("Expected 2 delivered events, got %d", len(notifier.events))
+	}
+	types := map[string]bool{}
+	for _, e := range notifier.events {
+		types[e.EventType] = true
+	}
+	if !types[services.EventCreated] || !types[services.EventDeleted] {
+		t.Errorf("Expected created and deleted events, got %v", types)
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/krushnna/meeting-scheduler/models"
+)
+
+type eventPage struct {
+	Data       []models.Event `json:"data"`
+	Total      int64          `json:"total"`
+	NextCursor string         `json:"next_cursor"`
+	Links      struct {
+		Next string `json:"next"`
+	} `json:"links"`
+}
+
+func getEventPage(t *testing.T, router *gin.Engine, path string) eventPage {
+	t.Helper()
+	req, _ := http.NewRequest("GET", path, nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	if resp.Code != http.StatusOK {
+		t.Fatalf("Expected 200 for %s, got %d: %s", path, resp.Code, resp.Body.String())
+	}
+	var page eventPage
+	json.Unmarshal(resp.Body.Bytes(), &page)
+	return page
+}
+
+// TestEventPagination walks the event list with cursors and checks filters and sorting.
+func TestEventPagination(t *testing.T) {
+	router, _ := setupTestRouter()
+
+	durations := []int{30, 90, 60, 45, 15}
+	for i, duration := range durations {
+		event := createTestEvent(router, "Paged Event", duration)
+		if i == 0 {
+			start := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+			createTestTimeSlot(router, event.ID, start, start.Add(time.Hour))
+		}
+	}
+
+	// Follow the next links until the list is exhausted.
+	var seen []uint
+	path := "/api/v1/events?limit=2&sort=-duration_minutes"
+	for path != "" {
+		page := getEventPage(t, router, path)
+		if page.Total != int64(len(durations)) {
+			t.Fatalf("Expected total %d, got %d", len(durations), page.Total)
+		}
+		for _, event := range page.Data {
+			seen = append(seen, uint(event.DurationMinutes))
+		}
+		path = page.Links.Next
+	}
+	expected := []uint{90, 60, 45, 30, 15}
+	if len(seen) != len(expected) {
+		t.Fatalf("Expected %d events across pages, got %v", len(expected), seen)
+	}
+	for i := range expected {
+		if seen[i] != expected[i] {
+			t.Fatalf("Expected durations %v, got %v", expected, seen)
+		}
+	}
+
+	// A cursor is only valid for the sort it was issued for.
+	first := getEventPage(t, router, "/api/v1/events?limit=2")
+	req, _ := http.NewRequest("GET", "/api/v1/events?sort=title&cursor="+first.NextCursor, nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	if resp.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for a cursor from another sort, got %d", resp.Code)
+	}
+	for _, path := range []string{"/api/v1/events?sort=secret", "/api/v1/events?limit=500", "/api/v1/events?cursor=not-a-cursor"} {
+		req, _ := http.NewRequest("GET", path, nil)
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		if resp.Code != http.StatusBadRequest {
+			t.Errorf("Expected 400 for %s, got %d", path, resp.Code)
+		}
+	}
+
+	// Only the first event has a slot in the next three days.
+	from := time.Now().UTC().Format(time.RFC3339)
+	to := time.Now().UTC().Add(72 * time.Hour).Format(time.RFC3339)
+	ranged := getEventPage(t, router, "/api/v1/events?from="+from+"&to="+to)
+	if ranged.Total != 1 || len(ranged.Data) != 1 || ranged.Data[0].DurationMinutes != 30 {
+		t.Errorf("Expected only the event with a slot in range, got %+v", ranged)
+	}
+
+	polling := getEventPage(t, router, "/api/v1/events?status=polling&organizer_id=1")
+	if polling.Total != int64(len(durations)) || polling.NextCursor != "" {
+		t.Errorf("Expected every event on one page of polling events, got total %d", polling.Total)
+	}
+	if cancelled := getEventPage(t, router, "/api/v1/events?status=cancelled"); cancelled.Total != 0 || cancelled.Data == nil {
+		t.Errorf("Expected an empty data array for cancelled events, got %+v", cancelled)
+	}
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/krushnna/meeting-scheduler/models"
+)
+
+func getRecommendations(router *gin.Engine, eventID uint) []models.TimeSlotRecommendation {
+	req, _ := http.NewRequest("GET", fmt.Sprintf("/api/v1/events/%d/recommendations", eventID), nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	var recommendations []models.TimeSlotRecommendation
+	json.Unmarshal(resp.Body.Bytes(), &recommendations)
+	return recommendations
+}
+
+// TestEventParticipants invites users who never respond and checks they count
+// against the matching percentage until they decline or are removed.
+func TestEventParticipants(t *testing.T) {
+	router, _ := setupTestRouter()
+
+	responder := createTestUser(router, "responder@test.com")
+	silent := createTestUser(router, "silent@test.com")
+	event := createTestEvent(router, "Kickoff", 60)
+	start := time.Date(2026, 11, 5, 9, 0, 0, 0, time.UTC)
+	createTestTimeSlot(router, event.ID, start, start.Add(time.Hour))
+
+	// Submitting availability makes the user a responded participant.
+	createAvailability(router, responder.ID, event.ID, start, start.Add(time.Hour))
+	participantsPath := fmt.Sprintf("/api/v1/events/%d/participants", event.ID)
+	resp := postJSON(router, participantsPath, map[string]uint{"user_id": silent.ID})
+	if resp.Code != http.StatusCreated {
+		t.Fatalf("Expected 201 inviting, got %d: %s", resp.Code, resp.Body.String())
+	}
+	if resp := postJSON(router, participantsPath, map[string]uint{"user_id": silent.ID}); resp.Code != http.StatusConflict {
+		t.Errorf("Expected 409 inviting twice, got %d", resp.Code)
+	}
+
+	req, _ := http.NewRequest("GET", participantsPath, nil)
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	var participants []models.EventParticipant
+	json.Unmarshal(resp.Body.Bytes(), &participants)
+	if len(participants) != 2 || participants[0].Status != models.ParticipantStatusResponded || participants[1].Status != models.ParticipantStatusInvited {
+		t.Fatalf("Expected one responded and one invited participant, got %s", resp.Body.String())
+	}
+
+	recommendations := getRecommendations(router, event.ID)
+	if len(recommendations) != 1 || recommendations[0].MatchingPercentage != 50 {
+		t.Fatalf("Expected 50%% with a non-responder, got %+v", recommendations)
+	}
+	if nonResponders := recommendations[0].NonResponders; len(nonResponders) != 1 || nonResponders[0].ID != silent.ID {
+		t.Errorf("Expected the silent user listed as a non-responder, got %+v", nonResponders)
+	}
+
+	// Declined invitees no longer count.
+	body, _ := json.Marshal(map[string]string{"status": models.ParticipantStatusDeclined})
+	req, _ = http.NewRequest("PUT", fmt.Sprintf("%s/%d", participantsPath, silent.ID), bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	if resp.Code != http.StatusOK {
+		t.Fatalf("Expected 200 declining, got %d: %s", resp.Code, resp.Body.String())
+	}
+	if recommendations := getRecommendations(router, event.ID); len(recommendations) != 1 || recommendations[0].MatchingPercentage != 100 {
+		t.Errorf("Expected 100%% once the invitee declined, got %+v", recommendations)
+	}
+
+	// Removing the responder takes their availability with them.
+	req, _ = http.NewRequest("DELETE", fmt.Sprintf("%s/%d", participantsPath, responder.ID), nil)
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	if resp.Code != http.StatusOK {
+		t.Fatalf("Expected 200 removing, got %d: %s", resp.Code, resp.Body.String())
+	}
+	req, _ = http.NewRequest("GET", fmt.Sprintf("/api/v1/users/%d/events/%d/availability", responder.ID, event.ID), nil)
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	var availability []models.UserAvailability
+	json.Unmarshal(resp.Body.Bytes(), &availability)
+	if len(availability) != 0 {
+		t.Errorf("Expected the removed participant's availability deleted, got %s", resp.Body.String())
+	}
+	if recommendations := getRecommendations(router, event.ID); len(recommendations) != 0 {
+		t.Errorf("Expected no recommendations without responders, got %+v", recommendations)
+	}
+}
+
+// TestInviteParticipantFields checks an invitation can't set its own RSVP and
+// that a participant added by responding can be made required afterwards.
+func TestInviteParticipantFields(t *testing.T) {
+	router, _ := setupTestRouter()
+
+	eager := createTestUser(router, "eager@test.com")
+	early := createTestUser(router, "early@test.com")
+	event := createTestEvent(router, "Kickoff", 60)
+	start := time.Date(2026, 11, 18, 9, 0, 0, 0, time.UTC)
+	createTestTimeSlot(router, event.ID, start, start.Add(2*time.Hour))
+	participantsPath := fmt.Sprintf("/api/v1/events/%d/participants", event.ID)
+
+	for _, rsvp := range []string{"accepted", "bogus"} {
+		resp := postJSON(router, participantsPath, map[string]interface{}{"user_id": eager.ID, "rsvp": rsvp, "rsvp_at": start})
+		if resp.Code != http.StatusCreated {
+			t.Fatalf("Expected 201 inviting with rsvp %q, got %d: %s", rsvp, resp.Code, resp.Body.String())
+		}
+		var participant models.EventParticipant
+		json.Unmarshal(resp.Body.Bytes(), &participant)
+		if participant.RSVP != models.RSVPPending || participant.RSVPAt != nil {
+			t.Errorf("Expected a pending RSVP, got %+v", participant)
+		}
+		req, _ := http.NewRequest("DELETE", fmt.Sprintf("%s/%d", participantsPath, eager.ID), nil)
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	// Responding first adds the user as an optional participant.
+	createAvailability(router, early.ID, event.ID, start, start.Add(time.Hour))
+	if resp := postJSON(router, participantsPath, map[string]interface{}{"user_id": early.ID, "required": true}); resp.Code != http.StatusConflict {
+		t.Errorf("Expected 409 inviting twice, got %d", resp.Code)
+	}
+	participantPath := fmt.Sprintf("%s/%d", participantsPath, early.ID)
+	resp := putJSON(router, participantPath, map[string]bool{"required": true})
+	if resp.Code != http.StatusOK {
+		t.Fatalf("Expected 200 making the participant required, got %d: %s", resp.Code, resp.Body.String())
+	}
+	var participant models.EventParticipant
+	json.Unmarshal(resp.Body.Bytes(), &participant)
+	if !participant.Required || participant.Status != models.ParticipantStatusResponded {
+		t.Errorf("Expected a required participant still responded, got %+v", participant)
+	}
+	if resp := putJSON(router, participantPath, map[string]string{}); resp.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected 422 without status or required, got %d", resp.Code)
+	}
+}
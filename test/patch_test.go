@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/krushnna/meeting-scheduler/models"
+)
+
+// patchJSON sends a merge patch to path and returns the response.
+func patchJSON(router http.Handler, path, patch string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest("PATCH", path, bytes.NewBufferString(patch))
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	return resp
+}
+
+// TestPatchEvent verifies merge patches update only the given fields and can clear values.
+func TestPatchEvent(t *testing.T) {
+	router, _ := setupTestRouter()
+
+	payload, _ := json.Marshal(map[string]interface{}{
+		"title":            "Patch Event",
+		"description":      "To be cleared",
+		"organizer_id":     1,
+		"duration_minutes": 30,
+	})
+	req, _ := http.NewRequest("POST", "/api/v1/events", bytes.NewBuffer(payload))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	var event models.Event
+	json.Unmarshal(resp.Body.Bytes(), &event)
+
+	path := fmt.Sprintf("/api/v1/events/%d", event.ID)
+	resp = patchJSON(router, path, `{"description": null, "duration_minutes": 45}`)
+	if resp.Code != http.StatusOK {
+		t.Fatalf("Expected 200 on patch, got %d: %s", resp.Code, resp.Body.String())
+	}
+
+	var patched models.Event
+	json.Unmarshal(resp.Body.Bytes(), &patched)
+	if patched.Description != "" {
+		t.Errorf("Expected description to be cleared, got %q", patched.Description)
+	}
+	if patched.DurationMinutes != 45 || patched.Title != "Patch Event" {
+		t.Errorf("Unexpected patched event: %+v", patched)
+	}
+
+	// The merged event is validated as a whole.
+	resp = patchJSON(router, path, `{"title": ""}`)
+	if resp.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected 422 when clearing the title, got %d", resp.Code)
+	}
+	resp = patchJSON(router, path, `["not", "an", "object"]`)
+	if resp.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected 422 for a non-object patch, got %d", resp.Code)
+	}
+
+	resp = patchJSON(router, "/api/v1/events/9999", `{"title": "Missing"}`)
+	if resp.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 patching a missing event, got %d", resp.Code)
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/krushnna/meeting-scheduler/models"
+)
+
+// TestQuorum checks recommendations flag and prefer starts meeting an event's
+// quorum and that finalizing below it needs an override.
+func TestQuorum(t *testing.T) {
+	router, _ := setupTestRouter()
+
+	alice := createTestUser(router, "alice.quorum@test.com")
+	bob := createTestUser(router, "bob.quorum@test.com")
+	carol := createTestUser(router, "carol.quorum@test.com")
+	start := time.Date(2026, 11, 19, 9, 0, 0, 0, time.UTC)
+
+	if resp := postJSON(router, "/api/v1/events", map[string]interface{}{
+		"title": "Both", "organizer_id": 1, "duration_minutes": 60, "quorum": 2, "quorum_percentage": 50,
+	}); resp.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected 422 setting both quorum kinds, got %d", resp.Code)
+	}
+
+	// Nobody can attend together, so no start reaches two attendees.
+	resp := postJSON(router, "/api/v1/events", map[string]interface{}{
+		"title": "Review", "organizer_id": 1, "duration_minutes": 60, "quorum": 2,
+	})
+	if resp.Code != http.StatusCreated {
+		t.Fatalf("Expected 201 creating the event, got %d: %s", resp.Code, resp.Body.String())
+	}
+	var review models.Event
+	json.Unmarshal(resp.Body.Bytes(), &review)
+	createTestTimeSlot(router, review.ID, start, start.Add(2*time.Hour))
+	createAvailability(router, alice.ID, review.ID, start, start.Add(time.Hour))
+	createAvailability(router, bob.ID, review.ID, start.Add(time.Hour), start.Add(2*time.Hour))
+
+	recommendations := getRecommendations(router, review.ID)
+	if len(recommendations) != 1 || recommendations[0].QuorumMet {
+		t.Fatalf("Expected one recommendation below quorum, got %+v", recommendations)
+	}
+	if resp := finalizeEvent(router, review.ID, start); resp.Code != http.StatusConflict {
+		t.Errorf("Expected 409 finalizing below quorum, got %d", resp.Code)
+	}
+	finalizePath := fmt.Sprintf("/api/v1/events/%d/finalize", review.ID)
+	if resp := postJSON(router, finalizePath, map[string]interface{}{"start_time": start, "override_quorum": true}); resp.Code != http.StatusOK {
+		t.Errorf("Expected 200 overriding the quorum, got %d: %s", resp.Code, resp.Body.String())
+	}
+
+	// With every required participant needed, the start Alice can make wins
+	// over the one more people can.
+	resp = postJSON(router, "/api/v1/events", map[string]interface{}{
+		"title": "Kickoff", "organizer_id": 1, "duration_minutes": 60, "quorum_all_required": true,
+	})
+	var kickoff models.Event
+	json.Unmarshal(resp.Body.Bytes(), &kickoff)
+	createTestTimeSlot(router, kickoff.ID, start.Add(24*time.Hour), start.Add(26*time.Hour))
+	postJSON(router, fmt.Sprintf("/api/v1/events/%d/participants", kickoff.ID), map[string]interface{}{"user_id": alice.ID, "required": true})
+	createAvailability(router, alice.ID, kickoff.ID, start.Add(25*time.Hour), start.Add(26*time.Hour))
+	createAvailability(router, bob.ID, kickoff.ID, start.Add(24*time.Hour), start.Add(25*time.Hour))
+	createAvailability(router, carol.ID, kickoff.ID, start.Add(24*time.Hour), start.Add(25*time.Hour))
+
+	recommendations = getRecommendations(router, kickoff.ID)
+	if len(recommendations) != 1 || !recommendations[0].QuorumMet || !recommendations[0].StartOptions[0].Equal(start.Add(25*time.Hour)) {
+		t.Fatalf("Expected Alice's hour meeting quorum, got %+v", recommendations)
+	}
+	if resp := finalizeEvent(router, kickoff.ID, start.Add(24*time.Hour)); resp.Code != http.StatusConflict {
+		t.Errorf("Expected 409 finalizing without a required participant, got %d", resp.Code)
+	}
+	if resp := finalizeEvent(router, kickoff.ID, start.Add(25*time.Hour)); resp.Code != http.StatusOK {
+		t.Errorf("Expected 200 finalizing with quorum, got %d: %s", resp.Code, resp.Body.String())
+	}
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/krushnna/meeting-scheduler/models"
+)
+
+// TestRescheduleEvent reopens a finalized event for polling, checking the old
+// time is kept in the history and participants are asked to respond again.
+func TestRescheduleEvent(t *testing.T) {
+	router, db := setupTestRouter()
+
+	user := createTestUser(router, "mover@test.com")
+	event := createTestEvent(router, "Retro", 60)
+	start := time.Date(2026, 11, 12, 15, 0, 0, 0, time.UTC)
+	createTestTimeSlot(router, event.ID, start, start.Add(time.Hour))
+	createAvailability(router, user.ID, event.ID, start, start.Add(time.Hour))
+
+	reschedulePath := fmt.Sprintf("/api/v1/events/%d/reschedule", event.ID)
+	if resp := postJSON(router, reschedulePath, map[string]string{}); resp.Code != http.StatusConflict {
+		t.Errorf("Expected 409 rescheduling a polling event, got %d", resp.Code)
+	}
+	if resp := finalizeEvent(router, event.ID, start); resp.Code != http.StatusOK {
+		t.Fatalf("Expected 200 finalizing, got %d: %s", resp.Code, resp.Body.String())
+	}
+
+	nextWeek := start.AddDate(0, 0, 7)
+	resp := postJSON(router, reschedulePath, map[string]interface{}{
+		"reason":     "room double-booked",
+		"time_slots": []map[string]time.Time{{"start_time": nextWeek, "end_time": nextWeek.Add(2 * time.Hour)}},
+	})
+	if resp.Code != http.StatusOK {
+		t.Fatalf("Expected 200 rescheduling, got %d: %s", resp.Code, resp.Body.String())
+	}
+	var rescheduled models.Event
+	json.Unmarshal(resp.Body.Bytes(), &rescheduled)
+	if rescheduled.Status != models.EventStatusPolling || rescheduled.ScheduledStart != nil {
+		t.Errorf("Expected the event back in polling, got %s", resp.Body.String())
+	}
+	if history := rescheduled.ScheduleHistory; len(history) != 1 || !history[0].ScheduledStart.Equal(start) || history[0].Reason != "room double-booked" {
+		t.Errorf("Expected the previous time in the history, got %+v", history)
+	}
+
+	req, _ := http.NewRequest("GET", fmt.Sprintf("/api/v1/events/%d/timeslots", event.ID), nil)
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	var slots struct {
+		Data []models.TimeSlot `json:"data"`
+	}
+	json.Unmarshal(resp.Body.Bytes(), &slots)
+	if len(slots.Data) != 2 {
+		t.Errorf("Expected the new time slot added, got %s", resp.Body.String())
+	}
+
+	// Availability was not preserved, so the user has to respond again.
+	detail := getEvent(router, event.ID)
+	if len(detail.Participants) != 1 || detail.Participants[0].Status != models.ParticipantStatusInvited {
+		t.Errorf("Expected the participant back to invited, got %+v", detail.Participants)
+	}
+	if recommendations := getRecommendations(router, event.ID); len(recommendations) != 0 {
+		t.Errorf("Expected no recommendations without availability, got %+v", recommendations)
+	}
+	var deletions, notifications int64
+	db.Model(&models.AuditLog{}).Where("entity_type = ? AND action = ?", "availability", "delete").Count(&deletions)
+	db.Model(&models.OutboxEvent{}).Where("event_type = ?", "availability.deleted").Count(&notifications)
+	if deletions != 1 || notifications != 1 {
+		t.Errorf("Expected the cleared availability audited and announced, got %d audit entries and %d events", deletions, notifications)
+	}
+
+	var notification models.OutboxEvent
+	if err := db.Where("event_type = ?", "event.rescheduled").First(&notification).Error; err != nil {
+		t.Fatalf("Expected an event.rescheduled notification: %v", err)
+	}
+	var payload struct {
+		ParticipantIDs []uint `json:"participant_ids"`
+	}
+	json.Unmarshal([]byte(notification.Payload), &payload)
+	if len(payload.ParticipantIDs) != 1 || payload.ParticipantIDs[0] != user.ID {
+		t.Errorf("Expected the participant listed in the notification, got %s", notification.Payload)
+	}
+}
+
+// TestStatusChangeOnScheduledEvent checks a scheduled event can't be reopened
+// through update or patch and that cancelling it frees its room.
+func TestStatusChangeOnScheduledEvent(t *testing.T) {
+	router, _ := setupTestRouter()
+
+	room := createTestResource(router, "Den", "room", 8)
+	user := createTestUser(router, "status@test.com")
+	start := time.Date(2026, 11, 26, 10, 0, 0, 0, time.UTC)
+	resp := postJSON(router, "/api/v1/events", map[string]interface{}{
+		"title": "Offsite", "organizer_id": 1, "duration_minutes": 60, "resource_id": room.ID,
+	})
+	var event models.Event
+	json.Unmarshal(resp.Body.Bytes(), &event)
+	createTestTimeSlot(router, event.ID, start, start.Add(time.Hour))
+	createAvailability(router, user.ID, event.ID, start, start.Add(time.Hour))
+	if resp := finalizeEvent(router, event.ID, start); resp.Code != http.StatusOK {
+		t.Fatalf("Expected 200 finalizing, got %d: %s", resp.Code, resp.Body.String())
+	}
+
+	eventPath := fmt.Sprintf("/api/v1/events/%d", event.ID)
+	if resp := patchJSON(router, eventPath, `{"status": "polling"}`); resp.Code != http.StatusConflict {
+		t.Errorf("Expected 409 patching a scheduled event back to polling, got %d", resp.Code)
+	}
+	if resp := putJSON(router, eventPath, map[string]interface{}{
+		"title": "Offsite", "organizer_id": 1, "duration_minutes": 60, "resource_id": room.ID, "status": "polling",
+	}); resp.Code != http.StatusConflict {
+		t.Errorf("Expected 409 updating a scheduled event back to polling, got %d", resp.Code)
+	}
+
+	resp = patchJSON(router, eventPath, `{"status": "cancelled"}`)
+	if resp.Code != http.StatusOK {
+		t.Fatalf("Expected 200 cancelling, got %d: %s", resp.Code, resp.Body.String())
+	}
+	json.Unmarshal(resp.Body.Bytes(), &event)
+	if event.ScheduledStart != nil || event.ReservedResourceID != nil {
+		t.Errorf("Expected the schedule cleared on cancel, got %+v", event)
+	}
+	req, _ := http.NewRequest("GET", fmt.Sprintf("/api/v1/resources/%d/bookings", room.ID), nil)
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	var bookings []models.ResourceBooking
+	json.Unmarshal(resp.Body.Bytes(), &bookings)
+	if resp.Code != http.StatusOK || len(bookings) != 0 {
+		t.Errorf("Expected the room released on cancel, got %s", resp.Body.String())
+	}
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/krushnna/meeting-scheduler/models"
+)
+
+func createTestResource(router *gin.Engine, name, kind string, capacity int) models.Resource {
+	resp := postJSON(router, "/api/v1/resources", map[string]interface{}{"name": name, "kind": kind, "capacity": capacity})
+	var resource models.Resource
+	json.Unmarshal(resp.Body.Bytes(), &resource)
+	return resource
+}
+
+// TestResources requires a room over a number of seats, checks recommendations
+// only offer times one is free and that finalizing reserves it until the event
+// is rescheduled.
+func TestResources(t *testing.T) {
+	router, _ := setupTestRouter()
+
+	small := createTestResource(router, "Huddle", "room", 4)
+	big := createTestResource(router, "Boardroom", "room", 10)
+	createTestResource(router, "Projector", "equipment", 0)
+	if resp := postJSON(router, "/api/v1/resources", map[string]interface{}{"name": "Closet", "kind": "cupboard"}); resp.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected 422 for an unknown kind, got %d", resp.Code)
+	}
+
+	user := createTestUser(router, "roomy@test.com")
+	start := time.Date(2026, 11, 17, 9, 0, 0, 0, time.UTC)
+	resp := postJSON(router, "/api/v1/events", map[string]interface{}{
+		"title": "All hands", "organizer_id": 1, "duration_minutes": 60, "min_capacity": 6,
+	})
+	if resp.Code != http.StatusCreated {
+		t.Fatalf("Expected 201 creating the event, got %d: %s", resp.Code, resp.Body.String())
+	}
+	var event models.Event
+	json.Unmarshal(resp.Body.Bytes(), &event)
+	createTestTimeSlot(router, event.ID, start, start.Add(2*time.Hour))
+	createAvailability(router, user.ID, event.ID, start, start.Add(2*time.Hour))
+
+	if resp := postJSON(router, "/api/v1/events", map[string]interface{}{
+		"title": "Nowhere", "organizer_id": 1, "duration_minutes": 60, "resource_id": 9999,
+	}); resp.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected 422 naming a missing resource, got %d", resp.Code)
+	}
+
+	// The only room big enough is blocked for the first hour.
+	bookingsPath := fmt.Sprintf("/api/v1/resources/%d/bookings", big.ID)
+	resp = postJSON(router, bookingsPath, map[string]interface{}{"start_time": start, "end_time": start.Add(time.Hour), "note": "cleaning"})
+	if resp.Code != http.StatusCreated {
+		t.Fatalf("Expected 201 booking, got %d: %s", resp.Code, resp.Body.String())
+	}
+	if resp := postJSON(router, bookingsPath, map[string]interface{}{"start_time": start.Add(30 * time.Minute), "end_time": start.Add(90 * time.Minute)}); resp.Code != http.StatusConflict {
+		t.Errorf("Expected 409 for an overlapping booking, got %d", resp.Code)
+	}
+
+	recommendations := getRecommendations(router, event.ID)
+	if len(recommendations) != 1 || !recommendations[0].StartOptions[0].Equal(start.Add(time.Hour)) {
+		t.Fatalf("Expected only the second hour offered, got %+v", recommendations)
+	}
+	if resources := recommendations[0].Resources; len(resources) != 1 || resources[0].ID != big.ID {
+		t.Errorf("Expected the boardroom offered, got %+v", resources)
+	}
+
+	if resp := finalizeEvent(router, event.ID, start); resp.Code != http.StatusConflict {
+		t.Errorf("Expected 409 finalizing while no room is free, got %d", resp.Code)
+	}
+	resp = finalizeEvent(router, event.ID, start.Add(time.Hour))
+	if resp.Code != http.StatusOK {
+		t.Fatalf("Expected 200 finalizing, got %d: %s", resp.Code, resp.Body.String())
+	}
+	json.Unmarshal(resp.Body.Bytes(), &event)
+	if event.ReservedResourceID == nil || *event.ReservedResourceID != big.ID {
+		t.Errorf("Expected the boardroom reserved, got %+v", event.ReservedResourceID)
+	}
+
+	getBookings := func() []models.ResourceBooking {
+		req, _ := http.NewRequest("GET", bookingsPath+"?from="+start.Format(time.RFC3339), nil)
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		var bookings []models.ResourceBooking
+		json.Unmarshal(resp.Body.Bytes(), &bookings)
+		return bookings
+	}
+	bookings := getBookings()
+	if len(bookings) != 2 || bookings[1].EventID == nil || *bookings[1].EventID != event.ID {
+		t.Fatalf("Expected the event's booking after the cleaning, got %+v", bookings)
+	}
+	req, _ := http.NewRequest("DELETE", fmt.Sprintf("%s/%d", bookingsPath, bookings[1].ID), nil)
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	if resp.Code != http.StatusConflict {
+		t.Errorf("Expected 409 deleting an event's booking, got %d", resp.Code)
+	}
+
+	// The small room still fits a smaller meeting in the same hour.
+	other := createTestUser(router, "huddler@test.com")
+	standup := createTestEvent(router, "Standup", 60)
+	createTestTimeSlot(router, standup.ID, start.Add(time.Hour), start.Add(2*time.Hour))
+	createAvailability(router, other.ID, standup.ID, start.Add(time.Hour), start.Add(2*time.Hour))
+	if resp := patchJSON(router, fmt.Sprintf("/api/v1/events/%d", standup.ID), fmt.Sprintf(`{"resource_id": %d}`, small.ID)); resp.Code != http.StatusOK {
+		t.Fatalf("Expected 200 naming the small room, got %d: %s", resp.Code, resp.Body.String())
+	}
+	if recommendations := getRecommendations(router, standup.ID); len(recommendations) != 1 || recommendations[0].Resources[0].ID != small.ID {
+		t.Errorf("Expected the small room offered, got %+v", recommendations)
+	}
+
+	// Rescheduling releases the room.
+	resp = postJSON(router, fmt.Sprintf("/api/v1/events/%d/reschedule", event.ID), map[string]string{})
+	if resp.Code != http.StatusOK {
+		t.Fatalf("Expected 200 rescheduling, got %d: %s", resp.Code, resp.Body.String())
+	}
+	event = models.Event{}
+	json.Unmarshal(resp.Body.Bytes(), &event)
+	if event.ReservedResourceID != nil || len(getBookings()) != 1 {
+		t.Errorf("Expected the boardroom released, got %s", resp.Body.String())
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/krushnna/meeting-scheduler/utils"
+)
+
+// TestRRuleBetween expands each supported rule shape and checks the dates it produces.
+func TestRRuleBetween(t *testing.T) {
+	newYork, _ := time.LoadLocation("America/New_York")
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2028, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name    string
+		rule    string
+		dtstart time.Time
+		limit   int
+		want    []string
+	}{
+		{"daily interval", "FREQ=DAILY;INTERVAL=3;COUNT=4", time.Date(2026, 2, 26, 9, 0, 0, 0, time.UTC), 10,
+			[]string{"2026-02-26", "2026-03-01", "2026-03-04", "2026-03-07"}},
+		{"daily by month day", "FREQ=DAILY;BYMONTHDAY=1,-1;COUNT=3", time.Date(2026, 1, 15, 9, 0, 0, 0, time.UTC), 10,
+			[]string{"2026-01-31", "2026-02-01", "2026-02-28"}},
+		{"weekly by day", "FREQ=WEEKLY;BYDAY=MO,FR;INTERVAL=2;COUNT=4", time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC), 10,
+			[]string{"2026-01-05", "2026-01-09", "2026-01-19", "2026-01-23"}},
+		{"monthly", "FREQ=MONTHLY;COUNT=3", time.Date(2026, 1, 15, 9, 0, 0, 0, time.UTC), 10,
+			[]string{"2026-01-15", "2026-02-15", "2026-03-15"}},
+		{"monthly skips short months", "FREQ=MONTHLY;COUNT=3", time.Date(2026, 1, 31, 9, 0, 0, 0, time.UTC), 10,
+			[]string{"2026-01-31", "2026-03-31", "2026-05-31"}},
+		{"monthly interval", "FREQ=MONTHLY;INTERVAL=5;COUNT=3", time.Date(2026, 1, 10, 9, 0, 0, 0, time.UTC), 10,
+			[]string{"2026-01-10", "2026-06-10", "2026-11-10"}},
+		{"monthly by month day", "FREQ=MONTHLY;BYMONTHDAY=1,15;COUNT=4", time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC), 10,
+			[]string{"2026-01-01", "2026-01-15", "2026-02-01", "2026-02-15"}},
+		{"monthly last day", "FREQ=MONTHLY;BYMONTHDAY=-1;COUNT=3", time.Date(2026, 1, 31, 9, 0, 0, 0, time.UTC), 10,
+			[]string{"2026-01-31", "2026-02-28", "2026-03-31"}},
+		{"monthly same day twice", "FREQ=MONTHLY;BYMONTHDAY=31,-1;COUNT=2", time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC), 10,
+			[]string{"2026-01-31", "2026-02-28"}},
+		{"monthly by day", "FREQ=MONTHLY;BYDAY=FR;BYMONTHDAY=13;COUNT=2", time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC), 10,
+			[]string{"2026-02-13", "2026-03-13"}},
+		{"yearly", "FREQ=YEARLY", time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC), 10,
+			[]string{"2026-03-10", "2027-03-10"}},
+		{"yearly leap day", "FREQ=YEARLY;COUNT=2", time.Date(2024, 2, 29, 9, 0, 0, 0, time.UTC), 10,
+			nil},
+		{"yearly by day", "FREQ=YEARLY;BYDAY=MO", time.Date(2026, 12, 20, 9, 0, 0, 0, time.UTC), 3,
+			[]string{"2026-12-21", "2026-12-28", "2027-01-04"}},
+		{"yearly by month day", "FREQ=YEARLY;BYMONTHDAY=-1", time.Date(2027, 10, 1, 9, 0, 0, 0, time.UTC), 10,
+			[]string{"2027-10-31", "2027-11-30", "2027-12-31"}},
+		{"yearly interval", "FREQ=YEARLY;INTERVAL=2", time.Date(2022, 6, 1, 9, 0, 0, 0, time.UTC), 10,
+			[]string{"2026-06-01"}},
+		{"until date-time", "FREQ=WEEKLY;UNTIL=20260119T140000Z", time.Date(2026, 1, 5, 9, 0, 0, 0, newYork), 10,
+			[]string{"2026-01-05", "2026-01-12", "2026-01-19"}},
+		{"until excludes later start", "FREQ=WEEKLY;UNTIL=20260119T135959Z", time.Date(2026, 1, 5, 9, 0, 0, 0, newYork), 10,
+			[]string{"2026-01-05", "2026-01-12"}},
+		{"until date", "FREQ=DAILY;UNTIL=20260103", time.Date(2026, 1, 1, 23, 0, 0, 0, time.UTC), 10,
+			[]string{"2026-01-01", "2026-01-02", "2026-01-03"}},
+	}
+	for _, c := range cases {
+		rule, err := utils.ParseRRule(c.rule)
+		if err != nil {
+			t.Errorf("%s: failed to parse %q: %v", c.name, c.rule, err)
+			continue
+		}
+		var got []string
+		for _, occurrence := range rule.Between(c.dtstart, from, to, c.limit) {
+			if occurrence.Hour() != c.dtstart.Hour() || occurrence.Location() != c.dtstart.Location() {
+				t.Errorf("%s: expected the wall-clock time of dtstart, got %s", c.name, occurrence)
+			}
+			got = append(got, occurrence.Format("2006-01-02"))
+		}
+		if len(got) != len(c.want) {
+			t.Errorf("%s: expected %v, got %v", c.name, c.want, got)
+			continue
+		}
+		for i := range got {
+			if got[i] != c.want[i] {
+				t.Errorf("%s: expected %v, got %v", c.name, c.want, got)
+				break
+			}
+		}
+	}
+}
+
+// TestParseRRuleRejects checks malformed and unsupported rules are refused.
+func TestParseRRuleRejects(t *testing.T) {
+	for _, rule := range []string{
+		"",
+		"INTERVAL=2",
+		"FREQ=HOURLY",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;COUNT=2;UNTIL=20260101",
+		"FREQ=MONTHLY;BYDAY=1MO",
+		"FREQ=MONTHLY;BYMONTHDAY=0",
+		"FREQ=MONTHLY;BYMONTHDAY=-32",
+		"FREQ=WEEKLY;BYMONTHDAY=1",
+		"FREQ=WEEKLY;WKST=SU",
+		"FREQ=YEARLY;BYMONTH=3",
+	} {
+		if _, err := utils.ParseRRule(rule); !errors.Is(err, utils.ErrInvalidRRule) {
+			t.Errorf("Expected %q to be rejected, got %v", rule, err)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/krushnna/meeting-scheduler/models"
+)
+
+func putJSON(router *gin.Engine, path string, payload interface{}) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(payload)
+	req, _ := http.NewRequest("PUT", path, bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	return resp
+}
+
+func getEvent(router *gin.Engine, eventID uint) models.Event {
+	req, _ := http.NewRequest("GET", fmt.Sprintf("/api/v1/events/%d", eventID), nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	var event models.Event
+	json.Unmarshal(resp.Body.Bytes(), &event)
+	return event
+}
+
+// TestRSVP records RSVPs to a finalized event and checks that a required
+// participant declining reopens polling.
+func TestRSVP(t *testing.T) {
+	router, _ := setupTestRouter()
+
+	lead := createTestUser(router, "lead@test.com")
+	guest := createTestUser(router, "guest@test.com")
+	resp := postJSON(router, "/api/v1/events", map[string]interface{}{
+		"title":            "Launch review",
+		"organizer_id":     lead.ID,
+		"duration_minutes": 60,
+		"auto_reschedule":  true,
+	})
+	var event models.Event
+	json.Unmarshal(resp.Body.Bytes(), &event)
+	start := time.Date(2026, 11, 10, 14, 0, 0, 0, time.UTC)
+	createTestTimeSlot(router, event.ID, start, start.Add(time.Hour))
+
+	postJSON(router, fmt.Sprintf("/api/v1/events/%d/participants", event.ID), map[string]interface{}{"user_id": lead.ID, "required": true})
+	for _, user := range []models.User{lead, guest} {
+		createAvailability(router, user.ID, event.ID, start, start.Add(time.Hour))
+	}
+
+	rsvpPath := func(userID uint) string {
+		return fmt.Sprintf("/api/v1/events/%d/participants/%d/rsvp", event.ID, userID)
+	}
+	if resp := putJSON(router, rsvpPath(lead.ID), map[string]string{"response": "accepted"}); resp.Code != http.StatusConflict {
+		t.Errorf("Expected 409 for an RSVP before finalizing, got %d", resp.Code)
+	}
+	if resp := finalizeEvent(router, event.ID, start); resp.Code != http.StatusOK {
+		t.Fatalf("Expected 200 finalizing, got %d: %s", resp.Code, resp.Body.String())
+	}
+
+	if resp := putJSON(router, rsvpPath(lead.ID), map[string]string{"response": "maybe"}); resp.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected 422 for an unknown response, got %d", resp.Code)
+	}
+	if resp := putJSON(router, rsvpPath(lead.ID), map[string]string{"response": "accepted"}); resp.Code != http.StatusOK {
+		t.Fatalf("Expected 200 accepting, got %d: %s", resp.Code, resp.Body.String())
+	}
+	// An optional participant declining leaves the event scheduled.
+	putJSON(router, rsvpPath(guest.ID), map[string]string{"response": "declined"})
+
+	detail := getEvent(router, event.ID)
+	if detail.Status != models.EventStatusScheduled || len(detail.Participants) != 2 {
+		t.Fatalf("Expected a scheduled event with two participants, got %+v", detail)
+	}
+	if detail.Participants[0].RSVP != models.RSVPAccepted || detail.Participants[1].RSVP != models.RSVPDeclined {
+		t.Errorf("Expected accepted and declined RSVPs, got %+v", detail.Participants)
+	}
+
+	if resp := putJSON(router, rsvpPath(lead.ID), map[string]string{"response": "declined"}); resp.Code != http.StatusOK {
+		t.Fatalf("Expected 200 declining, got %d: %s", resp.Code, resp.Body.String())
+	}
+	if reopened := getEvent(router, event.ID); reopened.Status != models.EventStatusPolling || reopened.ScheduledStart != nil {
+		t.Errorf("Expected the event back in polling after the required participant declined, got %+v", reopened)
+	}
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/krushnna/meeting-scheduler/models"
+)
+
+func getOccurrences(t *testing.T, router http.Handler, seriesID uint) []models.Occurrence {
+	req, _ := http.NewRequest("GET", fmt.Sprintf("/api/v1/series/%d/occurrences?from=2026-10-19T00:00:00Z&to=2027-01-01T00:00:00Z", seriesID), nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	if resp.Code != http.StatusOK {
+		t.Fatalf("Expected 200 expanding the series, got %d: %s", resp.Code, resp.Body.String())
+	}
+	var occurrences []models.Occurrence
+	json.Unmarshal(resp.Body.Bytes(), &occurrences)
+	return occurrences
+}
+
+// TestEventSeries expands a weekly series across the end of US daylight saving
+// time, applies exceptions and recommends a time that suits New York and London.
+func TestEventSeries(t *testing.T) {
+	router, _ := setupTestRouter()
+
+	var newYork, london models.User
+	resp := postJSON(router, "/api/v1/users", map[string]string{"name": "Nia", "email": "nia@test.com", "timezone": "America/New_York"})
+	json.Unmarshal(resp.Body.Bytes(), &newYork)
+	resp = postJSON(router, "/api/v1/users", map[string]string{"name": "Leo", "email": "leo@test.com", "timezone": "Europe/London"})
+	json.Unmarshal(resp.Body.Bytes(), &london)
+	postJSON(router, fmt.Sprintf("/api/v1/users/%d/availability-rules", newYork.ID), map[string]interface{}{"weekday": 2, "start_time": "09:00", "end_time": "12:00"})
+	postJSON(router, fmt.Sprintf("/api/v1/users/%d/availability-rules", london.ID), map[string]interface{}{"weekday": 2, "start_time": "14:00", "end_time": "18:00"})
+
+	payload := map[string]interface{}{
+		"title":            "Standup",
+		"organizer_id":     newYork.ID,
+		"duration_minutes": 60,
+		"start_time":       "2026-10-20T16:00:00-04:00",
+		"timezone":         "America/New_York",
+		"rrule":            "FREQ=WEEKLY;BYDAY=TU;COUNT=6",
+		"participant_ids":  []uint{newYork.ID, london.ID},
+	}
+	resp = postJSON(router, "/api/v1/series", payload)
+	if resp.Code != http.StatusCreated {
+		t.Fatalf("Expected 201 creating a series, got %d: %s", resp.Code, resp.Body.String())
+	}
+	var series models.EventSeries
+	json.Unmarshal(resp.Body.Bytes(), &series)
+
+	payload["rrule"] = "FREQ=HOURLY"
+	if resp := postJSON(router, "/api/v1/series", payload); resp.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected 422 for an unsupported rule, got %d", resp.Code)
+	}
+
+	// 4 PM New York is 20:00 UTC in October and 21:00 UTC after 1 November.
+	occurrences := getOccurrences(t, router, series.ID)
+	if len(occurrences) != 6 {
+		t.Fatalf("Expected 6 occurrences, got %d", len(occurrences))
+	}
+	if !occurrences[0].StartTime.Equal(time.Date(2026, 10, 20, 20, 0, 0, 0, time.UTC)) || !occurrences[2].StartTime.Equal(time.Date(2026, 11, 3, 21, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected occurrences to keep 4 PM local time, got %v and %v", occurrences[0].StartTime, occurrences[2].StartTime)
+	}
+
+	exceptionsPath := fmt.Sprintf("/api/v1/series/%d/exceptions", series.ID)
+	if resp := postJSON(router, exceptionsPath, map[string]interface{}{"original_start": "2026-10-27T20:00:00Z", "cancelled": true}); resp.Code != http.StatusCreated {
+		t.Fatalf("Expected 201 cancelling an occurrence, got %d: %s", resp.Code, resp.Body.String())
+	}
+	if resp := postJSON(router, exceptionsPath, map[string]interface{}{"original_start": "2026-11-10T21:00:00Z", "start_time": "2026-11-11T21:00:00Z"}); resp.Code != http.StatusCreated {
+		t.Fatalf("Expected 201 moving an occurrence, got %d: %s", resp.Code, resp.Body.String())
+	}
+	if resp := postJSON(router, exceptionsPath, map[string]interface{}{"original_start": "2026-10-27T20:00:00Z", "cancelled": true}); resp.Code != http.StatusConflict {
+		t.Errorf("Expected 409 for a second exception to one occurrence, got %d", resp.Code)
+	}
+	if resp := postJSON(router, exceptionsPath, map[string]interface{}{"original_start": "2026-10-28T20:00:00Z", "cancelled": true}); resp.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected 422 for a start that is not an occurrence, got %d", resp.Code)
+	}
+
+	occurrences = getOccurrences(t, router, series.ID)
+	if occurrences[1].Status != models.OccurrenceStatusCancelled {
+		t.Errorf("Expected the second occurrence to be cancelled, got %+v", occurrences[1])
+	}
+	if occurrences[3].Status != models.OccurrenceStatusMoved || !occurrences[3].StartTime.Equal(time.Date(2026, 11, 11, 21, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the fourth occurrence to move to Wednesday, got %+v", occurrences[3])
+	}
+
+	// Nia is free 9-12 New York time and Leo 9-13 New York time on Tuesdays, so
+	// 9 AM suits both on the two occurrences left after the cancellation.
+	req, _ := http.NewRequest("GET", fmt.Sprintf("/api/v1/series/%d/recommendations?from=2026-10-19T00:00:00Z&occurrences=2", series.ID), nil)
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	if resp.Code != http.StatusOK {
+		t.Fatalf("Expected 200 for series recommendations, got %d: %s", resp.Code, resp.Body.String())
+	}
+	var recommendations []models.SeriesTimeRecommendation
+	json.Unmarshal(resp.Body.Bytes(), &recommendations)
+	if len(recommendations) == 0 {
+		t.Fatal("Expected at least one recommendation")
+	}
+	best := recommendations[0]
+	if best.StartTime != "09:00" || best.MatchingPercentage != 100 || best.FullyAttendedOccurrences != 2 {
+		t.Errorf("Expected 09:00 with everyone attending both occurrences, got %+v", best)
+	}
+	if len(best.Occurrences) != 2 || !best.Occurrences[1].StartTime.Equal(time.Date(2026, 11, 3, 14, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the second scored occurrence on 3 November, got %+v", best.Occurrences)
+	}
+}
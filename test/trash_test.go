@@ -0,0 +1,195 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/krushnna/meeting-scheduler/models"
+)
+
+// TestTrashRestoreEvent verifies a deleted event can be listed and restored with its children.
+func TestTrashRestoreEvent(t *testing.T) {
+	router, db := setupTestRouter()
+
+	event := createTestEvent(router, "Trash Event", 60)
+	user := createTestUser(router, "trash@test.com")
+	start := time.Now().Add(24 * time.Hour)
+	createTestTimeSlot(router, event.ID, start, start.Add(2*time.Hour))
+	createAvailability(router, user.ID, event.ID, start, start.Add(time.Hour))
+
+	req, _ := http.NewRequest("DELETE", fmt.Sprintf("/api/v1/events/%d", event.ID), nil)
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	req, _ = http.NewRequest("GET", "/api/v1/admin/trash/events", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	var trashed struct {
+		Data  []models.Event `json:"data"`
+		Total int64          `json:"total"`
+	}
+	json.Unmarshal(resp.Body.Bytes(), &trashed)
+	if resp.Code != http.StatusOK || len(trashed.Data) != 1 || trashed.Total != 1 {
+		t.Fatalf("Expected one trashed event, got %s (status %d)", resp.Body.String(), resp.Code)
+	}
+
+	req, _ = http.NewRequest("POST", fmt.Sprintf("/api/v1/admin/trash/events/%d/restore", event.ID), nil)
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	if resp.Code != http.StatusOK {
+		t.Fatalf("Expected 200 on restore, got %d", resp.Code)
+	}
+
+	var slots, availability int64
+	db.Model(&models.TimeSlot{}).Where("event_id = ?", event.ID).Count(&slots)
+	db.Model(&models.UserAvailability{}).Where("event_id = ?", event.ID).Count(&availability)
+	if slots != 1 || availability != 1 {
+		t.Errorf("Expected children restored, got %d slots and %d availability", slots, availability)
+	}
+
+	// Restoring a live event is not possible.
+	req, _ = http.NewRequest("POST", fmt.Sprintf("/api/v1/admin/trash/events/%d/restore", event.ID), nil)
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	if resp.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 restoring a live event, got %d", resp.Code)
+	}
+}
+
+// TestTrashUserEmailReuse verifies deleted users do not block their email and can be purged.
+func TestTrashUserEmailReuse(t *testing.T) {
+	router, db := setupTestRouter()
+
+	original := createTestUser(router, "reuse@test.com")
+	req, _ := http.NewRequest("DELETE", fmt.Sprintf("/api/v1/users/%d", original.ID), nil)
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	replacement := createTestUser(router, "reuse@test.com")
+	if replacement.ID == 0 || replacement.ID == original.ID {
+		t.Fatalf("Expected email of deleted user to be reusable")
+	}
+
+	req, _ = http.NewRequest("POST", fmt.Sprintf("/api/v1/admin/trash/users/%d/restore", original.ID), nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	if resp.Code != http.StatusConflict {
+		t.Errorf("Expected 409 restoring user with reused email, got %d", resp.Code)
+	}
+
+	req, _ = http.NewRequest("DELETE", fmt.Sprintf("/api/v1/admin/trash/users/%d", original.ID), nil)
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	if resp.Code != http.StatusOK {
+		t.Errorf("Expected 200 on purge, got %d", resp.Code)
+	}
+
+	var remaining int64
+	db.Unscoped().Model(&models.User{}).Where("id = ?", original.ID).Count(&remaining)
+	if remaining != 0 {
+		t.Error("Expected purged user to be permanently removed")
+	}
+}
+
+// TestTrashAuditsCascadesAndPurges checks records deleted along with an event or
+// user, and records purged once expired, all leave audit entries.
+func TestTrashAuditsCascadesAndPurges(t *testing.T) {
+	router, db := setupTestRouter()
+
+	event := createTestEvent(router, "Cascade Event", 60)
+	user := createTestUser(router, "cascade@test.com")
+	other := createTestEvent(router, "Other Event", 60)
+	start := time.Now().Add(24 * time.Hour)
+	slot := createTestTimeSlot(router, event.ID, start, start.Add(2*time.Hour))
+	createTestTimeSlot(router, other.ID, start, start.Add(2*time.Hour))
+	createAvailability(router, user.ID, event.ID, start, start.Add(time.Hour))
+	createAvailability(router, user.ID, other.ID, start, start.Add(time.Hour))
+
+	req, _ := http.NewRequest("DELETE", fmt.Sprintf("/api/v1/events/%d", event.ID), nil)
+	router.ServeHTTP(httptest.NewRecorder(), req)
+	req, _ = http.NewRequest("DELETE", fmt.Sprintf("/api/v1/users/%d", user.ID), nil)
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	countAudit := func(entityType, action string) int64 {
+		var count int64
+		db.Model(&models.AuditLog{}).Where("entity_type = ? AND action = ?", entityType, action).Count(&count)
+		return count
+	}
+	var slotDeletes int64
+	db.Model(&models.AuditLog{}).Where("entity_type = ? AND entity_id = ? AND action = ?", "timeslot", slot.ID, "delete").Count(&slotDeletes)
+	if slotDeletes != 1 || countAudit("availability", "delete") != 2 {
+		t.Errorf("Expected the cascaded slot and both availability records audited, got %d and %d", slotDeletes, countAudit("availability", "delete"))
+	}
+
+	// Both were deleted long enough ago to be purged.
+	expired := time.Now().AddDate(0, 0, -60)
+	db.Unscoped().Model(&models.Event{}).Where("id = ?", event.ID).Update("deleted_at", expired)
+	db.Unscoped().Model(&models.User{}).Where("id = ?", user.ID).Update("deleted_at", expired)
+	resp := postJSON(router, "/api/v1/admin/trash/purge", map[string]string{})
+	if resp.Code != http.StatusOK {
+		t.Fatalf("Expected 200 purging, got %d: %s", resp.Code, resp.Body.String())
+	}
+	if countAudit("event", "purge") != 1 || countAudit("user", "purge") != 1 {
+		t.Errorf("Expected the purged event and user audited, got %d and %d", countAudit("event", "purge"), countAudit("user", "purge"))
+	}
+	var notifications int64
+	db.Model(&models.OutboxEvent{}).Where("event_type = ? AND aggregate_id = ?", "event.purged", event.ID).Count(&notifications)
+	if notifications != 1 {
+		t.Errorf("Expected an event.purged notification, got %d", notifications)
+	}
+}
+
+// TestTrashCascadesParticipantsAndVotes checks invitations and votes go to the
+// trash with their event or user and come back on restore, and that a deleted
+// event no longer gets in the way of its invitees' rule changes.
+func TestTrashCascadesParticipantsAndVotes(t *testing.T) {
+	router, db := setupTestRouter()
+
+	user := createTestUser(router, "invitee.trash@test.com")
+	event := createTestEvent(router, "Trashed Invite", 60)
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+	slot := createTestTimeSlot(router, event.ID, start, start.Add(2*time.Hour))
+	if resp := postJSON(router, fmt.Sprintf("/api/v1/events/%d/participants", event.ID), map[string]uint{"user_id": user.ID}); resp.Code != http.StatusCreated {
+		t.Fatalf("Expected 201 inviting, got %d: %s", resp.Code, resp.Body.String())
+	}
+	if resp := putJSON(router, fmt.Sprintf("/api/v1/users/%d/events/%d/votes", user.ID, event.ID),
+		[]map[string]interface{}{{"time_slot_id": slot.ID, "vote": "yes"}}); resp.Code != http.StatusOK {
+		t.Fatalf("Expected 200 voting, got %d: %s", resp.Code, resp.Body.String())
+	}
+	live := func(model interface{}) int64 {
+		var count int64
+		db.Model(model).Where("event_id = ?", event.ID).Count(&count)
+		return count
+	}
+
+	req, _ := http.NewRequest("DELETE", fmt.Sprintf("/api/v1/events/%d", event.ID), nil)
+	router.ServeHTTP(httptest.NewRecorder(), req)
+	if live(&models.EventParticipant{}) != 0 || live(&models.SlotVote{}) != 0 {
+		t.Errorf("Expected the invitation and vote deleted with the event")
+	}
+	if resp := postJSON(router, fmt.Sprintf("/api/v1/users/%d/availability-rules", user.ID), map[string]interface{}{"weekday": 1, "start_time": "09:00", "end_time": "17:00"}); resp.Code != http.StatusCreated {
+		t.Errorf("Expected 201 adding a rule after the event was deleted, got %d: %s", resp.Code, resp.Body.String())
+	}
+	if resp := patchJSON(router, fmt.Sprintf("/api/v1/users/%d", user.ID), `{"timezone": "Europe/Berlin"}`); resp.Code != http.StatusOK {
+		t.Errorf("Expected 200 changing the timezone after the event was deleted, got %d: %s", resp.Code, resp.Body.String())
+	}
+
+	req, _ = http.NewRequest("POST", fmt.Sprintf("/api/v1/admin/trash/events/%d/restore", event.ID), nil)
+	router.ServeHTTP(httptest.NewRecorder(), req)
+	if live(&models.EventParticipant{}) != 1 || live(&models.SlotVote{}) != 1 {
+		t.Fatalf("Expected the invitation and vote restored with the event")
+	}
+
+	req, _ = http.NewRequest("DELETE", fmt.Sprintf("/api/v1/users/%d", user.ID), nil)
+	router.ServeHTTP(httptest.NewRecorder(), req)
+	if live(&models.EventParticipant{}) != 0 || live(&models.SlotVote{}) != 0 {
+		t.Errorf("Expected the invitation and vote deleted with the user")
+	}
+	req, _ = http.NewRequest("POST", fmt.Sprintf("/api/v1/admin/trash/users/%d/restore", user.ID), nil)
+	router.ServeHTTP(httptest.NewRecorder(), req)
+	if live(&models.EventParticipant{}) != 1 || live(&models.SlotVote{}) != 1 {
+		t.Errorf("Expected the invitation and vote restored with the user")
+	}
+}
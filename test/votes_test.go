@@ -0,0 +1,167 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/krushnna/meeting-scheduler/models"
+)
+
+// TestSlotVotes votes on an event's slots, ranks them with the votes strategy and
+// checks the per-slot results.
+func TestSlotVotes(t *testing.T) {
+	router, _ := setupTestRouter()
+
+	alice := createTestUser(router, "alice.votes@test.com")
+	bob := createTestUser(router, "bob.votes@test.com")
+	silent := createTestUser(router, "silent.votes@test.com")
+	event := createTestEvent(router, "Offsite", 60)
+	start := time.Date(2026, 11, 9, 9, 0, 0, 0, time.UTC)
+	monday := createTestTimeSlot(router, event.ID, start, start.Add(time.Hour))
+	tuesday := createTestTimeSlot(router, event.ID, start.Add(24*time.Hour), start.Add(25*time.Hour))
+	other := createTestEvent(router, "Other", 60)
+	foreign := createTestTimeSlot(router, other.ID, start, start.Add(time.Hour))
+
+	if resp := patchJSON(router, fmt.Sprintf("/api/v1/events/%d", event.ID), `{"recommendation_strategy": "votes"}`); resp.Code != http.StatusOK {
+		t.Fatalf("Expected 200 switching to votes, got %d: %s", resp.Code, resp.Body.String())
+	}
+	postJSON(router, fmt.Sprintf("/api/v1/events/%d/participants", event.ID), map[string]uint{"user_id": silent.ID})
+
+	votesPath := func(userID uint) string {
+		return fmt.Sprintf("/api/v1/users/%d/events/%d/votes", userID, event.ID)
+	}
+	ballot := func(votes ...interface{}) []map[string]interface{} {
+		var result []map[string]interface{}
+		for i := 0; i < len(votes); i += 2 {
+			result = append(result, map[string]interface{}{"time_slot_id": votes[i], "vote": votes[i+1]})
+		}
+		return result
+	}
+	if resp := putJSON(router, votesPath(alice.ID), ballot(monday.ID, "yes", tuesday.ID, "maybe")); resp.Code != http.StatusOK {
+		t.Fatalf("Expected 200 voting, got %d: %s", resp.Code, resp.Body.String())
+	}
+	if resp := putJSON(router, votesPath(bob.ID), ballot(monday.ID, "no", tuesday.ID, "yes")); resp.Code != http.StatusOK {
+		t.Fatalf("Expected 200 voting, got %d: %s", resp.Code, resp.Body.String())
+	}
+	if resp := putJSON(router, votesPath(bob.ID), ballot(monday.ID, "perhaps")); resp.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected 422 for an unknown vote, got %d", resp.Code)
+	}
+	if resp := putJSON(router, votesPath(bob.ID), ballot(foreign.ID, "yes")); resp.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 voting on another event's slot, got %d", resp.Code)
+	}
+
+	// Tuesday scores 1.5 of 3 invitees, Monday 1 of 3.
+	recommendations := getRecommendations(router, event.ID)
+	if len(recommendations) != 2 || recommendations[0].TimeSlot.ID != tuesday.ID || recommendations[0].MatchingPercentage != 50 {
+		t.Fatalf("Expected Tuesday first at 50%%, got %+v", recommendations)
+	}
+	if votes := recommendations[0].Votes; votes == nil || votes.Yes != 1 || votes.Maybe != 1 || votes.No != 0 {
+		t.Errorf("Expected a 1/1/0 tally for Tuesday, got %+v", votes)
+	}
+	if len(recommendations[1].StartOptions) != 1 || !recommendations[1].StartOptions[0].Equal(start) {
+		t.Errorf("Expected Monday's start as its only option, got %v", recommendations[1].StartOptions)
+	}
+
+	req, _ := http.NewRequest("GET", fmt.Sprintf("/api/v1/events/%d/votes", event.ID), nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	var results []models.SlotVoteResult
+	json.Unmarshal(resp.Body.Bytes(), &results)
+	if len(results) != 2 || results[0].TimeSlot.ID != monday.ID {
+		t.Fatalf("Expected results for both slots in start order, got %s", resp.Body.String())
+	}
+	if len(results[0].Yes) != 1 || results[0].Yes[0].ID != alice.ID || len(results[0].No) != 1 || results[0].No[0].ID != bob.ID || results[0].Score != 1 {
+		t.Errorf("Expected Monday yes from Alice and no from Bob, got %+v", results[0])
+	}
+	if results[1].Score != 1.5 {
+		t.Errorf("Expected Tuesday to score 1.5, got %v", results[1].Score)
+	}
+
+	// Withdrawing every vote makes Bob a non-responder again.
+	if resp := putJSON(router, votesPath(bob.ID), []interface{}{}); resp.Code != http.StatusOK {
+		t.Fatalf("Expected 200 withdrawing votes, got %d: %s", resp.Code, resp.Body.String())
+	}
+	req, _ = http.NewRequest("GET", fmt.Sprintf("/api/v1/events/%d/participants", event.ID), nil)
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	var participants []models.EventParticipant
+	json.Unmarshal(resp.Body.Bytes(), &participants)
+	for _, participant := range participants {
+		want := models.ParticipantStatusInvited
+		if participant.UserID == alice.ID {
+			want = models.ParticipantStatusResponded
+		}
+		if participant.Status != want {
+			t.Errorf("Expected user %d %s, got %s", participant.UserID, want, participant.Status)
+		}
+	}
+	if len(participants) != 3 {
+		t.Errorf("Expected three participants, got %s", resp.Body.String())
+	}
+}
+
+// TestVotesRespectCommitments checks a yes voter who would be double-booked or
+// inside their buffer doesn't count as matching under the votes strategy.
+func TestVotesRespectCommitments(t *testing.T) {
+	router, _ := setupTestRouter()
+
+	alice := createTestUser(router, "alice.busyvotes@test.com")
+	bob := createTestUser(router, "bob.busyvotes@test.com")
+	start := time.Date(2026, 11, 30, 9, 0, 0, 0, time.UTC)
+
+	// Bob has a meeting from 8:30 and then one at 14:00.
+	for _, at := range []time.Time{start.Add(-30 * time.Minute), start.Add(5 * time.Hour)} {
+		busy := createTestEvent(router, "Busy", 30)
+		createTestTimeSlot(router, busy.ID, at, at.Add(30*time.Minute))
+		createAvailability(router, bob.ID, busy.ID, at, at.Add(30*time.Minute))
+		if resp := finalizeEvent(router, busy.ID, at); resp.Code != http.StatusOK {
+			t.Fatalf("Expected 200 finalizing, got %d: %s", resp.Code, resp.Body.String())
+		}
+	}
+	if resp := patchJSON(router, fmt.Sprintf("/api/v1/users/%d", bob.ID), `{"buffer_before_minutes": 15}`); resp.Code != http.StatusOK {
+		t.Fatalf("Expected 200 setting a buffer, got %d: %s", resp.Code, resp.Body.String())
+	}
+
+	event := createTestEvent(router, "Vote", 60)
+	patchJSON(router, fmt.Sprintf("/api/v1/events/%d", event.ID), `{"recommendation_strategy": "votes"}`)
+	morning := createTestTimeSlot(router, event.ID, start, start.Add(time.Hour))
+	afternoon := createTestTimeSlot(router, event.ID, start.Add(5*time.Hour), start.Add(6*time.Hour))
+	for _, user := range []models.User{alice, bob} {
+		putJSON(router, fmt.Sprintf("/api/v1/users/%d/events/%d/votes", user.ID, event.ID), []map[string]interface{}{
+			{"time_slot_id": morning.ID, "vote": "yes"},
+			{"time_slot_id": afternoon.ID, "vote": "yes"},
+		})
+	}
+
+	// The morning starts right after Bob's meeting, inside his buffer.
+	recommendations := getRecommendations(router, event.ID)
+	if len(recommendations) != 1 || recommendations[0].TimeSlot.ID != morning.ID {
+		t.Fatalf("Expected only the morning recommended, got %+v", recommendations)
+	}
+	morningRecommendation := recommendations[0]
+	if len(morningRecommendation.MatchingUsers) != 1 || morningRecommendation.MatchingUsers[0].ID != alice.ID || morningRecommendation.MatchingPercentage != 50 || morningRecommendation.Votes.Yes != 2 {
+		t.Errorf("Expected only Alice matching at 50%% with both yes votes tallied, got %+v", morningRecommendation)
+	}
+
+	// The afternoon clashes with Bob's meeting, which the explanation names.
+	req, _ := http.NewRequest("GET", fmt.Sprintf("/api/v1/events/%d/recommendations?explain=true", event.ID), nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	var explained []models.TimeSlotRecommendation
+	json.Unmarshal(resp.Body.Bytes(), &explained)
+	for _, recommendation := range explained {
+		if recommendation.TimeSlot.ID != afternoon.ID {
+			continue
+		}
+		explanation := recommendation.Explanations[0]
+		if !explanation.Conflicted || len(explanation.Excluded) != 1 || explanation.Excluded[0].User.ID != bob.ID || explanation.Excluded[0].Reasons[0].Reason != models.ExclusionConflict {
+			t.Errorf("Expected Bob excluded for a conflict in the afternoon, got %+v", explanation)
+		}
+		return
+	}
+	t.Errorf("Expected the afternoon explained, got %s", resp.Body.String())
+}
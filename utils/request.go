@@ -0,0 +1,31 @@
+package utils
+
+import "context"
+
+// Actors recorded when a change is not made by an identified caller
+const (
+	SystemActor    = "system"
+	AnonymousActor = "anonymous"
+)
+
+type requestMetaKey struct{}
+
+// RequestMeta identifies the request and caller behind a change
+type RequestMeta struct {
+	RequestID string
+	Actor     string
+}
+
+// WithRequestMeta returns a context carrying meta
+func WithRequestMeta(ctx context.Context, meta RequestMeta) context.Context {
+	return context.WithValue(ctx, requestMetaKey{}, meta)
+}
+
+// RequestMetaFrom returns the meta stored in ctx; the actor defaults to anonymous
+func RequestMetaFrom(ctx context.Context) RequestMeta {
+	meta, _ := ctx.Value(requestMetaKey{}).(RequestMeta)
+	if meta.Actor == "" {
+		meta.Actor = AnonymousActor
+	}
+	return meta
+}
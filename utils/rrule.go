@@ -0,0 +1,266 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidRRule is returned for recurrence rules that cannot be parsed or use
+// parts this implementation does not support
+var ErrInvalidRRule = errors.New("invalid recurrence rule")
+
+// Recurrence frequencies
+const (
+	FreqDaily   = "DAILY"
+	FreqWeekly  = "WEEKLY"
+	FreqMonthly = "MONTHLY"
+	FreqYearly  = "YEARLY"
+)
+
+// maxRRulePeriods bounds expansion of rules that never produce an instant
+const maxRRulePeriods = 100000
+
+var rruleWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+// RRule is a parsed RFC 5545 recurrence rule. FREQ, INTERVAL, COUNT, UNTIL,
+// BYDAY (without ordinals), BYMONTHDAY and WKST=MO are supported. BYDAY and
+// BYMONTHDAY expand MONTHLY and YEARLY rules to every matching day of the
+// period and filter DAILY ones; WEEKLY rules cannot use BYMONTHDAY.
+type RRule struct {
+	Freq       string
+	Interval   int
+	Count      int
+	Until      time.Time
+	ByDay      []time.Weekday
+	ByMonthDay []int
+}
+
+// ParseRRule parses a rule such as "FREQ=WEEKLY;BYDAY=MO,WE;COUNT=10". An
+// "RRULE:" prefix is accepted. A date-only UNTIL includes the whole day in UTC.
+func ParseRRule(value string) (*RRule, error) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "RRULE:")
+	rule := &RRule{Interval: 1}
+
+	for _, part := range strings.Split(value, ";") {
+		name, arg, ok := strings.Cut(part, "=")
+		if !ok || arg == "" {
+			return nil, fmt.Errorf("%w: malformed part %q", ErrInvalidRRule, part)
+		}
+		switch strings.ToUpper(name) {
+		case "FREQ":
+			rule.Freq = strings.ToUpper(arg)
+			if rule.Freq != FreqDaily && rule.Freq != FreqWeekly && rule.Freq != FreqMonthly && rule.Freq != FreqYearly {
+				return nil, fmt.Errorf("%w: unsupported FREQ %q", ErrInvalidRRule, arg)
+			}
+		case "INTERVAL":
+			interval, err := strconv.Atoi(arg)
+			if err != nil || interval < 1 {
+				return nil, fmt.Errorf("%w: INTERVAL must be a positive integer", ErrInvalidRRule)
+			}
+			rule.Interval = interval
+		case "COUNT":
+			count, err := strconv.Atoi(arg)
+			if err != nil || count < 1 {
+				return nil, fmt.Errorf("%w: COUNT must be a positive integer", ErrInvalidRRule)
+			}
+			rule.Count = count
+		case "UNTIL":
+			until, err := time.Parse("20060102T150405Z", arg)
+			if err != nil {
+				date, dateErr := time.Parse("20060102", arg)
+				if dateErr != nil {
+					return nil, fmt.Errorf("%w: UNTIL must be a UTC date-time or a date", ErrInvalidRRule)
+				}
+				until = date.Add(24*time.Hour - time.Second)
+			}
+			rule.Until = until
+		case "BYDAY":
+			for _, day := range strings.Split(strings.ToUpper(arg), ",") {
+				weekday, ok := rruleWeekdays[day]
+				if !ok {
+					return nil, fmt.Errorf("%w: unsupported BYDAY value %q", ErrInvalidRRule, day)
+				}
+				rule.ByDay = append(rule.ByDay, weekday)
+			}
+		case "BYMONTHDAY":
+			for _, day := range strings.Split(arg, ",") {
+				monthDay, err := strconv.Atoi(day)
+				if err != nil || monthDay == 0 || monthDay < -31 || monthDay > 31 {
+					return nil, fmt.Errorf("%w: invalid BYMONTHDAY value %q", ErrInvalidRRule, day)
+				}
+				rule.ByMonthDay = append(rule.ByMonthDay, monthDay)
+			}
+		case "WKST":
+			if strings.ToUpper(arg) != "MO" {
+				return nil, fmt.Errorf("%w: only WKST=MO is supported", ErrInvalidRRule)
+			}
+		default:
+			return nil, fmt.Errorf("%w: unsupported part %s", ErrInvalidRRule, name)
+		}
+	}
+
+	if rule.Freq == "" {
+		return nil, fmt.Errorf("%w: FREQ is required", ErrInvalidRRule)
+	}
+	if rule.Count > 0 && !rule.Until.IsZero() {
+		return nil, fmt.Errorf("%w: COUNT and UNTIL cannot both be set", ErrInvalidRRule)
+	}
+	if rule.Freq == FreqWeekly && len(rule.ByMonthDay) > 0 {
+		return nil, fmt.Errorf("%w: BYMONTHDAY cannot be used with FREQ=WEEKLY", ErrInvalidRRule)
+	}
+	return rule, nil
+}
+
+// Between returns the occurrences of the rule starting at dtstart that fall in
+// [from, to), at most limit of them. Occurrences keep the wall-clock time of
+// dtstart in its location, so they do not drift across DST transitions.
+func (r *RRule) Between(dtstart, from, to time.Time, limit int) []time.Time {
+	var occurrences []time.Time
+	seen := 0
+	for period := 0; period < maxRRulePeriods; period++ {
+		for _, occurrence := range r.periodInstants(dtstart, period) {
+			if occurrence.Before(dtstart) {
+				continue
+			}
+			if !r.Until.IsZero() && occurrence.After(r.Until) {
+				return occurrences
+			}
+			if !occurrence.Before(to) {
+				return occurrences
+			}
+			seen++
+			if r.Count > 0 && seen > r.Count {
+				return occurrences
+			}
+			if !occurrence.Before(from) {
+				occurrences = append(occurrences, occurrence)
+				if len(occurrences) >= limit {
+					return occurrences
+				}
+			}
+		}
+	}
+	return occurrences
+}
+
+// periodInstants lists the candidate instants of the n-th period after dtstart, in order
+func (r *RRule) periodInstants(dtstart time.Time, n int) []time.Time {
+	step := n * r.Interval
+	at := func(year int, month time.Month, day int) (time.Time, bool) {
+		instant := time.Date(year, month, day, dtstart.Hour(), dtstart.Minute(), dtstart.Second(), 0, dtstart.Location())
+		return instant, instant.Day() == day && instant.Month() == month
+	}
+
+	var days []time.Time
+	switch r.Freq {
+	case FreqDaily:
+		day, _ := at(dtstart.Year(), dtstart.Month(), dtstart.Day()+step)
+		days = append(days, day)
+	case FreqWeekly:
+		// Weeks start on Monday; days past the end of the month roll over.
+		offset := (int(dtstart.Weekday()) + 6) % 7
+		monday := dtstart.Day() - offset + 7*step
+		weekdays := r.ByDay
+		if len(weekdays) == 0 {
+			weekdays = []time.Weekday{dtstart.Weekday()}
+		}
+		for _, weekday := range weekdays {
+			day, _ := at(dtstart.Year(), dtstart.Month(), monday+(int(weekday)+6)%7)
+			days = append(days, day)
+		}
+	case FreqMonthly:
+		first := time.Date(dtstart.Year(), dtstart.Month()+time.Month(step), 1, 0, 0, 0, 0, dtstart.Location())
+		days = r.monthInstants(dtstart, first.Year(), first.Month(), at)
+	case FreqYearly:
+		if len(r.ByDay) == 0 && len(r.ByMonthDay) == 0 {
+			if day, ok := at(dtstart.Year()+step, dtstart.Month(), dtstart.Day()); ok {
+				days = append(days, day)
+			}
+			break
+		}
+		for month := time.January; month <= time.December; month++ {
+			days = append(days, r.monthInstants(dtstart, dtstart.Year()+step, month, at)...)
+		}
+	}
+
+	var instants []time.Time
+	for _, day := range days {
+		if r.matches(day) {
+			instants = append(instants, day)
+		}
+	}
+	sort.Slice(instants, func(i, j int) bool { return instants[i].Before(instants[j]) })
+	// BYMONTHDAY=-1,31 names the same day in long months.
+	unique := instants[:0]
+	for _, instant := range instants {
+		if len(unique) == 0 || !instant.Equal(unique[len(unique)-1]) {
+			unique = append(unique, instant)
+		}
+	}
+	return unique
+}
+
+// monthInstants expands one month of a MONTHLY or YEARLY rule
+func (r *RRule) monthInstants(dtstart time.Time, year int, month time.Month, at func(int, time.Month, int) (time.Time, bool)) []time.Time {
+	var days []time.Time
+	switch {
+	case len(r.ByMonthDay) > 0:
+		lastDay := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+		for _, monthDay := range r.ByMonthDay {
+			if monthDay < 0 {
+				monthDay = lastDay + monthDay + 1
+			}
+			if day, ok := at(year, month, monthDay); ok {
+				days = append(days, day)
+			}
+		}
+	case len(r.ByDay) > 0:
+		for monthDay := 1; monthDay <= 31; monthDay++ {
+			if day, ok := at(year, month, monthDay); ok {
+				days = append(days, day)
+			}
+		}
+	default:
+		if day, ok := at(year, month, dtstart.Day()); ok {
+			days = append(days, day)
+		}
+	}
+	return days
+}
+
+// matches applies the BYDAY and BYMONTHDAY filters to a candidate instant
+func (r *RRule) matches(instant time.Time) bool {
+	if len(r.ByDay) > 0 {
+		found := false
+		for _, weekday := range r.ByDay {
+			if instant.Weekday() == weekday {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(r.ByMonthDay) > 0 && r.Freq == FreqDaily {
+		lastDay := time.Date(instant.Year(), instant.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+		found := false
+		for _, monthDay := range r.ByMonthDay {
+			if monthDay == instant.Day() || lastDay+monthDay+1 == instant.Day() {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}